@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	service "github.com/benrod407/explore-service/internal"
+)
+
+func reconcileLikeStats(ctx context.Context, store *service.MySQLStore, args []string) error {
+	config := service.DefaultLikeStatsReconcilerConfig()
+
+	flags := flag.NewFlagSet("like-stats reconcile", flag.ContinueOnError)
+	flags.BoolVar(&config.Repair, "repair", false, "overwrite the drifted counts")
+	flags.IntVar(&config.ChunkSize, "chunk-size", config.ChunkSize, "users checked per query")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 || config.ChunkSize <= 0 {
+		return errors.New("like-stats reconcile takes no arguments and a positive -chunk-size")
+	}
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(out, "USER\tCACHED\tCOUNTED")
+
+	report, err := service.NewLikeStatsReconciler(store, config).Reconcile(ctx, func(count service.LikeCount) error {
+		_, err := fmt.Fprintf(out, "%s\t%d\t%d\n", count.UserID, count.Cached, count.Actual)
+		return err
+	})
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d users checked, %d drifted, %d repaired\n", report.UsersChecked, report.Drifted, report.Repaired)
+	return nil
+}
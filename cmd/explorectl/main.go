@@ -0,0 +1,90 @@
+// explorectl runs maintenance commands against the MySQL database of the explore service.
+// It reads the same MYSQL_* environment variables as the server
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	service "github.com/benrod407/explore-service/internal"
+)
+
+// command is a subcommand, args are the arguments after its name
+type command struct {
+	usage string
+	run   func(ctx context.Context, store *service.MySQLStore, args []string) error
+}
+
+var commands = map[string]command{
+	"like-stats reconcile": {
+		usage: "[-repair] [-chunk-size n] list the users whose like_stats differ from their decisions, and repair them",
+		run:   reconcileLikeStats,
+	},
+	"users export": {
+		usage: "[-o file] <user_id> write everything stored about the user as NDJSON, to stdout by default",
+		run:   exportUserData,
+	},
+	"webhooks dead-letters": {
+		usage: "list the webhook deliveries that failed every attempt",
+		run:   listWebhookDeadLetters,
+	},
+	"webhooks replay": {
+		usage: "[-all] [id ...] schedule dead letters again, by id or all of them",
+		run:   replayWebhookDeadLetters,
+	},
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("explorectl: ")
+
+	if len(os.Args) < 3 {
+		usage()
+	}
+	cmd, ok := commands[os.Args[1]+" "+os.Args[2]]
+	if !ok {
+		usage()
+	}
+
+	ctx := context.Background()
+	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
+		getEnv("MYSQL_USER", "root"),
+		getEnv("MYSQL_PASSWORD", "rootsecret"),
+		getEnv("MYSQL_HOST", "127.0.0.1"),
+		getEnv("MYSQL_PORT", "3306"),
+		getEnv("MYSQL_DATABASE", "myapp_db"),
+	)
+	db, err := service.NewDB(ctx, dataSourceName)
+	if err != nil {
+		log.Fatalf("failed to connect to db: %v", err)
+	}
+	defer db.Close()
+
+	if err := cmd.run(ctx, service.NewMySQLStore(db), os.Args[3:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: explorectl <command> [arguments]")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, commands[name].usage)
+	}
+	os.Exit(2)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	service "github.com/benrod407/explore-service/internal"
+)
+
+func exportUserData(ctx context.Context, store *service.MySQLStore, args []string) error {
+	flags := flag.NewFlagSet("users export", flag.ContinueOnError)
+	output := flags.String("o", "", "write the export to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("users export takes the id of the user")
+	}
+	userID := flags.Arg(0)
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	buffered := bufio.NewWriter(w)
+	err := service.WriteUserDataNDJSON(ctx, service.NewExploreBusiness(store, nil), userID, buffered)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil && *output != "" {
+		// don't leave a partial export behind
+		os.Remove(*output)
+	}
+	return err
+}
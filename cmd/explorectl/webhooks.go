@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	service "github.com/benrod407/explore-service/internal"
+)
+
+// deadLetterPageSize is the amount of dead letters read per query
+const deadLetterPageSize = 100
+
+func listWebhookDeadLetters(ctx context.Context, store *service.MySQLStore, args []string) error {
+	if len(args) > 0 {
+		return errors.New("webhooks dead-letters takes no arguments")
+	}
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(out, "ID\tEVENT\tENDPOINT\tATTEMPTS\tENQUEUED\tLAST ERROR")
+
+	var afterID uint64
+	for {
+		deliveries, err := store.ListWebhookDeadLetters(ctx, afterID, deadLetterPageSize)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			enqueued := time.Unix(int64(delivery.UnixTimestamp), 0).UTC().Format(time.RFC3339)
+			fmt.Fprintf(out, "%d\t%d\t%s\t%d\t%s\t%s\n",
+				delivery.ID, delivery.EventID, delivery.Endpoint, delivery.Attempts, enqueued, delivery.LastError)
+			afterID = delivery.ID
+		}
+		if len(deliveries) < deadLetterPageSize {
+			break
+		}
+	}
+	return out.Flush()
+}
+
+func replayWebhookDeadLetters(ctx context.Context, store *service.MySQLStore, args []string) error {
+	flags := flag.NewFlagSet("webhooks replay", flag.ContinueOnError)
+	all := flags.Bool("all", false, "replay every dead letter")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var ids []uint64
+	for _, arg := range flags.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil || id == 0 {
+			return fmt.Errorf("invalid dead letter id %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	if *all == (len(ids) > 0) {
+		return errors.New("webhooks replay takes either -all or the ids of the dead letters")
+	}
+
+	requeued, err := store.RequeueWebhookDeadLetters(ctx, ids)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("requeued %d dead letters, the server dispatches them on its next poll\n", requeued)
+	return nil
+}
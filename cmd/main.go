@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/benrod407/explore-service/explore_service_proto"
 	service "github.com/benrod407/explore-service/internal"
@@ -14,21 +19,44 @@ import (
 
 func main() {
 
-	dbName := getEnv("MYSQL_DATABASE", "myapp_db")
-	dbHost := getEnv("MYSQL_HOST", "127.0.0.1")
-	dbPort := getEnv("MYSQL_PORT", "3306")
-	dbUser := getEnv("MYSQL_USER", "root")
-	dbPassword := getEnv("MYSQL_PASSWORD", "rootsecret")
+	storeType := getEnv("STORE", "mysql")
 
 	ctx := context.Background()
 
-	// connect to DB instance
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
-	dbInstance, err := service.NewDB(ctx, dataSourceName)
-	if err != nil {
-		log.Fatalf("failed to connect to db: %v", err)
+	// select the storage backend
+	var store service.DecisionStore
+	var feed service.DecisionEventFeed
+	var outbox service.OutboxStore
+	var webhooks service.WebhookStore
+	var keys service.IdempotencyKeyStore
+	var likeStats service.LikeStatsStore
+	switch storeType {
+	case "mysql":
+		dbName := getEnv("MYSQL_DATABASE", "myapp_db")
+		dbHost := getEnv("MYSQL_HOST", "127.0.0.1")
+		dbPort := getEnv("MYSQL_PORT", "3306")
+		dbUser := getEnv("MYSQL_USER", "root")
+		dbPassword := getEnv("MYSQL_PASSWORD", "rootsecret")
+
+		// connect to DB instance
+		dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
+		dbInstance, err := service.NewDB(ctx, dataSourceName)
+		if err != nil {
+			log.Fatalf("failed to connect to db: %v", err)
+		}
+		defer dbInstance.Close()
+		mysqlStore := service.NewMySQLStore(dbInstance)
+		store, feed, outbox, webhooks, keys, likeStats = mysqlStore, mysqlStore, mysqlStore, mysqlStore, mysqlStore, mysqlStore
+	case "memory":
+		memoryStore := service.NewMemoryStore()
+		if err := service.SeedDemoData(ctx, memoryStore); err != nil {
+			log.Fatalf("failed to seed memory store: %v", err)
+		}
+		log.Print("using in-memory store, data will be lost on exit")
+		store, feed, outbox, webhooks, keys, likeStats = memoryStore, memoryStore, memoryStore, memoryStore, memoryStore, memoryStore
+	default:
+		log.Fatalf("unknown STORE %q, expected mysql or memory", storeType)
 	}
-	defer dbInstance.Close()
 
 	// initialice explore-service server
 	lis, err := net.Listen("tcp", ":9001")
@@ -40,11 +68,70 @@ func main() {
 	grpcServer := grpc.NewServer()
 
 	// Create business logic layer
-	business := service.NewExploreBusiness(dbInstance)
+	business := service.NewExploreBusiness(store, newTokenSigner())
+
+	// Keep PutDecision idempotency keys for IDEMPOTENCY_KEY_TTL, purging the expired ones hourly
+	idempotencyTTL, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", service.DefaultIdempotencyTTL.String()))
+	if err != nil || idempotencyTTL <= 0 {
+		log.Fatalf("invalid IDEMPOTENCY_KEY_TTL: must be a positive duration")
+	}
+	business.SetIdempotencyTTL(idempotencyTTL)
+	go service.PurgeExpiredIdempotencyKeys(ctx, keys, time.Hour)
+
+	// Leave the likes older than LIKES_MAX_AGE out of the liked-you lists and counts, when set
+	likesMaxAge, err := time.ParseDuration(getEnv("LIKES_MAX_AGE", "0s"))
+	if err != nil || likesMaxAge < 0 {
+		log.Fatalf("invalid LIKES_MAX_AGE: must be a non-negative duration")
+	}
+	business.SetLikesMaxAge(likesMaxAge)
+
+	// Stop the windowed like counts, which can't read the like_stats cache, at LIKES_COUNT_CAP likes, 0 counts every like
+	likesCountCap, err := strconv.ParseUint(getEnv("LIKES_COUNT_CAP", strconv.Itoa(service.DefaultLikesCountCap)), 10, 64)
+	if err != nil {
+		log.Fatalf("invalid LIKES_COUNT_CAP: must be a non-negative integer")
+	}
+	business.SetLikesCountCap(likesCountCap)
+
+	// Delete the users requested with DeleteUser, one chunk per transaction
+	go service.NewUserEraser(store, newUserEraserConfig()).Run(ctx)
+
+	// Check the like_stats counters against the decisions, when enabled
+	if config, enabled := newLikeStatsReconcilerConfig(); enabled {
+		go service.NewLikeStatsReconciler(likeStats, config).Run(ctx)
+	}
+
+	// Tail the decision history for WatchLikes streams
+	watcher := service.NewLikeWatcher(feed, newWatchConfig())
+	if err := watcher.Start(ctx); err != nil {
+		log.Fatalf("failed to start like watcher: %v", err)
+	}
+	business.AttachLikeWatcher(watcher)
+
+	// Relay the outbox domain events, the relay enqueues the match webhooks and the dispatcher calls them
+	var sinks service.MultiSink
+	if sink := newEventSink(); sink != nil {
+		sinks = append(sinks, sink)
+	}
+	if webhookConfig := newWebhookConfig(); len(webhookConfig.Endpoints) > 0 {
+		sinks = append(sinks, service.NewWebhookSink(webhooks, webhookConfig.Endpoints))
+		go service.NewWebhookDispatcher(webhooks, webhookConfig).Run(ctx)
+	}
+	if len(sinks) > 0 {
+		relay := service.NewOutboxRelay(outbox, sinks, service.DefaultOutboxRelayConfig())
+		go relay.Run(ctx)
+	}
+
+	// Delete the events published more than OUTBOX_RETENTION ago, hourly
+	outboxRetention, err := time.ParseDuration(getEnv("OUTBOX_RETENTION", service.DefaultOutboxRetention.String()))
+	if err != nil || outboxRetention <= 0 {
+		log.Fatalf("invalid OUTBOX_RETENTION: must be a positive duration")
+	}
+	go service.PurgePublishedOutboxEvents(ctx, outbox, outboxRetention, time.Hour)
 
 	// Create gRPC handler with business logic dependency
 	pb.RegisterExploreServiceServer(grpcServer, &service.ExploreService{
-		Business: business,
+		Business:  business,
+		Validator: service.NewRequestValidator(newValidationConfig()),
 	})
 
 	if err := grpcServer.Serve(lis); err != nil {
@@ -52,6 +139,165 @@ func main() {
 	}
 }
 
+// newTokenSigner builds the pagination token signer from PAGINATION_TOKEN_SECRET and PAGINATION_TOKEN_TTL.
+// Without a secret a random one is generated, tokens then only work against this server instance
+func newTokenSigner() *service.TokenSigner {
+	ttl, err := time.ParseDuration(getEnv("PAGINATION_TOKEN_TTL", "1h"))
+	if err != nil {
+		log.Fatalf("invalid PAGINATION_TOKEN_TTL: %v", err)
+	}
+
+	secret := []byte(os.Getenv("PAGINATION_TOKEN_SECRET"))
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("failed to generate pagination token secret: %v", err)
+		}
+		log.Print("PAGINATION_TOKEN_SECRET not set, using a random secret")
+	}
+
+	return service.NewTokenSigner(secret, ttl)
+}
+
+// newValidationConfig reads the request validation bounds from MAX_PAGE_SIZE, MAX_BATCH_SIZE and REQUIRE_UUID_USER_IDS
+func newValidationConfig() service.ValidationConfig {
+	config := service.DefaultValidationConfig()
+
+	maxPageSize, err := strconv.ParseUint(getEnv("MAX_PAGE_SIZE", strconv.FormatUint(uint64(config.MaxPageSize), 10)), 10, 32)
+	if err != nil || maxPageSize == 0 {
+		log.Fatalf("invalid MAX_PAGE_SIZE: must be a positive integer")
+	}
+	config.MaxPageSize = uint32(maxPageSize)
+
+	maxBatchSize, err := strconv.Atoi(getEnv("MAX_BATCH_SIZE", strconv.Itoa(config.MaxBatchSize)))
+	if err != nil || maxBatchSize <= 0 {
+		log.Fatalf("invalid MAX_BATCH_SIZE: must be a positive integer")
+	}
+	config.MaxBatchSize = maxBatchSize
+
+	requireUUIDs, err := strconv.ParseBool(getEnv("REQUIRE_UUID_USER_IDS", strconv.FormatBool(config.RequireUUIDs)))
+	if err != nil {
+		log.Fatalf("invalid REQUIRE_UUID_USER_IDS: %v", err)
+	}
+	config.RequireUUIDs = requireUUIDs
+
+	return config
+}
+
+// newWatchConfig reads the WatchLikes polling settings from WATCH_POLL_INTERVAL, WATCH_GAP_TIMEOUT
+// and WATCH_LATE_EVENT_TIMEOUT
+func newWatchConfig() service.WatchConfig {
+	config := service.DefaultWatchConfig()
+
+	pollInterval, err := time.ParseDuration(getEnv("WATCH_POLL_INTERVAL", config.PollInterval.String()))
+	if err != nil || pollInterval <= 0 {
+		log.Fatalf("invalid WATCH_POLL_INTERVAL: must be a positive duration")
+	}
+	config.PollInterval = pollInterval
+
+	gapTimeout, err := time.ParseDuration(getEnv("WATCH_GAP_TIMEOUT", config.GapTimeout.String()))
+	if err != nil || gapTimeout < 0 {
+		log.Fatalf("invalid WATCH_GAP_TIMEOUT: must be a non negative duration")
+	}
+	config.GapTimeout = gapTimeout
+
+	lateEventTimeout, err := time.ParseDuration(getEnv("WATCH_LATE_EVENT_TIMEOUT", config.LateEventTimeout.String()))
+	if err != nil || lateEventTimeout < 0 {
+		log.Fatalf("invalid WATCH_LATE_EVENT_TIMEOUT: must be a non negative duration")
+	}
+	config.LateEventTimeout = lateEventTimeout
+
+	return config
+}
+
+// newUserEraserConfig reads the rows deleted per transaction of a user deletion from USER_DELETION_CHUNK_SIZE
+func newUserEraserConfig() service.UserEraserConfig {
+	config := service.DefaultUserEraserConfig()
+
+	chunkSize, err := strconv.Atoi(getEnv("USER_DELETION_CHUNK_SIZE", strconv.Itoa(config.ChunkSize)))
+	if err != nil || chunkSize <= 0 {
+		log.Fatalf("invalid USER_DELETION_CHUNK_SIZE: must be a positive integer")
+	}
+	config.ChunkSize = chunkSize
+
+	return config
+}
+
+// newLikeStatsReconcilerConfig reads the like_stats reconciliation schedule from LIKE_STATS_RECONCILE_INTERVAL,
+// disabled when empty, and LIKE_STATS_REPAIR to repair the drifted counts instead of only logging them
+func newLikeStatsReconcilerConfig() (service.LikeStatsReconcilerConfig, bool) {
+	config := service.DefaultLikeStatsReconcilerConfig()
+
+	interval := os.Getenv("LIKE_STATS_RECONCILE_INTERVAL")
+	if interval == "" {
+		return config, false
+	}
+	var err error
+	config.Interval, err = time.ParseDuration(interval)
+	if err != nil || config.Interval <= 0 {
+		log.Fatalf("invalid LIKE_STATS_RECONCILE_INTERVAL: must be a positive duration")
+	}
+
+	config.Repair, err = strconv.ParseBool(getEnv("LIKE_STATS_REPAIR", "false"))
+	if err != nil {
+		log.Fatalf("invalid LIKE_STATS_REPAIR: must be a boolean")
+	}
+
+	return config, true
+}
+
+// newEventSink selects where the outbox relay publishes domain events from OUTBOX_SINK:
+// stdout (default), none to disable the relay, or the path of a file events are appended to
+func newEventSink() service.EventSink {
+	switch target := getEnv("OUTBOX_SINK", "stdout"); target {
+	case "none":
+		log.Print("OUTBOX_SINK is none, domain events stay in the outbox")
+		return nil
+	case "stdout":
+		return service.NewWriterSink(os.Stdout)
+	default:
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Fatalf("failed to open OUTBOX_SINK file: %v", err)
+		}
+		return service.NewWriterSink(file)
+	}
+}
+
+// newWebhookConfig reads the match webhooks from WEBHOOK_URLS (comma separated), WEBHOOK_SECRET
+// and WEBHOOK_MAX_ATTEMPTS. Webhooks are disabled when WEBHOOK_URLS is empty
+func newWebhookConfig() service.WebhookConfig {
+	config := service.DefaultWebhookConfig()
+
+	for _, endpoint := range strings.Split(os.Getenv("WEBHOOK_URLS"), ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(endpoint) > 512 {
+			log.Fatalf("invalid WEBHOOK_URLS: %q must be an http(s) URL of at most 512 characters", endpoint)
+		}
+		config.Endpoints = append(config.Endpoints, endpoint)
+	}
+	if len(config.Endpoints) == 0 {
+		return config
+	}
+
+	config.Secret = []byte(os.Getenv("WEBHOOK_SECRET"))
+	if len(config.Secret) == 0 {
+		log.Fatalf("WEBHOOK_SECRET is required when WEBHOOK_URLS is set")
+	}
+
+	maxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", strconv.Itoa(config.MaxAttempts)))
+	if err != nil || maxAttempts <= 0 {
+		log.Fatalf("invalid WEBHOOK_MAX_ATTEMPTS: must be a positive integer")
+	}
+	config.MaxAttempts = maxAttempts
+
+	return config
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
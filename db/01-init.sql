@@ -13,6 +13,7 @@ CREATE TABLE IF NOT EXISTS decision (
   actor_user_id CHAR(36) NOT NULL,
   recipient_user_id CHAR(36) NOT NULL,
   liked_recipient BOOLEAN NOT NULL,
+  unmatched BOOLEAN NOT NULL DEFAULT FALSE, -- pass recorded by Unmatch, hides the other user's like
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   
   -- force unique pair of (actor, recipient) decisions
@@ -23,6 +24,146 @@ CREATE TABLE IF NOT EXISTS decision (
   FOREIGN KEY (recipient_user_id) REFERENCES user(id)
 );
 
+-- Create decision_event table, an append-only log of every decision.
+-- The decision table only keeps the latest decision of each pair
+CREATE TABLE IF NOT EXISTS decision_event (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  actor_user_id CHAR(36) NOT NULL,
+  recipient_user_id CHAR(36) NOT NULL,
+  liked_recipient BOOLEAN NOT NULL,
+  unmatched BOOLEAN NOT NULL DEFAULT FALSE,
+  matched BOOLEAN NOT NULL DEFAULT FALSE, -- like that created a new match
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  -- foreign key references
+  FOREIGN KEY (actor_user_id) REFERENCES user(id),
+  FOREIGN KEY (recipient_user_id) REFERENCES user(id)
+);
+
+-- Create user_match table, a match is stored once per side
+-- so each user lists its matches with a single index range
+CREATE TABLE IF NOT EXISTS user_match (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  matched_user_id CHAR(36) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  -- force unique pair of (user, matched user)
+  UNIQUE KEY unique_user_matched (user_id, matched_user_id),
+
+  -- foreign key references
+  FOREIGN KEY (user_id) REFERENCES user(id),
+  FOREIGN KEY (matched_user_id) REFERENCES user(id)
+);
+
+-- Create user_block table, the blocker no longer sees likes of the blocked user
+-- and neither of them can decide on the other
+CREATE TABLE IF NOT EXISTS user_block (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  blocker_user_id CHAR(36) NOT NULL,
+  blocked_user_id CHAR(36) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  -- force unique pair of (blocker, blocked user)
+  UNIQUE KEY unique_blocker_blocked (blocker_user_id, blocked_user_id),
+
+  -- foreign key references
+  FOREIGN KEY (blocker_user_id) REFERENCES user(id),
+  FOREIGN KEY (blocked_user_id) REFERENCES user(id)
+);
+
+-- Create user_report table, the moderation queue
+CREATE TABLE IF NOT EXISTS user_report (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  reporter_user_id CHAR(36) NOT NULL,
+  reported_user_id CHAR(36) NOT NULL,
+  reason VARCHAR(32) NOT NULL, -- SPAM, HARASSMENT, INAPPROPRIATE_CONTENT, FAKE_PROFILE, UNDERAGE or OTHER
+  details VARCHAR(1000) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL DEFAULT 'OPEN', -- OPEN, CLAIMED or RESOLVED
+  moderator_id VARCHAR(64) NOT NULL DEFAULT '', -- moderator who claimed the report, moderators are not users
+  outcome VARCHAR(16) NOT NULL DEFAULT '', -- DISMISSED, WARNING, LIKES_HIDDEN or BANNED once resolved
+  resolution_note VARCHAR(1000) NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- foreign key references
+  FOREIGN KEY (reporter_user_id) REFERENCES user(id),
+  FOREIGN KEY (reported_user_id) REFERENCES user(id)
+);
+
+-- Create user_moderation table, only users sanctioned by a moderator have a row
+CREATE TABLE IF NOT EXISTS user_moderation (
+  user_id CHAR(36) NOT NULL PRIMARY KEY,
+  warnings INT UNSIGNED NOT NULL DEFAULT 0,
+  likes_hidden BOOLEAN NOT NULL DEFAULT FALSE, -- likes left out of like lists, like_stats and WatchLikes
+  banned BOOLEAN NOT NULL DEFAULT FALSE, -- decisions rejected
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- foreign key references
+  FOREIGN KEY (user_id) REFERENCES user(id)
+);
+
+-- Create user_deletion table, the deletions requested with DeleteUser and their progress.
+-- Rows are kept once completed as the deletion receipt, so there is no foreign key to user
+CREATE TABLE IF NOT EXISTS user_deletion (
+  user_id CHAR(36) NOT NULL PRIMARY KEY,
+  step VARCHAR(32) NOT NULL, -- next step to run, DONE once completed
+  decisions_deleted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  like_counts_repaired BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  decision_events_deleted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  matches_deleted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  blocks_deleted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  reports_deleted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  requested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP NULL DEFAULT NULL
+);
+
+-- Create idempotency_key table, the outcome of PutDecision calls sent with an idempotency key
+CREATE TABLE IF NOT EXISTS idempotency_key (
+  actor_user_id CHAR(36) NOT NULL,
+  idempotency_key VARCHAR(128) NOT NULL, -- chosen by the client, unique per actor
+  recipient_user_id CHAR(36) NOT NULL,
+  liked_recipient BOOLEAN NOT NULL,
+  mutual_likes BOOLEAN NOT NULL, -- original response
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  expires_at TIMESTAMP NOT NULL,
+
+  PRIMARY KEY (actor_user_id, idempotency_key),
+
+  -- foreign key references
+  FOREIGN KEY (actor_user_id) REFERENCES user(id)
+);
+
+-- Create outbox_event table, domain events written in the same transaction as the decisions
+-- and published by the outbox relay
+CREATE TABLE IF NOT EXISTS outbox_event (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  event_type VARCHAR(32) NOT NULL,
+  event_key CHAR(36) NOT NULL, -- recipient user id, events of a key are published in order
+  payload JSON NOT NULL,
+  actor_user_id CHAR(36) AS (payload->>'$.actor_user_id') STORED, -- for the user eraser
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  published_at TIMESTAMP NULL DEFAULT NULL,
+  claimed_until TIMESTAMP(3) NULL DEFAULT NULL -- lease of the relay publishing the event
+);
+
+-- Create webhook_delivery table, pending webhook calls of MatchCreated events, kept as dead letters
+-- (dead_at set) once every attempt failed
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  endpoint VARCHAR(512) NOT NULL,
+  event_id BIGINT NOT NULL, -- outbox_event id
+  body JSON NOT NULL,
+  event_key CHAR(36) AS (body->>'$.key') STORED, -- for the user eraser, like the outbox_event columns
+  actor_user_id CHAR(36) AS (body->>'$.payload.actor_user_id') STORED,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error VARCHAR(1024) NOT NULL DEFAULT '',
+  next_attempt_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  dead_at TIMESTAMP NULL DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE KEY unique_event_endpoint (event_id, endpoint)
+);
+
 -- Create like_stats table
 CREATE TABLE IF NOT EXISTS like_stats (
   user_id CHAR(36) PRIMARY KEY,
@@ -33,10 +174,82 @@ CREATE TABLE IF NOT EXISTS like_stats (
   FOREIGN KEY (user_id) REFERENCES user(id)
 );
 
--- index for ListLikedYou query optimization
-CREATE INDEX idx_decision_recipient_like_id 
-  ON decision (recipient_user_id, liked_recipient, id);
+-- Create user_stats table, the counters returned by GetUserStats next to like_stats.
+-- Like like_stats they leave out likes of actors blocked by the user or hidden by moderation
+CREATE TABLE IF NOT EXISTS user_stats (
+  user_id CHAR(36) PRIMARY KEY,
+  new_like_count INT UNSIGNED NOT NULL DEFAULT 0, -- likers the user did not like back nor unmatch, the ListNewLikedYou set
+  match_count INT UNSIGNED NOT NULL DEFAULT 0,
+  pass_count INT UNSIGNED NOT NULL DEFAULT 0, -- passes received, unmatched ones included
+  last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- foreign key references
+  FOREIGN KEY (user_id) REFERENCES user(id)
+);
+
+-- index for ListLikedYou and ListNewLikedYou, ordered by like time with id as tie-breaker
+CREATE INDEX idx_decision_recipient_like_created
+  ON decision (recipient_user_id, liked_recipient, created_at, id);
 
 -- index for ListNewLikedYou sub-query optimization
 CREATE INDEX idx_decision_actor_recipient_like 
-  ON decision (actor_user_id, recipient_user_id, liked_recipient);
+  ON decision (actor_user_id, recipient_user_id, liked_recipient, unmatched);
+
+-- index for ListMyDecisions, the actor's likes or passes ordered by decision time with id as tie-breaker
+CREATE INDEX idx_decision_actor_like_created
+  ON decision (actor_user_id, liked_recipient, created_at, id);
+
+-- indexes for ListDecisionHistory, per actor and per (actor, recipient) pair
+CREATE INDEX idx_decision_event_actor_id
+  ON decision_event (actor_user_id, id);
+
+CREATE INDEX idx_decision_event_actor_recipient_id
+  ON decision_event (actor_user_id, recipient_user_id, id);
+
+-- index for WatchLikes replays, likes received by a user
+CREATE INDEX idx_decision_event_recipient_id
+  ON decision_event (recipient_user_id, id);
+
+-- index for ListMatches, ordered by match time with id as tie-breaker
+CREATE INDEX idx_user_match_user_created
+  ON user_match (user_id, created_at, id);
+
+-- index for ListBlocked, same shape as idx_user_match_user_created
+CREATE INDEX idx_user_block_blocker_created
+  ON user_block (blocker_user_id, created_at, id);
+
+-- index for ListReports, the moderation queue by status and report time
+CREATE INDEX idx_user_report_status_created
+  ON user_report (status, created_at, id);
+
+-- index for the user eraser, pending deletions by request time
+CREATE INDEX idx_user_deletion_pending
+  ON user_deletion (completed_at, requested_at);
+
+-- index for the outbox relay, pending events in id order and published events by age for the purge
+CREATE INDEX idx_outbox_event_published_id
+  ON outbox_event (published_at, id);
+
+-- index for the webhook dispatcher, due deliveries by due time
+CREATE INDEX idx_webhook_delivery_due
+  ON webhook_delivery (dead_at, next_attempt_at);
+
+-- index for purging expired idempotency keys
+CREATE INDEX idx_idempotency_key_expires
+  ON idempotency_key (expires_at);
+
+-- indexes for the user eraser, columns naming a user without a foreign key
+CREATE INDEX idx_idempotency_key_recipient
+  ON idempotency_key (recipient_user_id);
+
+CREATE INDEX idx_outbox_event_key
+  ON outbox_event (event_key);
+
+CREATE INDEX idx_outbox_event_actor
+  ON outbox_event (actor_user_id);
+
+CREATE INDEX idx_webhook_delivery_key
+  ON webhook_delivery (event_key);
+
+CREATE INDEX idx_webhook_delivery_actor
+  ON webhook_delivery (actor_user_id);
@@ -1,37 +1,76 @@
 -- Ingest the db tables with initial data
 
--- Insert users
+-- Insert users, ids are UUIDs as required by the request validation
 INSERT INTO user (id, name)
 VALUES
-('1', 'Lily'),
-('2', 'Matt'),
-('3', 'Kevin'),     -- likes everyone
-('4', 'Alice'),
-('5', "Anna"),      -- liked by everyone
-('6', "Sebastian");
+('11111111-1111-4111-8111-111111111111', 'Lily'),
+('22222222-2222-4222-8222-222222222222', 'Matt'),
+('33333333-3333-4333-8333-333333333333', 'Kevin'),     -- likes everyone
+('44444444-4444-4444-8444-444444444444', 'Alice'),
+('55555555-5555-4555-8555-555555555555', "Anna"),      -- liked by everyone
+('66666666-6666-4666-8666-666666666666', "Sebastian");
 
 -- Insert decisions
 INSERT INTO decision (actor_user_id, recipient_user_id, liked_recipient)
 VALUES
-('1', '2', TRUE),
-('2', '1', TRUE),
-('2', '4', FALSE),
-('3', '1', TRUE),
-('3', '2', TRUE),
-('3', '4', TRUE),
-('4', '1', TRUE),
-('1', '5', TRUE),
-('2', '5', TRUE),
-('3', '5', TRUE),
-('4', '5', TRUE),
-('6', '5', TRUE);
+('11111111-1111-4111-8111-111111111111', '22222222-2222-4222-8222-222222222222', TRUE),
+('22222222-2222-4222-8222-222222222222', '11111111-1111-4111-8111-111111111111', TRUE),
+('22222222-2222-4222-8222-222222222222', '44444444-4444-4444-8444-444444444444', FALSE),
+('33333333-3333-4333-8333-333333333333', '11111111-1111-4111-8111-111111111111', TRUE),
+('33333333-3333-4333-8333-333333333333', '22222222-2222-4222-8222-222222222222', TRUE),
+('33333333-3333-4333-8333-333333333333', '44444444-4444-4444-8444-444444444444', TRUE),
+('44444444-4444-4444-8444-444444444444', '11111111-1111-4111-8111-111111111111', TRUE),
+('11111111-1111-4111-8111-111111111111', '55555555-5555-4555-8555-555555555555', TRUE),
+('22222222-2222-4222-8222-222222222222', '55555555-5555-4555-8555-555555555555', TRUE),
+('33333333-3333-4333-8333-333333333333', '55555555-5555-4555-8555-555555555555', TRUE),
+('44444444-4444-4444-8444-444444444444', '55555555-5555-4555-8555-555555555555', TRUE),
+('66666666-6666-4666-8666-666666666666', '55555555-5555-4555-8555-555555555555', TRUE);
+
+-- Backfill the decision history with the initial decisions,
+-- a like is flagged as matched when the recipient liked the actor first
+INSERT INTO decision_event (actor_user_id, recipient_user_id, liked_recipient, matched, created_at)
+SELECT
+  d.actor_user_id,
+  d.recipient_user_id,
+  d.liked_recipient,
+  d.liked_recipient AND EXISTS (
+    SELECT 1
+    FROM decision d2
+    WHERE d2.actor_user_id = d.recipient_user_id
+      AND d2.recipient_user_id = d.actor_user_id
+      AND d2.liked_recipient = TRUE
+      AND d2.id < d.id
+  ),
+  d.created_at
+FROM decision d
+ORDER BY d.id;
+
+-- Backfill the matches of the initial mutual likes (Lily and Matt)
+INSERT INTO user_match (user_id, matched_user_id, created_at)
+SELECT d.actor_user_id, d.recipient_user_id, GREATEST(d.created_at, d2.created_at)
+FROM decision d
+JOIN decision d2
+  ON d2.actor_user_id = d.recipient_user_id
+  AND d2.recipient_user_id = d.actor_user_id
+  AND d2.liked_recipient = TRUE
+WHERE d.liked_recipient = TRUE
+ORDER BY d.id;
 
 -- Insert like counts
 INSERT INTO like_stats (user_id, like_count)
 VALUES
-('1', 3),  -- liked by users 2, 3, 4
-('2', 2),  -- liked by users 1, 3
-('3', 0),  -- not liked by anyone
-('4', 1),  -- liked by user 3
-('5', 5),  -- liked by everyone
-('6', 0);  -- not liked by anyone
\ No newline at end of file
+('11111111-1111-4111-8111-111111111111', 3),  -- liked by Matt, Kevin, Alice
+('22222222-2222-4222-8222-222222222222', 2),  -- liked by Lily, Kevin
+('33333333-3333-4333-8333-333333333333', 0),  -- not liked by anyone
+('44444444-4444-4444-8444-444444444444', 1),  -- liked by Kevin
+('55555555-5555-4555-8555-555555555555', 5),  -- liked by everyone
+('66666666-6666-4666-8666-666666666666', 0);  -- not liked by anyone
+
+INSERT INTO user_stats (user_id, new_like_count, match_count, pass_count)
+VALUES
+('11111111-1111-4111-8111-111111111111', 2, 1, 0),  -- Kevin and Alice are new, Matt is a match
+('22222222-2222-4222-8222-222222222222', 1, 1, 0),  -- Kevin is new, Lily is a match
+('33333333-3333-4333-8333-333333333333', 0, 0, 0),
+('44444444-4444-4444-8444-444444444444', 1, 0, 1),  -- liked by Kevin, passed by Matt
+('55555555-5555-4555-8555-555555555555', 5, 0, 0),  -- never decided
+('66666666-6666-4666-8666-666666666666', 0, 0, 0);
\ No newline at end of file
@@ -21,13 +21,334 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED  SortOrder = 0 // Same as SORT_ORDER_OLDEST_FIRST
+	SortOrder_SORT_ORDER_OLDEST_FIRST SortOrder = 1
+	SortOrder_SORT_ORDER_NEWEST_FIRST SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_OLDEST_FIRST",
+		2: "SORT_ORDER_NEWEST_FIRST",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED":  0,
+		"SORT_ORDER_OLDEST_FIRST": 1,
+		"SORT_ORDER_NEWEST_FIRST": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{0}
+}
+
+type ReportReason int32
+
+const (
+	ReportReason_REPORT_REASON_UNSPECIFIED           ReportReason = 0
+	ReportReason_REPORT_REASON_SPAM                  ReportReason = 1
+	ReportReason_REPORT_REASON_HARASSMENT            ReportReason = 2
+	ReportReason_REPORT_REASON_INAPPROPRIATE_CONTENT ReportReason = 3
+	ReportReason_REPORT_REASON_FAKE_PROFILE          ReportReason = 4
+	ReportReason_REPORT_REASON_UNDERAGE              ReportReason = 5
+	ReportReason_REPORT_REASON_OTHER                 ReportReason = 6
+)
+
+// Enum value maps for ReportReason.
+var (
+	ReportReason_name = map[int32]string{
+		0: "REPORT_REASON_UNSPECIFIED",
+		1: "REPORT_REASON_SPAM",
+		2: "REPORT_REASON_HARASSMENT",
+		3: "REPORT_REASON_INAPPROPRIATE_CONTENT",
+		4: "REPORT_REASON_FAKE_PROFILE",
+		5: "REPORT_REASON_UNDERAGE",
+		6: "REPORT_REASON_OTHER",
+	}
+	ReportReason_value = map[string]int32{
+		"REPORT_REASON_UNSPECIFIED":           0,
+		"REPORT_REASON_SPAM":                  1,
+		"REPORT_REASON_HARASSMENT":            2,
+		"REPORT_REASON_INAPPROPRIATE_CONTENT": 3,
+		"REPORT_REASON_FAKE_PROFILE":          4,
+		"REPORT_REASON_UNDERAGE":              5,
+		"REPORT_REASON_OTHER":                 6,
+	}
+)
+
+func (x ReportReason) Enum() *ReportReason {
+	p := new(ReportReason)
+	*p = x
+	return p
+}
+
+func (x ReportReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ReportReason) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[1]
+}
+
+func (x ReportReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportReason.Descriptor instead.
+func (ReportReason) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ReportStatus int32
+
+const (
+	ReportStatus_REPORT_STATUS_UNSPECIFIED ReportStatus = 0 // Same as REPORT_STATUS_OPEN
+	ReportStatus_REPORT_STATUS_OPEN        ReportStatus = 1
+	ReportStatus_REPORT_STATUS_CLAIMED     ReportStatus = 2
+	ReportStatus_REPORT_STATUS_RESOLVED    ReportStatus = 3
+)
+
+// Enum value maps for ReportStatus.
+var (
+	ReportStatus_name = map[int32]string{
+		0: "REPORT_STATUS_UNSPECIFIED",
+		1: "REPORT_STATUS_OPEN",
+		2: "REPORT_STATUS_CLAIMED",
+		3: "REPORT_STATUS_RESOLVED",
+	}
+	ReportStatus_value = map[string]int32{
+		"REPORT_STATUS_UNSPECIFIED": 0,
+		"REPORT_STATUS_OPEN":        1,
+		"REPORT_STATUS_CLAIMED":     2,
+		"REPORT_STATUS_RESOLVED":    3,
+	}
+)
+
+func (x ReportStatus) Enum() *ReportStatus {
+	p := new(ReportStatus)
+	*p = x
+	return p
+}
+
+func (x ReportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ReportStatus) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[2]
+}
+
+func (x ReportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportStatus.Descriptor instead.
+func (ReportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{2}
+}
+
+type ReportOutcome int32
+
+const (
+	ReportOutcome_REPORT_OUTCOME_UNSPECIFIED  ReportOutcome = 0
+	ReportOutcome_REPORT_OUTCOME_DISMISSED    ReportOutcome = 1 // No sanction
+	ReportOutcome_REPORT_OUTCOME_WARNING      ReportOutcome = 2 // Counted in the user's warnings
+	ReportOutcome_REPORT_OUTCOME_LIKES_HIDDEN ReportOutcome = 3 // The user's likes are hidden from everyone
+	ReportOutcome_REPORT_OUTCOME_BANNED       ReportOutcome = 4 // Likes hidden and decisions rejected
+)
+
+// Enum value maps for ReportOutcome.
+var (
+	ReportOutcome_name = map[int32]string{
+		0: "REPORT_OUTCOME_UNSPECIFIED",
+		1: "REPORT_OUTCOME_DISMISSED",
+		2: "REPORT_OUTCOME_WARNING",
+		3: "REPORT_OUTCOME_LIKES_HIDDEN",
+		4: "REPORT_OUTCOME_BANNED",
+	}
+	ReportOutcome_value = map[string]int32{
+		"REPORT_OUTCOME_UNSPECIFIED":  0,
+		"REPORT_OUTCOME_DISMISSED":    1,
+		"REPORT_OUTCOME_WARNING":      2,
+		"REPORT_OUTCOME_LIKES_HIDDEN": 3,
+		"REPORT_OUTCOME_BANNED":       4,
+	}
+)
+
+func (x ReportOutcome) Enum() *ReportOutcome {
+	p := new(ReportOutcome)
+	*p = x
+	return p
+}
+
+func (x ReportOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[3].Descriptor()
+}
+
+func (ReportOutcome) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[3]
+}
+
+func (x ReportOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportOutcome.Descriptor instead.
+func (ReportOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{3}
+}
+
+type DecisionState int32
+
+const (
+	DecisionState_DECISION_STATE_NONE      DecisionState = 0
+	DecisionState_DECISION_STATE_LIKED     DecisionState = 1
+	DecisionState_DECISION_STATE_PASSED    DecisionState = 2
+	DecisionState_DECISION_STATE_UNMATCHED DecisionState = 3 // Pass recorded by Unmatch or BlockUser
+)
+
+// Enum value maps for DecisionState.
+var (
+	DecisionState_name = map[int32]string{
+		0: "DECISION_STATE_NONE",
+		1: "DECISION_STATE_LIKED",
+		2: "DECISION_STATE_PASSED",
+		3: "DECISION_STATE_UNMATCHED",
+	}
+	DecisionState_value = map[string]int32{
+		"DECISION_STATE_NONE":      0,
+		"DECISION_STATE_LIKED":     1,
+		"DECISION_STATE_PASSED":    2,
+		"DECISION_STATE_UNMATCHED": 3,
+	}
+)
+
+func (x DecisionState) Enum() *DecisionState {
+	p := new(DecisionState)
+	*p = x
+	return p
+}
+
+func (x DecisionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecisionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[4].Descriptor()
+}
+
+func (DecisionState) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[4]
+}
+
+func (x DecisionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecisionState.Descriptor instead.
+func (DecisionState) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{4}
+}
+
+type DecisionType int32
+
+const (
+	DecisionType_DECISION_TYPE_UNSPECIFIED DecisionType = 0
+	DecisionType_DECISION_TYPE_LIKE        DecisionType = 1
+	DecisionType_DECISION_TYPE_PASS        DecisionType = 2 // Including the passes recorded by Unmatch and BlockUser
+)
+
+// Enum value maps for DecisionType.
+var (
+	DecisionType_name = map[int32]string{
+		0: "DECISION_TYPE_UNSPECIFIED",
+		1: "DECISION_TYPE_LIKE",
+		2: "DECISION_TYPE_PASS",
+	}
+	DecisionType_value = map[string]int32{
+		"DECISION_TYPE_UNSPECIFIED": 0,
+		"DECISION_TYPE_LIKE":        1,
+		"DECISION_TYPE_PASS":        2,
+	}
+)
+
+func (x DecisionType) Enum() *DecisionType {
+	p := new(DecisionType)
+	*p = x
+	return p
+}
+
+func (x DecisionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecisionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[5].Descriptor()
+}
+
+func (DecisionType) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[5]
+}
+
+func (x DecisionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecisionType.Descriptor instead.
+func (DecisionType) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{5}
+}
+
 type ListLikedYouRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
-	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"` // Amount of items wanted in a single page
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId    string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	PaginationToken    *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize           *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                                 // Amount of items wanted in a single page
+	SortOrder          SortOrder              `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"`             // Order by like time, must not change between pages
+	SinceUnixTimestamp *uint64                `protobuf:"varint,5,opt,name=since_unix_timestamp,json=sinceUnixTimestamp,proto3,oneof" json:"since_unix_timestamp,omitempty"` // Only likes recorded at or after this time
+	UntilUnixTimestamp *uint64                `protobuf:"varint,6,opt,name=until_unix_timestamp,json=untilUnixTimestamp,proto3,oneof" json:"until_unix_timestamp,omitempty"` // Only likes recorded before this time
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ListLikedYouRequest) Reset() {
@@ -81,6 +402,27 @@ func (x *ListLikedYouRequest) GetPageSize() uint32 {
 	return 0
 }
 
+func (x *ListLikedYouRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListLikedYouRequest) GetSinceUnixTimestamp() uint64 {
+	if x != nil && x.SinceUnixTimestamp != nil {
+		return *x.SinceUnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetUntilUnixTimestamp() uint64 {
+	if x != nil && x.UntilUnixTimestamp != nil {
+		return *x.UntilUnixTimestamp
+	}
+	return 0
+}
+
 type ListLikedYouResponse struct {
 	state               protoimpl.MessageState        `protogen:"open.v1"`
 	Likers              []*ListLikedYouResponse_Liker `protobuf:"bytes,1,rep,name=likers,proto3" json:"likers,omitempty"`
@@ -134,10 +476,12 @@ func (x *ListLikedYouResponse) GetNextPaginationToken() string {
 }
 
 type CountLikedYouRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId    string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	SinceUnixTimestamp *uint64                `protobuf:"varint,2,opt,name=since_unix_timestamp,json=sinceUnixTimestamp,proto3,oneof" json:"since_unix_timestamp,omitempty"` // Only likes recorded at or after this time
+	UntilUnixTimestamp *uint64                `protobuf:"varint,3,opt,name=until_unix_timestamp,json=untilUnixTimestamp,proto3,oneof" json:"until_unix_timestamp,omitempty"` // Only likes recorded before this time
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CountLikedYouRequest) Reset() {
@@ -177,9 +521,23 @@ func (x *CountLikedYouRequest) GetRecipientUserId() string {
 	return ""
 }
 
+func (x *CountLikedYouRequest) GetSinceUnixTimestamp() uint64 {
+	if x != nil && x.SinceUnixTimestamp != nil {
+		return *x.SinceUnixTimestamp
+	}
+	return 0
+}
+
+func (x *CountLikedYouRequest) GetUntilUnixTimestamp() uint64 {
+	if x != nil && x.UntilUnixTimestamp != nil {
+		return *x.UntilUnixTimestamp
+	}
+	return 0
+}
+
 type CountLikedYouResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Counts of a window the like_stats cache can't serve stop at the server's LIKES_COUNT_CAP, reaching it means at least that many likes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,18 +579,131 @@ func (x *CountLikedYouResponse) GetCount() uint64 {
 	return 0
 }
 
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_explore_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikeCount     uint64                 `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`            // Same as CountLikedYou
+	NewLikeCount  uint64                 `protobuf:"varint,2,opt,name=new_like_count,json=newLikeCount,proto3" json:"new_like_count,omitempty"` // Likes from users the user did not like back nor unmatch, the ListNewLikedYou set
+	MatchCount    uint64                 `protobuf:"varint,3,opt,name=match_count,json=matchCount,proto3" json:"match_count,omitempty"`
+	PassCount     uint64                 `protobuf:"varint,4,opt,name=pass_count,json=passCount,proto3" json:"pass_count,omitempty"` // Passes received, including the ones recorded by Unmatch and BlockUser
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsResponse) Reset() {
+	*x = GetUserStatsResponse{}
+	mi := &file_explore_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsResponse) ProtoMessage() {}
+
+func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStatsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserStatsResponse) GetLikeCount() uint64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetNewLikeCount() uint64 {
+	if x != nil {
+		return x.NewLikeCount
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetMatchCount() uint64 {
+	if x != nil {
+		return x.MatchCount
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetPassCount() uint64 {
+	if x != nil {
+		return x.PassCount
+	}
+	return 0
+}
+
 type PutDecisionRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
 	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	IdempotencyKey  *string                `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"` // Retries with the same key return the original response, can also be sent as idempotency-key metadata
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PutDecisionRequest) Reset() {
 	*x = PutDecisionRequest{}
-	mi := &file_explore_service_proto_msgTypes[4]
+	mi := &file_explore_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -244,7 +715,7 @@ func (x *PutDecisionRequest) String() string {
 func (*PutDecisionRequest) ProtoMessage() {}
 
 func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[4]
+	mi := &file_explore_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,7 +728,7 @@ func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
 func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{4}
+	return file_explore_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutDecisionRequest) GetActorUserId() string {
@@ -281,6 +752,13 @@ func (x *PutDecisionRequest) GetLikedRecipient() bool {
 	return false
 }
 
+func (x *PutDecisionRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
 type PutDecisionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MutualLikes   bool                   `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other
@@ -290,7 +768,7 @@ type PutDecisionResponse struct {
 
 func (x *PutDecisionResponse) Reset() {
 	*x = PutDecisionResponse{}
-	mi := &file_explore_service_proto_msgTypes[5]
+	mi := &file_explore_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -302,7 +780,7 @@ func (x *PutDecisionResponse) String() string {
 func (*PutDecisionResponse) ProtoMessage() {}
 
 func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[5]
+	mi := &file_explore_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +793,7 @@ func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
 func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{5}
+	return file_explore_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PutDecisionResponse) GetMutualLikes() bool {
@@ -325,29 +803,3234 @@ func (x *PutDecisionResponse) GetMutualLikes() bool {
 	return false
 }
 
-type ListLikedYouResponse_Liker struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type BatchPutDecisionRequest struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	ActorUserId   string                              `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Decisions     []*BatchPutDecisionRequest_Decision `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"` // Recorded in order, a later decision on the same recipient overwrites an earlier one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionRequest) Reset() {
+	*x = BatchPutDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionRequest) ProtoMessage() {}
+
+func (x *BatchPutDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionRequest.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchPutDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionRequest) GetDecisions() []*BatchPutDecisionRequest_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type BatchPutDecisionResponse struct {
+	state         protoimpl.MessageState             `protogen:"open.v1"`
+	Results       []*BatchPutDecisionResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per decision, in the request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionResponse) Reset() {
+	*x = BatchPutDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionResponse) ProtoMessage() {}
+
+func (x *BatchPutDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionResponse.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchPutDecisionResponse) GetResults() []*BatchPutDecisionResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetDecisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionRequest) Reset() {
+	*x = GetDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionRequest) ProtoMessage() {}
+
+func (x *GetDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type GetDecisionResponse struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Decision      *GetDecisionResponse_Decision `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDecisionResponse) Reset() {
+	*x = GetDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionResponse) ProtoMessage() {}
+
+func (x *GetDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetDecisionResponse) GetDecision() *GetDecisionResponse_Decision {
+	if x != nil {
+		return x.Decision
+	}
+	return nil
+}
+
+type BatchGetDecisionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId      string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserIds []string               `protobuf:"bytes,2,rep,name=recipient_user_ids,json=recipientUserIds,proto3" json:"recipient_user_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BatchGetDecisionRequest) Reset() {
+	*x = BatchGetDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDecisionRequest) ProtoMessage() {}
+
+func (x *BatchGetDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDecisionRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchGetDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *BatchGetDecisionRequest) GetRecipientUserIds() []string {
+	if x != nil {
+		return x.RecipientUserIds
+	}
+	return nil
+}
+
+type BatchGetDecisionResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Decisions     []*GetDecisionResponse_Decision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"` // One per recipient, in the request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetDecisionResponse) Reset() {
+	*x = BatchGetDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetDecisionResponse) ProtoMessage() {}
+
+func (x *BatchGetDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetDecisionResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchGetDecisionResponse) GetDecisions() []*GetDecisionResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type ListMyDecisionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	DecisionType    DecisionType           `protobuf:"varint,2,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"` // Only list the current decisions of this type
+	PaginationToken *string                `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Amount of items wanted in a single page
+	SortOrder       SortOrder              `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Order by decision time, must not change between pages
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMyDecisionsRequest) Reset() {
+	*x = ListMyDecisionsRequest{}
+	mi := &file_explore_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDecisionsRequest) ProtoMessage() {}
+
+func (x *ListMyDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListMyDecisionsRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListMyDecisionsRequest) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+func (x *ListMyDecisionsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListMyDecisionsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyDecisionsRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListMyDecisionsResponse struct {
+	state               protoimpl.MessageState          `protogen:"open.v1"`
+	Decisions           []*GetDecisionResponse_Decision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	NextPaginationToken *string                         `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMyDecisionsResponse) Reset() {
+	*x = ListMyDecisionsResponse{}
+	mi := &file_explore_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDecisionsResponse) ProtoMessage() {}
+
+func (x *ListMyDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMyDecisionsResponse) GetDecisions() []*GetDecisionResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ListMyDecisionsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type ListDecisionHistoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId *string                `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3,oneof" json:"recipient_user_id,omitempty"` // Only list the decisions of the actor on this recipient
+	PaginationToken *string                `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Amount of items wanted in a single page
+	SortOrder       SortOrder              `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Order by decision time, must not change between pages
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListDecisionHistoryRequest) Reset() {
+	*x = ListDecisionHistoryRequest{}
+	mi := &file_explore_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecisionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionHistoryRequest) ProtoMessage() {}
+
+func (x *ListDecisionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListDecisionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListDecisionHistoryRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionHistoryRequest) GetRecipientUserId() string {
+	if x != nil && x.RecipientUserId != nil {
+		return *x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionHistoryRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListDecisionHistoryRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDecisionHistoryRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListDecisionHistoryResponse struct {
+	state               protoimpl.MessageState                       `protogen:"open.v1"`
+	Events              []*ListDecisionHistoryResponse_DecisionEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPaginationToken *string                                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListDecisionHistoryResponse) Reset() {
+	*x = ListDecisionHistoryResponse{}
+	mi := &file_explore_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecisionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionHistoryResponse) ProtoMessage() {}
+
+func (x *ListDecisionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListDecisionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDecisionHistoryResponse) GetEvents() []*ListDecisionHistoryResponse_DecisionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListDecisionHistoryResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type ListMatchesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Amount of items wanted in a single page
+	SortOrder       SortOrder              `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Order by match time, must not change between pages
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	mi := &file_explore_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMatchesRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListMatchesResponse struct {
+	state               protoimpl.MessageState       `protogen:"open.v1"`
+	Matches             []*ListMatchesResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPaginationToken *string                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	mi := &file_explore_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type UnmatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	OtherUserId   string                 `protobuf:"bytes,2,opt,name=other_user_id,json=otherUserId,proto3" json:"other_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchRequest) Reset() {
+	*x = UnmatchRequest{}
+	mi := &file_explore_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchRequest) ProtoMessage() {}
+
+func (x *UnmatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchRequest.ProtoReflect.Descriptor instead.
+func (*UnmatchRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnmatchRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *UnmatchRequest) GetOtherUserId() string {
+	if x != nil {
+		return x.OtherUserId
+	}
+	return ""
+}
+
+type UnmatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchResponse) Reset() {
+	*x = UnmatchResponse{}
+	mi := &file_explore_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchResponse) ProtoMessage() {}
+
+func (x *UnmatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchResponse.ProtoReflect.Descriptor instead.
+func (*UnmatchResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{21}
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerUserId string                 `protobuf:"bytes,1,opt,name=blocker_user_id,json=blockerUserId,proto3" json:"blocker_user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BlockUserRequest) GetBlockerUserId() string {
+	if x != nil {
+		return x.BlockerUserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{23}
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerUserId string                 `protobuf:"bytes,1,opt,name=blocker_user_id,json=blockerUserId,proto3" json:"blocker_user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UnblockUserRequest) GetBlockerUserId() string {
+	if x != nil {
+		return x.BlockerUserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{25}
+}
+
+type ListBlockedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Amount of items wanted in a single page
+	SortOrder       SortOrder              `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Order by block time, must not change between pages
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_explore_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBlockedRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListBlockedRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlockedRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListBlockedResponse struct {
+	state               protoimpl.MessageState             `protogen:"open.v1"`
+	Blocked             []*ListBlockedResponse_BlockedUser `protobuf:"bytes,1,rep,name=blocked,proto3" json:"blocked,omitempty"`
+	NextPaginationToken *string                            `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_explore_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListBlockedResponse) GetBlocked() []*ListBlockedResponse_BlockedUser {
+	if x != nil {
+		return x.Blocked
+	}
+	return nil
+}
+
+func (x *ListBlockedResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type ReportUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReporterUserId string                 `protobuf:"bytes,1,opt,name=reporter_user_id,json=reporterUserId,proto3" json:"reporter_user_id,omitempty"`
+	ReportedUserId string                 `protobuf:"bytes,2,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Reason         ReportReason           `protobuf:"varint,3,opt,name=reason,proto3,enum=explore.ReportReason" json:"reason,omitempty"`
+	Details        string                 `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportUserRequest) Reset() {
+	*x = ReportUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserRequest) ProtoMessage() {}
+
+func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
+func (*ReportUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReportUserRequest) GetReporterUserId() string {
+	if x != nil {
+		return x.ReporterUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReportedUserId() string {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *ReportUserRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type ReportUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      uint64                 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportUserResponse) Reset() {
+	*x = ReportUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserResponse) ProtoMessage() {}
+
+func (x *ReportUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserResponse.ProtoReflect.Descriptor instead.
+func (*ReportUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReportUserResponse) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+type Report struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReportId       uint64                 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ReporterUserId string                 `protobuf:"bytes,2,opt,name=reporter_user_id,json=reporterUserId,proto3" json:"reporter_user_id,omitempty"`
+	ReportedUserId string                 `protobuf:"bytes,3,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Reason         ReportReason           `protobuf:"varint,4,opt,name=reason,proto3,enum=explore.ReportReason" json:"reason,omitempty"`
+	Details        string                 `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	Status         ReportStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=explore.ReportStatus" json:"status,omitempty"`
+	ModeratorId    string                 `protobuf:"bytes,7,opt,name=moderator_id,json=moderatorId,proto3" json:"moderator_id,omitempty"`  // Moderator who claimed the report
+	Outcome        ReportOutcome          `protobuf:"varint,8,opt,name=outcome,proto3,enum=explore.ReportOutcome" json:"outcome,omitempty"` // Set once resolved
+	ResolutionNote string                 `protobuf:"bytes,9,opt,name=resolution_note,json=resolutionNote,proto3" json:"resolution_note,omitempty"`
+	UnixTimestamp  uint64                 `protobuf:"varint,10,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // Time the report was made
+	Relationship   *Report_Relationship   `protobuf:"bytes,11,opt,name=relationship,proto3" json:"relationship,omitempty"`                         // Current relationship of both users
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_explore_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Report) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *Report) GetReporterUserId() string {
+	if x != nil {
+		return x.ReporterUserId
+	}
+	return ""
+}
+
+func (x *Report) GetReportedUserId() string {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return ""
+}
+
+func (x *Report) GetReason() ReportReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ReportReason_REPORT_REASON_UNSPECIFIED
+}
+
+func (x *Report) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Report) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *Report) GetModeratorId() string {
+	if x != nil {
+		return x.ModeratorId
+	}
+	return ""
+}
+
+func (x *Report) GetOutcome() ReportOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ReportOutcome_REPORT_OUTCOME_UNSPECIFIED
+}
+
+func (x *Report) GetResolutionNote() string {
+	if x != nil {
+		return x.ResolutionNote
+	}
+	return ""
+}
+
+func (x *Report) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *Report) GetRelationship() *Report_Relationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type ListReportsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          ReportStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=explore.ReportStatus" json:"status,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`                     // Amount of items wanted in a single page
+	SortOrder       SortOrder              `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=explore.SortOrder" json:"sort_order,omitempty"` // Order by report time, must not change between pages
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	mi := &file_explore_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListReportsRequest) GetStatus() ReportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReportStatus_REPORT_STATUS_UNSPECIFIED
+}
+
+func (x *ListReportsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListReportsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListReportsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Reports             []*Report              `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	NextPaginationToken *string                `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListReportsResponse) Reset() {
+	*x = ListReportsResponse{}
+	mi := &file_explore_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsResponse) ProtoMessage() {}
+
+func (x *ListReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListReportsResponse) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListReportsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type ClaimReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      uint64                 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ModeratorId   string                 `protobuf:"bytes,2,opt,name=moderator_id,json=moderatorId,proto3" json:"moderator_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReportRequest) Reset() {
+	*x = ClaimReportRequest{}
+	mi := &file_explore_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReportRequest) ProtoMessage() {}
+
+func (x *ClaimReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReportRequest.ProtoReflect.Descriptor instead.
+func (*ClaimReportRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ClaimReportRequest) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *ClaimReportRequest) GetModeratorId() string {
+	if x != nil {
+		return x.ModeratorId
+	}
+	return ""
+}
+
+type ClaimReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *Report                `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReportResponse) Reset() {
+	*x = ClaimReportResponse{}
+	mi := &file_explore_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReportResponse) ProtoMessage() {}
+
+func (x *ClaimReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReportResponse.ProtoReflect.Descriptor instead.
+func (*ClaimReportResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ClaimReportResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type ResolveReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      uint64                 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ModeratorId   string                 `protobuf:"bytes,2,opt,name=moderator_id,json=moderatorId,proto3" json:"moderator_id,omitempty"` // Must be the moderator who claimed the report
+	Outcome       ReportOutcome          `protobuf:"varint,3,opt,name=outcome,proto3,enum=explore.ReportOutcome" json:"outcome,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveReportRequest) Reset() {
+	*x = ResolveReportRequest{}
+	mi := &file_explore_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReportRequest) ProtoMessage() {}
+
+func (x *ResolveReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReportRequest.ProtoReflect.Descriptor instead.
+func (*ResolveReportRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ResolveReportRequest) GetReportId() uint64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *ResolveReportRequest) GetModeratorId() string {
+	if x != nil {
+		return x.ModeratorId
+	}
+	return ""
+}
+
+func (x *ResolveReportRequest) GetOutcome() ReportOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ReportOutcome_REPORT_OUTCOME_UNSPECIFIED
+}
+
+func (x *ResolveReportRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ResolveReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *Report                `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveReportResponse) Reset() {
+	*x = ResolveReportResponse{}
+	mi := &file_explore_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveReportResponse) ProtoMessage() {}
+
+func (x *ResolveReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveReportResponse.ProtoReflect.Descriptor instead.
+func (*ResolveReportResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ResolveReportResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type UserDeletion struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Completed              bool                   `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	DecisionsDeleted       uint64                 `protobuf:"varint,3,opt,name=decisions_deleted,json=decisionsDeleted,proto3" json:"decisions_deleted,omitempty"`         // Decisions made and received
+	LikeCountsRepaired     uint64                 `protobuf:"varint,4,opt,name=like_counts_repaired,json=likeCountsRepaired,proto3" json:"like_counts_repaired,omitempty"` // Liked users whose like count was decremented
+	DecisionEventsDeleted  uint64                 `protobuf:"varint,5,opt,name=decision_events_deleted,json=decisionEventsDeleted,proto3" json:"decision_events_deleted,omitempty"`
+	MatchesDeleted         uint64                 `protobuf:"varint,6,opt,name=matches_deleted,json=matchesDeleted,proto3" json:"matches_deleted,omitempty"` // Two per match, one for each side
+	BlocksDeleted          uint64                 `protobuf:"varint,7,opt,name=blocks_deleted,json=blocksDeleted,proto3" json:"blocks_deleted,omitempty"`
+	ReportsDeleted         uint64                 `protobuf:"varint,8,opt,name=reports_deleted,json=reportsDeleted,proto3" json:"reports_deleted,omitempty"`
+	RequestedUnixTimestamp uint64                 `protobuf:"varint,9,opt,name=requested_unix_timestamp,json=requestedUnixTimestamp,proto3" json:"requested_unix_timestamp,omitempty"`
+	CompletedUnixTimestamp uint64                 `protobuf:"varint,10,opt,name=completed_unix_timestamp,json=completedUnixTimestamp,proto3" json:"completed_unix_timestamp,omitempty"` // 0 until completed
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UserDeletion) Reset() {
+	*x = UserDeletion{}
+	mi := &file_explore_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletion) ProtoMessage() {}
+
+func (x *UserDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletion.ProtoReflect.Descriptor instead.
+func (*UserDeletion) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UserDeletion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDeletion) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *UserDeletion) GetDecisionsDeleted() uint64 {
+	if x != nil {
+		return x.DecisionsDeleted
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetLikeCountsRepaired() uint64 {
+	if x != nil {
+		return x.LikeCountsRepaired
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetDecisionEventsDeleted() uint64 {
+	if x != nil {
+		return x.DecisionEventsDeleted
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetMatchesDeleted() uint64 {
+	if x != nil {
+		return x.MatchesDeleted
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetBlocksDeleted() uint64 {
+	if x != nil {
+		return x.BlocksDeleted
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetReportsDeleted() uint64 {
+	if x != nil {
+		return x.ReportsDeleted
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetRequestedUnixTimestamp() uint64 {
+	if x != nil {
+		return x.RequestedUnixTimestamp
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetCompletedUnixTimestamp() uint64 {
+	if x != nil {
+		return x.CompletedUnixTimestamp
+	}
+	return 0
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletion      *UserDeletion          `protobuf:"bytes,1,opt,name=deletion,proto3" json:"deletion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteUserResponse) GetDeletion() *UserDeletion {
+	if x != nil {
+		return x.Deletion
+	}
+	return nil
+}
+
+type GetUserDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserDeletionRequest) Reset() {
+	*x = GetUserDeletionRequest{}
+	mi := &file_explore_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDeletionRequest) ProtoMessage() {}
+
+func (x *GetUserDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserDeletionRequest.ProtoReflect.Descriptor instead.
+func (*GetUserDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserDeletionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserDeletionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletion      *UserDeletion          `protobuf:"bytes,1,opt,name=deletion,proto3" json:"deletion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserDeletionResponse) Reset() {
+	*x = GetUserDeletionResponse{}
+	mi := &file_explore_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDeletionResponse) ProtoMessage() {}
+
+func (x *GetUserDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserDeletionResponse.ProtoReflect.Descriptor instead.
+func (*GetUserDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUserDeletionResponse) GetDeletion() *UserDeletion {
+	if x != nil {
+		return x.Deletion
+	}
+	return nil
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_explore_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// One record of the export, the user comes first, then like_stats and moderation, then every list in order
+type ExportUserDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Record:
+	//
+	//	*ExportUserDataResponse_User_
+	//	*ExportUserDataResponse_LikeStats_
+	//	*ExportUserDataResponse_Moderation_
+	//	*ExportUserDataResponse_Decision_
+	//	*ExportUserDataResponse_LikeReceived
+	//	*ExportUserDataResponse_Match
+	//	*ExportUserDataResponse_Blocked
+	//	*ExportUserDataResponse_DecisionEvent
+	//	*ExportUserDataResponse_DecisionEventReceived
+	//	*ExportUserDataResponse_ReportFiled
+	//	*ExportUserDataResponse_ReportAbout
+	//	*ExportUserDataResponse_IdempotencyKey_
+	Record        isExportUserDataResponse_Record `protobuf_oneof:"record"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_explore_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ExportUserDataResponse) GetRecord() isExportUserDataResponse_Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetUser() *ExportUserDataResponse_User {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_User_); ok {
+			return x.User
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetLikeStats() *ExportUserDataResponse_LikeStats {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_LikeStats_); ok {
+			return x.LikeStats
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetModeration() *ExportUserDataResponse_Moderation {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_Moderation_); ok {
+			return x.Moderation
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetDecision() *ExportUserDataResponse_Decision {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_Decision_); ok {
+			return x.Decision
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetLikeReceived() *ListLikedYouResponse_Liker {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_LikeReceived); ok {
+			return x.LikeReceived
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetMatch() *ListMatchesResponse_Match {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_Match); ok {
+			return x.Match
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetBlocked() *ListBlockedResponse_BlockedUser {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_Blocked); ok {
+			return x.Blocked
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetDecisionEvent() *ListDecisionHistoryResponse_DecisionEvent {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_DecisionEvent); ok {
+			return x.DecisionEvent
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetDecisionEventReceived() *ListDecisionHistoryResponse_DecisionEvent {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_DecisionEventReceived); ok {
+			return x.DecisionEventReceived
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetReportFiled() *Report {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_ReportFiled); ok {
+			return x.ReportFiled
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetReportAbout() *Report {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_ReportAbout); ok {
+			return x.ReportAbout
+		}
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetIdempotencyKey() *ExportUserDataResponse_IdempotencyKey {
+	if x != nil {
+		if x, ok := x.Record.(*ExportUserDataResponse_IdempotencyKey_); ok {
+			return x.IdempotencyKey
+		}
+	}
+	return nil
+}
+
+type isExportUserDataResponse_Record interface {
+	isExportUserDataResponse_Record()
+}
+
+type ExportUserDataResponse_User_ struct {
+	User *ExportUserDataResponse_User `protobuf:"bytes,1,opt,name=user,proto3,oneof"`
+}
+
+type ExportUserDataResponse_LikeStats_ struct {
+	LikeStats *ExportUserDataResponse_LikeStats `protobuf:"bytes,2,opt,name=like_stats,json=likeStats,proto3,oneof"`
+}
+
+type ExportUserDataResponse_Moderation_ struct {
+	Moderation *ExportUserDataResponse_Moderation `protobuf:"bytes,3,opt,name=moderation,proto3,oneof"`
+}
+
+type ExportUserDataResponse_Decision_ struct {
+	Decision *ExportUserDataResponse_Decision `protobuf:"bytes,4,opt,name=decision,proto3,oneof"` // Current decision made by the user
+}
+
+type ExportUserDataResponse_LikeReceived struct {
+	LikeReceived *ListLikedYouResponse_Liker `protobuf:"bytes,5,opt,name=like_received,json=likeReceived,proto3,oneof"` // Including the likes hidden from ListLikedYou
+}
+
+type ExportUserDataResponse_Match struct {
+	Match *ListMatchesResponse_Match `protobuf:"bytes,6,opt,name=match,proto3,oneof"`
+}
+
+type ExportUserDataResponse_Blocked struct {
+	Blocked *ListBlockedResponse_BlockedUser `protobuf:"bytes,7,opt,name=blocked,proto3,oneof"`
+}
+
+type ExportUserDataResponse_DecisionEvent struct {
+	DecisionEvent *ListDecisionHistoryResponse_DecisionEvent `protobuf:"bytes,8,opt,name=decision_event,json=decisionEvent,proto3,oneof"` // Decision history of the user
+}
+
+type ExportUserDataResponse_DecisionEventReceived struct {
+	DecisionEventReceived *ListDecisionHistoryResponse_DecisionEvent `protobuf:"bytes,9,opt,name=decision_event_received,json=decisionEventReceived,proto3,oneof"` // Decisions of other users on the user, passes included
+}
+
+type ExportUserDataResponse_ReportFiled struct {
+	ReportFiled *Report `protobuf:"bytes,10,opt,name=report_filed,json=reportFiled,proto3,oneof"` // Without the relationship
+}
+
+type ExportUserDataResponse_ReportAbout struct {
+	ReportAbout *Report `protobuf:"bytes,11,opt,name=report_about,json=reportAbout,proto3,oneof"` // Without the reporter nor the relationship
+}
+
+type ExportUserDataResponse_IdempotencyKey_ struct {
+	IdempotencyKey *ExportUserDataResponse_IdempotencyKey `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof"`
+}
+
+func (*ExportUserDataResponse_User_) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_LikeStats_) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_Moderation_) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_Decision_) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_LikeReceived) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_Match) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_Blocked) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_DecisionEvent) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_DecisionEventReceived) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_ReportFiled) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_ReportAbout) isExportUserDataResponse_Record() {}
+
+func (*ExportUserDataResponse_IdempotencyKey_) isExportUserDataResponse_Record() {}
+
+type WatchLikesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ResumeToken   *string                `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3,oneof" json:"resume_token,omitempty"` // resume_token of the last event received, replays the events missed since
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikesRequest) Reset() {
+	*x = WatchLikesRequest{}
+	mi := &file_explore_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikesRequest) ProtoMessage() {}
+
+func (x *WatchLikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikesRequest.ProtoReflect.Descriptor instead.
+func (*WatchLikesRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WatchLikesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchLikesRequest) GetResumeToken() string {
+	if x != nil && x.ResumeToken != nil {
+		return *x.ResumeToken
+	}
+	return ""
+}
+
+type WatchLikesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchLikesResponse_LikeReceived_
+	//	*WatchLikesResponse_MatchCreated_
+	Event         isWatchLikesResponse_Event `protobuf_oneof:"event"`
+	ResumeToken   string                     `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // Pass it in WatchLikesRequest to resume after this event
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikesResponse) Reset() {
+	*x = WatchLikesResponse{}
+	mi := &file_explore_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikesResponse) ProtoMessage() {}
+
+func (x *WatchLikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikesResponse.ProtoReflect.Descriptor instead.
+func (*WatchLikesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WatchLikesResponse) GetEvent() isWatchLikesResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchLikesResponse) GetLikeReceived() *WatchLikesResponse_LikeReceived {
+	if x != nil {
+		if x, ok := x.Event.(*WatchLikesResponse_LikeReceived_); ok {
+			return x.LikeReceived
+		}
+	}
+	return nil
+}
+
+func (x *WatchLikesResponse) GetMatchCreated() *WatchLikesResponse_MatchCreated {
+	if x != nil {
+		if x, ok := x.Event.(*WatchLikesResponse_MatchCreated_); ok {
+			return x.MatchCreated
+		}
+	}
+	return nil
+}
+
+func (x *WatchLikesResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type isWatchLikesResponse_Event interface {
+	isWatchLikesResponse_Event()
+}
+
+type WatchLikesResponse_LikeReceived_ struct {
+	LikeReceived *WatchLikesResponse_LikeReceived `protobuf:"bytes,1,opt,name=like_received,json=likeReceived,proto3,oneof"`
+}
+
+type WatchLikesResponse_MatchCreated_ struct {
+	MatchCreated *WatchLikesResponse_MatchCreated `protobuf:"bytes,2,opt,name=match_created,json=matchCreated,proto3,oneof"`
+}
+
+func (*WatchLikesResponse_LikeReceived_) isWatchLikesResponse_Event() {}
+
+func (*WatchLikesResponse_MatchCreated_) isWatchLikesResponse_Event() {}
+
+type ListLikedYouResponse_Liker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
 	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListLikedYouResponse_Liker) Reset() {
-	*x = ListLikedYouResponse_Liker{}
-	mi := &file_explore_service_proto_msgTypes[6]
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	mi := &file_explore_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type BatchPutDecisionRequest_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionRequest_Decision) Reset() {
+	*x = BatchPutDecisionRequest_Decision{}
+	mi := &file_explore_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionRequest_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionRequest_Decision) ProtoMessage() {}
+
+func (x *BatchPutDecisionRequest_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionRequest_Decision.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionRequest_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *BatchPutDecisionRequest_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionRequest_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+type BatchPutDecisionResponse_Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`    // Canonical gRPC status code PutDecision would have failed with
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Same reasons as the ErrorInfo of failed calls
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionResponse_Error) Reset() {
+	*x = BatchPutDecisionResponse_Error{}
+	mi := &file_explore_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionResponse_Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionResponse_Error) ProtoMessage() {}
+
+func (x *BatchPutDecisionResponse_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionResponse_Error.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionResponse_Error) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *BatchPutDecisionResponse_Error) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchPutDecisionResponse_Error) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionResponse_Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BatchPutDecisionResponse_Result struct {
+	state           protoimpl.MessageState          `protogen:"open.v1"`
+	RecipientUserId string                          `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	MutualLikes     bool                            `protobuf:"varint,2,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other
+	Error           *BatchPutDecisionResponse_Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                 // Set if this decision was not recorded
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchPutDecisionResponse_Result) Reset() {
+	*x = BatchPutDecisionResponse_Result{}
+	mi := &file_explore_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutDecisionResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutDecisionResponse_Result) ProtoMessage() {}
+
+func (x *BatchPutDecisionResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPutDecisionResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchPutDecisionResponse_Result) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (x *BatchPutDecisionResponse_Result) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *BatchPutDecisionResponse_Result) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+func (x *BatchPutDecisionResponse_Result) GetError() *BatchPutDecisionResponse_Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetDecisionResponse_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	State           DecisionState          `protobuf:"varint,2,opt,name=state,proto3,enum=explore.DecisionState" json:"state,omitempty"`           // DECISION_STATE_NONE if the actor never decided on the recipient
+	UnixTimestamp   uint64                 `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // Time of the latest decision, 0 if there is none
+	Reciprocated    bool                   `protobuf:"varint,4,opt,name=reciprocated,proto3" json:"reciprocated,omitempty"`                        // True if the actor likes the recipient and is liked back
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionResponse_Decision) Reset() {
+	*x = GetDecisionResponse_Decision{}
+	mi := &file_explore_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionResponse_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionResponse_Decision) ProtoMessage() {}
+
+func (x *GetDecisionResponse_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionResponse_Decision.ProtoReflect.Descriptor instead.
+func (*GetDecisionResponse_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *GetDecisionResponse_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionResponse_Decision) GetState() DecisionState {
+	if x != nil {
+		return x.State
+	}
+	return DecisionState_DECISION_STATE_NONE
+}
+
+func (x *GetDecisionResponse_Decision) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *GetDecisionResponse_Decision) GetReciprocated() bool {
+	if x != nil {
+		return x.Reciprocated
+	}
+	return false
+}
+
+type ListDecisionHistoryResponse_DecisionEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp   uint64                 `protobuf:"varint,4,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	Unmatched       bool                   `protobuf:"varint,5,opt,name=unmatched,proto3" json:"unmatched,omitempty"` // True if the pass was recorded by Unmatch
+	Matched         bool                   `protobuf:"varint,6,opt,name=matched,proto3" json:"matched,omitempty"`     // True if the like created a new match
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) Reset() {
+	*x = ListDecisionHistoryResponse_DecisionEvent{}
+	mi := &file_explore_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionHistoryResponse_DecisionEvent) ProtoMessage() {}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionHistoryResponse_DecisionEvent.ProtoReflect.Descriptor instead.
+func (*ListDecisionHistoryResponse_DecisionEvent) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetUnmatched() bool {
+	if x != nil {
+		return x.Unmatched
+	}
+	return false
+}
+
+func (x *ListDecisionHistoryResponse_DecisionEvent) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+type ListMatchesResponse_Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchedUserId string                 `protobuf:"bytes,1,opt,name=matched_user_id,json=matchedUserId,proto3" json:"matched_user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // Time the like completing the match was recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse_Match) Reset() {
+	*x = ListMatchesResponse_Match{}
+	mi := &file_explore_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse_Match) ProtoMessage() {}
+
+func (x *ListMatchesResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse_Match.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse_Match) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *ListMatchesResponse_Match) GetMatchedUserId() string {
+	if x != nil {
+		return x.MatchedUserId
+	}
+	return ""
+}
+
+func (x *ListMatchesResponse_Match) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ListBlockedResponse_BlockedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedUserId string                 `protobuf:"bytes,1,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // Time the block was created
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse_BlockedUser) Reset() {
+	*x = ListBlockedResponse_BlockedUser{}
+	mi := &file_explore_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse_BlockedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse_BlockedUser) ProtoMessage() {}
+
+func (x *ListBlockedResponse_BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse_BlockedUser.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse_BlockedUser) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{27, 0}
+}
+
+func (x *ListBlockedResponse_BlockedUser) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+func (x *ListBlockedResponse_BlockedUser) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type Report_Relationship struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReporterDecision DecisionState          `protobuf:"varint,1,opt,name=reporter_decision,json=reporterDecision,proto3,enum=explore.DecisionState" json:"reporter_decision,omitempty"` // Decision of the reporter on the reported user
+	ReportedDecision DecisionState          `protobuf:"varint,2,opt,name=reported_decision,json=reportedDecision,proto3,enum=explore.DecisionState" json:"reported_decision,omitempty"` // Decision of the reported user on the reporter
+	Matched          bool                   `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	Blocked          bool                   `protobuf:"varint,4,opt,name=blocked,proto3" json:"blocked,omitempty"` // The reporter blocked the reported user
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Report_Relationship) Reset() {
+	*x = Report_Relationship{}
+	mi := &file_explore_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report_Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report_Relationship) ProtoMessage() {}
+
+func (x *Report_Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report_Relationship.ProtoReflect.Descriptor instead.
+func (*Report_Relationship) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{30, 0}
+}
+
+func (x *Report_Relationship) GetReporterDecision() DecisionState {
+	if x != nil {
+		return x.ReporterDecision
+	}
+	return DecisionState_DECISION_STATE_NONE
+}
+
+func (x *Report_Relationship) GetReportedDecision() DecisionState {
+	if x != nil {
+		return x.ReportedDecision
+	}
+	return DecisionState_DECISION_STATE_NONE
+}
+
+func (x *Report_Relationship) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *Report_Relationship) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+type ExportUserDataResponse_User struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	UserId               string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedUnixTimestamp uint64                 `protobuf:"varint,3,opt,name=created_unix_timestamp,json=createdUnixTimestamp,proto3" json:"created_unix_timestamp,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_User) Reset() {
+	*x = ExportUserDataResponse_User{}
+	mi := &file_explore_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_User) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_User) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_User.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_User) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43, 0}
+}
+
+func (x *ExportUserDataResponse_User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_User) GetCreatedUnixTimestamp() uint64 {
+	if x != nil {
+		return x.CreatedUnixTimestamp
+	}
+	return 0
+}
+
+type ExportUserDataResponse_LikeStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikeCount     uint64                 `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`            // Likes counted by CountLikedYou
+	NewLikeCount  uint64                 `protobuf:"varint,2,opt,name=new_like_count,json=newLikeCount,proto3" json:"new_like_count,omitempty"` // Counters returned by GetUserStats
+	MatchCount    uint64                 `protobuf:"varint,3,opt,name=match_count,json=matchCount,proto3" json:"match_count,omitempty"`
+	PassCount     uint64                 `protobuf:"varint,4,opt,name=pass_count,json=passCount,proto3" json:"pass_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_LikeStats) Reset() {
+	*x = ExportUserDataResponse_LikeStats{}
+	mi := &file_explore_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_LikeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_LikeStats) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_LikeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_LikeStats.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_LikeStats) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43, 1}
+}
+
+func (x *ExportUserDataResponse_LikeStats) GetLikeCount() uint64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *ExportUserDataResponse_LikeStats) GetNewLikeCount() uint64 {
+	if x != nil {
+		return x.NewLikeCount
+	}
+	return 0
+}
+
+func (x *ExportUserDataResponse_LikeStats) GetMatchCount() uint64 {
+	if x != nil {
+		return x.MatchCount
+	}
+	return 0
+}
+
+func (x *ExportUserDataResponse_LikeStats) GetPassCount() uint64 {
+	if x != nil {
+		return x.PassCount
+	}
+	return 0
+}
+
+type ExportUserDataResponse_Moderation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warnings      uint32                 `protobuf:"varint,1,opt,name=warnings,proto3" json:"warnings,omitempty"`
+	LikesHidden   bool                   `protobuf:"varint,2,opt,name=likes_hidden,json=likesHidden,proto3" json:"likes_hidden,omitempty"`
+	Banned        bool                   `protobuf:"varint,3,opt,name=banned,proto3" json:"banned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_Moderation) Reset() {
+	*x = ExportUserDataResponse_Moderation{}
+	mi := &file_explore_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_Moderation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_Moderation) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_Moderation) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_Moderation.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_Moderation) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43, 2}
+}
+
+func (x *ExportUserDataResponse_Moderation) GetWarnings() uint32 {
+	if x != nil {
+		return x.Warnings
+	}
+	return 0
+}
+
+func (x *ExportUserDataResponse_Moderation) GetLikesHidden() bool {
+	if x != nil {
+		return x.LikesHidden
+	}
+	return false
+}
+
+func (x *ExportUserDataResponse_Moderation) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+type ExportUserDataResponse_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	Unmatched       bool                   `protobuf:"varint,3,opt,name=unmatched,proto3" json:"unmatched,omitempty"`                              // The pass was recorded by Unmatch or BlockUser
+	UnixTimestamp   uint64                 `protobuf:"varint,4,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // Time of the latest decision on the recipient
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_Decision) Reset() {
+	*x = ExportUserDataResponse_Decision{}
+	mi := &file_explore_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_Decision) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_Decision.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43, 3}
+}
+
+func (x *ExportUserDataResponse_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ExportUserDataResponse_Decision) GetUnmatched() bool {
+	if x != nil {
+		return x.Unmatched
+	}
+	return false
+}
+
+func (x *ExportUserDataResponse_Decision) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ExportUserDataResponse_IdempotencyKey struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey  string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"` // PutDecision the key was first used for
+	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	MutualLikes     bool                   `protobuf:"varint,4,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // Original response
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) Reset() {
+	*x = ExportUserDataResponse_IdempotencyKey{}
+	mi := &file_explore_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_IdempotencyKey) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_IdempotencyKey) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_IdempotencyKey.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_IdempotencyKey) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{43, 4}
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ExportUserDataResponse_IdempotencyKey) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+type WatchLikesResponse_LikeReceived struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikesResponse_LikeReceived) Reset() {
+	*x = WatchLikesResponse_LikeReceived{}
+	mi := &file_explore_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLikedYouResponse_Liker) String() string {
+func (x *WatchLikesResponse_LikeReceived) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+func (*WatchLikesResponse_LikeReceived) ProtoMessage() {}
 
-func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[6]
+func (x *WatchLikesResponse_LikeReceived) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,19 +4041,71 @@ func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
-func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use WatchLikesResponse_LikeReceived.ProtoReflect.Descriptor instead.
+func (*WatchLikesResponse_LikeReceived) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{45, 0}
 }
 
-func (x *ListLikedYouResponse_Liker) GetActorId() string {
+func (x *WatchLikesResponse_LikeReceived) GetActorUserId() string {
 	if x != nil {
-		return x.ActorId
+		return x.ActorUserId
 	}
 	return ""
 }
 
-func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+func (x *WatchLikesResponse_LikeReceived) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type WatchLikesResponse_MatchCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchedUserId string                 `protobuf:"bytes,1,opt,name=matched_user_id,json=matchedUserId,proto3" json:"matched_user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikesResponse_MatchCreated) Reset() {
+	*x = WatchLikesResponse_MatchCreated{}
+	mi := &file_explore_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikesResponse_MatchCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikesResponse_MatchCreated) ProtoMessage() {}
+
+func (x *WatchLikesResponse_MatchCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikesResponse_MatchCreated.ProtoReflect.Descriptor instead.
+func (*WatchLikesResponse_MatchCreated) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{45, 1}
+}
+
+func (x *WatchLikesResponse_MatchCreated) GetMatchedUserId() string {
+	if x != nil {
+		return x.MatchedUserId
+	}
+	return ""
+}
+
+func (x *WatchLikesResponse_MatchCreated) GetUnixTimestamp() uint64 {
 	if x != nil {
 		return x.UnixTimestamp
 	}
@@ -381,36 +4116,355 @@ var File_explore_service_proto protoreflect.FileDescriptor
 
 const file_explore_service_proto_rawDesc = "" +
 	"\n" +
-	"\x15explore-service.proto\x12\aexplore\"\xb6\x01\n" +
+	"\x15explore-service.proto\x12\aexplore\"\x89\x03\n" +
 	"\x13ListLikedYouRequest\x12*\n" +
 	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
 	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
-	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01B\x13\n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x04 \x01(\x0e2\x12.explore.SortOrderR\tsortOrder\x125\n" +
+	"\x14since_unix_timestamp\x18\x05 \x01(\x04H\x02R\x12sinceUnixTimestamp\x88\x01\x01\x125\n" +
+	"\x14until_unix_timestamp\x18\x06 \x01(\x04H\x03R\x12untilUnixTimestamp\x88\x01\x01B\x13\n" +
 	"\x11_pagination_tokenB\f\n" +
 	"\n" +
-	"_page_size\"\xf1\x01\n" +
+	"_page_sizeB\x17\n" +
+	"\x15_since_unix_timestampB\x17\n" +
+	"\x15_until_unix_timestamp\"\xf1\x01\n" +
 	"\x14ListLikedYouResponse\x12;\n" +
 	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
 	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aI\n" +
 	"\x05Liker\x12\x19\n" +
 	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
 	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
-	"\x16_next_pagination_token\"B\n" +
+	"\x16_next_pagination_token\"\xe2\x01\n" +
 	"\x14CountLikedYouRequest\x12*\n" +
-	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"-\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x125\n" +
+	"\x14since_unix_timestamp\x18\x02 \x01(\x04H\x00R\x12sinceUnixTimestamp\x88\x01\x01\x125\n" +
+	"\x14until_unix_timestamp\x18\x03 \x01(\x04H\x01R\x12untilUnixTimestamp\x88\x01\x01B\x17\n" +
+	"\x15_since_unix_timestampB\x17\n" +
+	"\x15_until_unix_timestamp\"-\n" +
 	"\x15CountLikedYouResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x04R\x05count\"\x8d\x01\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\".\n" +
+	"\x13GetUserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9b\x01\n" +
+	"\x14GetUserStatsResponse\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x01 \x01(\x04R\tlikeCount\x12$\n" +
+	"\x0enew_like_count\x18\x02 \x01(\x04R\fnewLikeCount\x12\x1f\n" +
+	"\vmatch_count\x18\x03 \x01(\x04R\n" +
+	"matchCount\x12\x1d\n" +
+	"\n" +
+	"pass_count\x18\x04 \x01(\x04R\tpassCount\"\xcf\x01\n" +
 	"\x12PutDecisionRequest\x12\"\n" +
 	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
 	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
-	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\"8\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12,\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01B\x12\n" +
+	"\x10_idempotency_key\"8\n" +
 	"\x13PutDecisionResponse\x12!\n" +
-	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes2\xc7\x02\n" +
+	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes\"\xe7\x01\n" +
+	"\x17BatchPutDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12G\n" +
+	"\tdecisions\x18\x02 \x03(\v2).explore.BatchPutDecisionRequest.DecisionR\tdecisions\x1a_\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\"\xc6\x02\n" +
+	"\x18BatchPutDecisionResponse\x12B\n" +
+	"\aresults\x18\x01 \x03(\v2(.explore.BatchPutDecisionResponse.ResultR\aresults\x1aM\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x1a\x96\x01\n" +
+	"\x06Result\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12!\n" +
+	"\fmutual_likes\x18\x02 \x01(\bR\vmutualLikes\x12=\n" +
+	"\x05error\x18\x03 \x01(\v2'.explore.BatchPutDecisionResponse.ErrorR\x05error\"d\n" +
+	"\x12GetDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"\x8a\x02\n" +
+	"\x13GetDecisionResponse\x12A\n" +
+	"\bdecision\x18\x01 \x01(\v2%.explore.GetDecisionResponse.DecisionR\bdecision\x1a\xaf\x01\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12,\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x16.explore.DecisionStateR\x05state\x12%\n" +
+	"\x0eunix_timestamp\x18\x03 \x01(\x04R\runixTimestamp\x12\"\n" +
+	"\freciprocated\x18\x04 \x01(\bR\freciprocated\"k\n" +
+	"\x17BatchGetDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12,\n" +
+	"\x12recipient_user_ids\x18\x02 \x03(\tR\x10recipientUserIds\"_\n" +
+	"\x18BatchGetDecisionResponse\x12C\n" +
+	"\tdecisions\x18\x01 \x03(\v2%.explore.GetDecisionResponse.DecisionR\tdecisions\"\xa0\x02\n" +
+	"\x16ListMyDecisionsRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12:\n" +
+	"\rdecision_type\x18\x02 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionType\x12.\n" +
+	"\x10pagination_token\x18\x03 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\rH\x01R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\x0e2\x12.explore.SortOrderR\tsortOrderB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xb1\x01\n" +
+	"\x17ListMyDecisionsResponse\x12C\n" +
+	"\tdecisions\x18\x01 \x03(\v2%.explore.GetDecisionResponse.DecisionR\tdecisions\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01B\x18\n" +
+	"\x16_next_pagination_token\"\xaf\x02\n" +
+	"\x1aListDecisionHistoryRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12/\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tH\x00R\x0frecipientUserId\x88\x01\x01\x12.\n" +
+	"\x10pagination_token\x18\x03 \x01(\tH\x01R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\rH\x02R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\x0e2\x12.explore.SortOrderR\tsortOrderB\x14\n" +
+	"\x12_recipient_user_idB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xa6\x03\n" +
+	"\x1bListDecisionHistoryResponse\x12J\n" +
+	"\x06events\x18\x01 \x03(\v22.explore.ListDecisionHistoryResponse.DecisionEventR\x06events\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\xe7\x01\n" +
+	"\rDecisionEvent\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x04 \x01(\x04R\runixTimestamp\x12\x1c\n" +
+	"\tunmatched\x18\x05 \x01(\bR\tunmatched\x12\x18\n" +
+	"\amatched\x18\x06 \x01(\bR\amatchedB\x18\n" +
+	"\x16_next_pagination_token\"\xd5\x01\n" +
+	"\x12ListMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x04 \x01(\x0e2\x12.explore.SortOrderR\tsortOrderB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xfe\x01\n" +
+	"\x13ListMatchesResponse\x12<\n" +
+	"\amatches\x18\x01 \x03(\v2\".explore.ListMatchesResponse.MatchR\amatches\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aV\n" +
+	"\x05Match\x12&\n" +
+	"\x0fmatched_user_id\x18\x01 \x01(\tR\rmatchedUserId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"X\n" +
+	"\x0eUnmatchRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12\"\n" +
+	"\rother_user_id\x18\x02 \x01(\tR\votherUserId\"\x11\n" +
+	"\x0fUnmatchResponse\"b\n" +
+	"\x10BlockUserRequest\x12&\n" +
+	"\x0fblocker_user_id\x18\x01 \x01(\tR\rblockerUserId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x13\n" +
+	"\x11BlockUserResponse\"d\n" +
+	"\x12UnblockUserRequest\x12&\n" +
+	"\x0fblocker_user_id\x18\x01 \x01(\tR\rblockerUserId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x15\n" +
+	"\x13UnblockUserResponse\"\xd5\x01\n" +
+	"\x12ListBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x04 \x01(\x0e2\x12.explore.SortOrderR\tsortOrderB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\x8a\x02\n" +
+	"\x13ListBlockedResponse\x12B\n" +
+	"\ablocked\x18\x01 \x03(\v2(.explore.ListBlockedResponse.BlockedUserR\ablocked\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\\\n" +
+	"\vBlockedUser\x12&\n" +
+	"\x0fblocked_user_id\x18\x01 \x01(\tR\rblockedUserId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"\xb0\x01\n" +
+	"\x11ReportUserRequest\x12(\n" +
+	"\x10reporter_user_id\x18\x01 \x01(\tR\x0ereporterUserId\x12(\n" +
+	"\x10reported_user_id\x18\x02 \x01(\tR\x0ereportedUserId\x12-\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x15.explore.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x04 \x01(\tR\adetails\"1\n" +
+	"\x12ReportUserResponse\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x04R\breportId\"\xa7\x05\n" +
+	"\x06Report\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x04R\breportId\x12(\n" +
+	"\x10reporter_user_id\x18\x02 \x01(\tR\x0ereporterUserId\x12(\n" +
+	"\x10reported_user_id\x18\x03 \x01(\tR\x0ereportedUserId\x12-\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x15.explore.ReportReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\x12-\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x15.explore.ReportStatusR\x06status\x12!\n" +
+	"\fmoderator_id\x18\a \x01(\tR\vmoderatorId\x120\n" +
+	"\aoutcome\x18\b \x01(\x0e2\x16.explore.ReportOutcomeR\aoutcome\x12'\n" +
+	"\x0fresolution_note\x18\t \x01(\tR\x0eresolutionNote\x12%\n" +
+	"\x0eunix_timestamp\x18\n" +
+	" \x01(\x04R\runixTimestamp\x12@\n" +
+	"\frelationship\x18\v \x01(\v2\x1c.explore.Report.RelationshipR\frelationship\x1a\xcc\x01\n" +
+	"\fRelationship\x12C\n" +
+	"\x11reporter_decision\x18\x01 \x01(\x0e2\x16.explore.DecisionStateR\x10reporterDecision\x12C\n" +
+	"\x11reported_decision\x18\x02 \x01(\x0e2\x16.explore.DecisionStateR\x10reportedDecision\x12\x18\n" +
+	"\amatched\x18\x03 \x01(\bR\amatched\x12\x18\n" +
+	"\ablocked\x18\x04 \x01(\bR\ablocked\"\xeb\x01\n" +
+	"\x12ListReportsRequest\x12-\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x15.explore.ReportStatusR\x06status\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x121\n" +
+	"\n" +
+	"sort_order\x18\x04 \x01(\x0e2\x12.explore.SortOrderR\tsortOrderB\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\x93\x01\n" +
+	"\x13ListReportsResponse\x12)\n" +
+	"\areports\x18\x01 \x03(\v2\x0f.explore.ReportR\areports\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01B\x18\n" +
+	"\x16_next_pagination_token\"T\n" +
+	"\x12ClaimReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x04R\breportId\x12!\n" +
+	"\fmoderator_id\x18\x02 \x01(\tR\vmoderatorId\">\n" +
+	"\x13ClaimReportResponse\x12'\n" +
+	"\x06report\x18\x01 \x01(\v2\x0f.explore.ReportR\x06report\"\x9c\x01\n" +
+	"\x14ResolveReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x04R\breportId\x12!\n" +
+	"\fmoderator_id\x18\x02 \x01(\tR\vmoderatorId\x120\n" +
+	"\aoutcome\x18\x03 \x01(\x0e2\x16.explore.ReportOutcomeR\aoutcome\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"@\n" +
+	"\x15ResolveReportResponse\x12'\n" +
+	"\x06report\x18\x01 \x01(\v2\x0f.explore.ReportR\x06report\"\xc9\x03\n" +
+	"\fUserDeletion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\bR\tcompleted\x12+\n" +
+	"\x11decisions_deleted\x18\x03 \x01(\x04R\x10decisionsDeleted\x120\n" +
+	"\x14like_counts_repaired\x18\x04 \x01(\x04R\x12likeCountsRepaired\x126\n" +
+	"\x17decision_events_deleted\x18\x05 \x01(\x04R\x15decisionEventsDeleted\x12'\n" +
+	"\x0fmatches_deleted\x18\x06 \x01(\x04R\x0ematchesDeleted\x12%\n" +
+	"\x0eblocks_deleted\x18\a \x01(\x04R\rblocksDeleted\x12'\n" +
+	"\x0freports_deleted\x18\b \x01(\x04R\x0ereportsDeleted\x128\n" +
+	"\x18requested_unix_timestamp\x18\t \x01(\x04R\x16requestedUnixTimestamp\x128\n" +
+	"\x18completed_unix_timestamp\x18\n" +
+	" \x01(\x04R\x16completedUnixTimestamp\",\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"G\n" +
+	"\x12DeleteUserResponse\x121\n" +
+	"\bdeletion\x18\x01 \x01(\v2\x15.explore.UserDeletionR\bdeletion\"1\n" +
+	"\x16GetUserDeletionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x17GetUserDeletionResponse\x121\n" +
+	"\bdeletion\x18\x01 \x01(\v2\x15.explore.UserDeletionR\bdeletion\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xde\f\n" +
+	"\x16ExportUserDataResponse\x12:\n" +
+	"\x04user\x18\x01 \x01(\v2$.explore.ExportUserDataResponse.UserH\x00R\x04user\x12J\n" +
+	"\n" +
+	"like_stats\x18\x02 \x01(\v2).explore.ExportUserDataResponse.LikeStatsH\x00R\tlikeStats\x12L\n" +
+	"\n" +
+	"moderation\x18\x03 \x01(\v2*.explore.ExportUserDataResponse.ModerationH\x00R\n" +
+	"moderation\x12F\n" +
+	"\bdecision\x18\x04 \x01(\v2(.explore.ExportUserDataResponse.DecisionH\x00R\bdecision\x12J\n" +
+	"\rlike_received\x18\x05 \x01(\v2#.explore.ListLikedYouResponse.LikerH\x00R\flikeReceived\x12:\n" +
+	"\x05match\x18\x06 \x01(\v2\".explore.ListMatchesResponse.MatchH\x00R\x05match\x12D\n" +
+	"\ablocked\x18\a \x01(\v2(.explore.ListBlockedResponse.BlockedUserH\x00R\ablocked\x12[\n" +
+	"\x0edecision_event\x18\b \x01(\v22.explore.ListDecisionHistoryResponse.DecisionEventH\x00R\rdecisionEvent\x12l\n" +
+	"\x17decision_event_received\x18\t \x01(\v22.explore.ListDecisionHistoryResponse.DecisionEventH\x00R\x15decisionEventReceived\x124\n" +
+	"\freport_filed\x18\n" +
+	" \x01(\v2\x0f.explore.ReportH\x00R\vreportFiled\x124\n" +
+	"\freport_about\x18\v \x01(\v2\x0f.explore.ReportH\x00R\vreportAbout\x12Y\n" +
+	"\x0fidempotency_key\x18\f \x01(\v2..explore.ExportUserDataResponse.IdempotencyKeyH\x00R\x0eidempotencyKey\x1ai\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\x16created_unix_timestamp\x18\x03 \x01(\x04R\x14createdUnixTimestamp\x1a\x90\x01\n" +
+	"\tLikeStats\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x01 \x01(\x04R\tlikeCount\x12$\n" +
+	"\x0enew_like_count\x18\x02 \x01(\x04R\fnewLikeCount\x12\x1f\n" +
+	"\vmatch_count\x18\x03 \x01(\x04R\n" +
+	"matchCount\x12\x1d\n" +
+	"\n" +
+	"pass_count\x18\x04 \x01(\x04R\tpassCount\x1ac\n" +
+	"\n" +
+	"Moderation\x12\x1a\n" +
+	"\bwarnings\x18\x01 \x01(\rR\bwarnings\x12!\n" +
+	"\flikes_hidden\x18\x02 \x01(\bR\vlikesHidden\x12\x16\n" +
+	"\x06banned\x18\x03 \x01(\bR\x06banned\x1a\xa4\x01\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12\x1c\n" +
+	"\tunmatched\x18\x03 \x01(\bR\tunmatched\x12%\n" +
+	"\x0eunix_timestamp\x18\x04 \x01(\x04R\runixTimestamp\x1a\xb1\x01\n" +
+	"\x0eIdempotencyKey\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12!\n" +
+	"\fmutual_likes\x18\x04 \x01(\bR\vmutualLikesB\b\n" +
+	"\x06record\"e\n" +
+	"\x11WatchLikesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\fresume_token\x18\x02 \x01(\tH\x00R\vresumeToken\x88\x01\x01B\x0f\n" +
+	"\r_resume_token\"\x9c\x03\n" +
+	"\x12WatchLikesResponse\x12O\n" +
+	"\rlike_received\x18\x01 \x01(\v2(.explore.WatchLikesResponse.LikeReceivedH\x00R\flikeReceived\x12O\n" +
+	"\rmatch_created\x18\x02 \x01(\v2(.explore.WatchLikesResponse.MatchCreatedH\x00R\fmatchCreated\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\x1aY\n" +
+	"\fLikeReceived\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x1a]\n" +
+	"\fMatchCreated\x12&\n" +
+	"\x0fmatched_user_id\x18\x01 \x01(\tR\rmatchedUserId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\a\n" +
+	"\x05event*a\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SORT_ORDER_OLDEST_FIRST\x10\x01\x12\x1b\n" +
+	"\x17SORT_ORDER_NEWEST_FIRST\x10\x02*\xe1\x01\n" +
+	"\fReportReason\x12\x1d\n" +
+	"\x19REPORT_REASON_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_REASON_SPAM\x10\x01\x12\x1c\n" +
+	"\x18REPORT_REASON_HARASSMENT\x10\x02\x12'\n" +
+	"#REPORT_REASON_INAPPROPRIATE_CONTENT\x10\x03\x12\x1e\n" +
+	"\x1aREPORT_REASON_FAKE_PROFILE\x10\x04\x12\x1a\n" +
+	"\x16REPORT_REASON_UNDERAGE\x10\x05\x12\x17\n" +
+	"\x13REPORT_REASON_OTHER\x10\x06*|\n" +
+	"\fReportStatus\x12\x1d\n" +
+	"\x19REPORT_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12REPORT_STATUS_OPEN\x10\x01\x12\x19\n" +
+	"\x15REPORT_STATUS_CLAIMED\x10\x02\x12\x1a\n" +
+	"\x16REPORT_STATUS_RESOLVED\x10\x03*\xa5\x01\n" +
+	"\rReportOutcome\x12\x1e\n" +
+	"\x1aREPORT_OUTCOME_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18REPORT_OUTCOME_DISMISSED\x10\x01\x12\x1a\n" +
+	"\x16REPORT_OUTCOME_WARNING\x10\x02\x12\x1f\n" +
+	"\x1bREPORT_OUTCOME_LIKES_HIDDEN\x10\x03\x12\x19\n" +
+	"\x15REPORT_OUTCOME_BANNED\x10\x04*{\n" +
+	"\rDecisionState\x12\x17\n" +
+	"\x13DECISION_STATE_NONE\x10\x00\x12\x18\n" +
+	"\x14DECISION_STATE_LIKED\x10\x01\x12\x19\n" +
+	"\x15DECISION_STATE_PASSED\x10\x02\x12\x1c\n" +
+	"\x18DECISION_STATE_UNMATCHED\x10\x03*]\n" +
+	"\fDecisionType\x12\x1d\n" +
+	"\x19DECISION_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DECISION_TYPE_LIKE\x10\x01\x12\x16\n" +
+	"\x12DECISION_TYPE_PASS\x10\x022\x8e\x0e\n" +
 	"\x0eExploreService\x12K\n" +
 	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
 	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
-	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12H\n" +
-	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponseB<Z:github.com/benrod407/explore-service/explore_service_protob\x06proto3"
+	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12K\n" +
+	"\fGetUserStats\x12\x1c.explore.GetUserStatsRequest\x1a\x1d.explore.GetUserStatsResponse\x12H\n" +
+	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponse\x12W\n" +
+	"\x10BatchPutDecision\x12 .explore.BatchPutDecisionRequest\x1a!.explore.BatchPutDecisionResponse\x12H\n" +
+	"\vGetDecision\x12\x1b.explore.GetDecisionRequest\x1a\x1c.explore.GetDecisionResponse\x12W\n" +
+	"\x10BatchGetDecision\x12 .explore.BatchGetDecisionRequest\x1a!.explore.BatchGetDecisionResponse\x12T\n" +
+	"\x0fListMyDecisions\x12\x1f.explore.ListMyDecisionsRequest\x1a .explore.ListMyDecisionsResponse\x12`\n" +
+	"\x13ListDecisionHistory\x12#.explore.ListDecisionHistoryRequest\x1a$.explore.ListDecisionHistoryResponse\x12H\n" +
+	"\vListMatches\x12\x1b.explore.ListMatchesRequest\x1a\x1c.explore.ListMatchesResponse\x12<\n" +
+	"\aUnmatch\x12\x17.explore.UnmatchRequest\x1a\x18.explore.UnmatchResponse\x12B\n" +
+	"\tBlockUser\x12\x19.explore.BlockUserRequest\x1a\x1a.explore.BlockUserResponse\x12H\n" +
+	"\vUnblockUser\x12\x1b.explore.UnblockUserRequest\x1a\x1c.explore.UnblockUserResponse\x12H\n" +
+	"\vListBlocked\x12\x1b.explore.ListBlockedRequest\x1a\x1c.explore.ListBlockedResponse\x12E\n" +
+	"\n" +
+	"ReportUser\x12\x1a.explore.ReportUserRequest\x1a\x1b.explore.ReportUserResponse\x12H\n" +
+	"\vListReports\x12\x1b.explore.ListReportsRequest\x1a\x1c.explore.ListReportsResponse\x12H\n" +
+	"\vClaimReport\x12\x1b.explore.ClaimReportRequest\x1a\x1c.explore.ClaimReportResponse\x12N\n" +
+	"\rResolveReport\x12\x1d.explore.ResolveReportRequest\x1a\x1e.explore.ResolveReportResponse\x12E\n" +
+	"\n" +
+	"DeleteUser\x12\x1a.explore.DeleteUserRequest\x1a\x1b.explore.DeleteUserResponse\x12T\n" +
+	"\x0fGetUserDeletion\x12\x1f.explore.GetUserDeletionRequest\x1a .explore.GetUserDeletionResponse\x12S\n" +
+	"\x0eExportUserData\x12\x1e.explore.ExportUserDataRequest\x1a\x1f.explore.ExportUserDataResponse0\x01\x12G\n" +
+	"\n" +
+	"WatchLikes\x12\x1a.explore.WatchLikesRequest\x1a\x1b.explore.WatchLikesResponse0\x01B<Z:github.com/benrod407/explore-service/explore_service_protob\x06proto3"
 
 var (
 	file_explore_service_proto_rawDescOnce sync.Once
@@ -424,31 +4478,176 @@ func file_explore_service_proto_rawDescGZIP() []byte {
 	return file_explore_service_proto_rawDescData
 }
 
-var file_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_explore_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_explore_service_proto_goTypes = []any{
-	(*ListLikedYouRequest)(nil),        // 0: explore.ListLikedYouRequest
-	(*ListLikedYouResponse)(nil),       // 1: explore.ListLikedYouResponse
-	(*CountLikedYouRequest)(nil),       // 2: explore.CountLikedYouRequest
-	(*CountLikedYouResponse)(nil),      // 3: explore.CountLikedYouResponse
-	(*PutDecisionRequest)(nil),         // 4: explore.PutDecisionRequest
-	(*PutDecisionResponse)(nil),        // 5: explore.PutDecisionResponse
-	(*ListLikedYouResponse_Liker)(nil), // 6: explore.ListLikedYouResponse.Liker
+	(SortOrder)(0),                                    // 0: explore.SortOrder
+	(ReportReason)(0),                                 // 1: explore.ReportReason
+	(ReportStatus)(0),                                 // 2: explore.ReportStatus
+	(ReportOutcome)(0),                                // 3: explore.ReportOutcome
+	(DecisionState)(0),                                // 4: explore.DecisionState
+	(DecisionType)(0),                                 // 5: explore.DecisionType
+	(*ListLikedYouRequest)(nil),                       // 6: explore.ListLikedYouRequest
+	(*ListLikedYouResponse)(nil),                      // 7: explore.ListLikedYouResponse
+	(*CountLikedYouRequest)(nil),                      // 8: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),                     // 9: explore.CountLikedYouResponse
+	(*GetUserStatsRequest)(nil),                       // 10: explore.GetUserStatsRequest
+	(*GetUserStatsResponse)(nil),                      // 11: explore.GetUserStatsResponse
+	(*PutDecisionRequest)(nil),                        // 12: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),                       // 13: explore.PutDecisionResponse
+	(*BatchPutDecisionRequest)(nil),                   // 14: explore.BatchPutDecisionRequest
+	(*BatchPutDecisionResponse)(nil),                  // 15: explore.BatchPutDecisionResponse
+	(*GetDecisionRequest)(nil),                        // 16: explore.GetDecisionRequest
+	(*GetDecisionResponse)(nil),                       // 17: explore.GetDecisionResponse
+	(*BatchGetDecisionRequest)(nil),                   // 18: explore.BatchGetDecisionRequest
+	(*BatchGetDecisionResponse)(nil),                  // 19: explore.BatchGetDecisionResponse
+	(*ListMyDecisionsRequest)(nil),                    // 20: explore.ListMyDecisionsRequest
+	(*ListMyDecisionsResponse)(nil),                   // 21: explore.ListMyDecisionsResponse
+	(*ListDecisionHistoryRequest)(nil),                // 22: explore.ListDecisionHistoryRequest
+	(*ListDecisionHistoryResponse)(nil),               // 23: explore.ListDecisionHistoryResponse
+	(*ListMatchesRequest)(nil),                        // 24: explore.ListMatchesRequest
+	(*ListMatchesResponse)(nil),                       // 25: explore.ListMatchesResponse
+	(*UnmatchRequest)(nil),                            // 26: explore.UnmatchRequest
+	(*UnmatchResponse)(nil),                           // 27: explore.UnmatchResponse
+	(*BlockUserRequest)(nil),                          // 28: explore.BlockUserRequest
+	(*BlockUserResponse)(nil),                         // 29: explore.BlockUserResponse
+	(*UnblockUserRequest)(nil),                        // 30: explore.UnblockUserRequest
+	(*UnblockUserResponse)(nil),                       // 31: explore.UnblockUserResponse
+	(*ListBlockedRequest)(nil),                        // 32: explore.ListBlockedRequest
+	(*ListBlockedResponse)(nil),                       // 33: explore.ListBlockedResponse
+	(*ReportUserRequest)(nil),                         // 34: explore.ReportUserRequest
+	(*ReportUserResponse)(nil),                        // 35: explore.ReportUserResponse
+	(*Report)(nil),                                    // 36: explore.Report
+	(*ListReportsRequest)(nil),                        // 37: explore.ListReportsRequest
+	(*ListReportsResponse)(nil),                       // 38: explore.ListReportsResponse
+	(*ClaimReportRequest)(nil),                        // 39: explore.ClaimReportRequest
+	(*ClaimReportResponse)(nil),                       // 40: explore.ClaimReportResponse
+	(*ResolveReportRequest)(nil),                      // 41: explore.ResolveReportRequest
+	(*ResolveReportResponse)(nil),                     // 42: explore.ResolveReportResponse
+	(*UserDeletion)(nil),                              // 43: explore.UserDeletion
+	(*DeleteUserRequest)(nil),                         // 44: explore.DeleteUserRequest
+	(*DeleteUserResponse)(nil),                        // 45: explore.DeleteUserResponse
+	(*GetUserDeletionRequest)(nil),                    // 46: explore.GetUserDeletionRequest
+	(*GetUserDeletionResponse)(nil),                   // 47: explore.GetUserDeletionResponse
+	(*ExportUserDataRequest)(nil),                     // 48: explore.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),                    // 49: explore.ExportUserDataResponse
+	(*WatchLikesRequest)(nil),                         // 50: explore.WatchLikesRequest
+	(*WatchLikesResponse)(nil),                        // 51: explore.WatchLikesResponse
+	(*ListLikedYouResponse_Liker)(nil),                // 52: explore.ListLikedYouResponse.Liker
+	(*BatchPutDecisionRequest_Decision)(nil),          // 53: explore.BatchPutDecisionRequest.Decision
+	(*BatchPutDecisionResponse_Error)(nil),            // 54: explore.BatchPutDecisionResponse.Error
+	(*BatchPutDecisionResponse_Result)(nil),           // 55: explore.BatchPutDecisionResponse.Result
+	(*GetDecisionResponse_Decision)(nil),              // 56: explore.GetDecisionResponse.Decision
+	(*ListDecisionHistoryResponse_DecisionEvent)(nil), // 57: explore.ListDecisionHistoryResponse.DecisionEvent
+	(*ListMatchesResponse_Match)(nil),                 // 58: explore.ListMatchesResponse.Match
+	(*ListBlockedResponse_BlockedUser)(nil),           // 59: explore.ListBlockedResponse.BlockedUser
+	(*Report_Relationship)(nil),                       // 60: explore.Report.Relationship
+	(*ExportUserDataResponse_User)(nil),               // 61: explore.ExportUserDataResponse.User
+	(*ExportUserDataResponse_LikeStats)(nil),          // 62: explore.ExportUserDataResponse.LikeStats
+	(*ExportUserDataResponse_Moderation)(nil),         // 63: explore.ExportUserDataResponse.Moderation
+	(*ExportUserDataResponse_Decision)(nil),           // 64: explore.ExportUserDataResponse.Decision
+	(*ExportUserDataResponse_IdempotencyKey)(nil),     // 65: explore.ExportUserDataResponse.IdempotencyKey
+	(*WatchLikesResponse_LikeReceived)(nil),           // 66: explore.WatchLikesResponse.LikeReceived
+	(*WatchLikesResponse_MatchCreated)(nil),           // 67: explore.WatchLikesResponse.MatchCreated
 }
 var file_explore_service_proto_depIdxs = []int32{
-	6, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
-	0, // 1: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
-	0, // 2: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
-	2, // 3: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
-	4, // 4: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
-	1, // 5: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
-	1, // 6: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
-	3, // 7: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
-	5, // 8: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: explore.ListLikedYouRequest.sort_order:type_name -> explore.SortOrder
+	52, // 1: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	53, // 2: explore.BatchPutDecisionRequest.decisions:type_name -> explore.BatchPutDecisionRequest.Decision
+	55, // 3: explore.BatchPutDecisionResponse.results:type_name -> explore.BatchPutDecisionResponse.Result
+	56, // 4: explore.GetDecisionResponse.decision:type_name -> explore.GetDecisionResponse.Decision
+	56, // 5: explore.BatchGetDecisionResponse.decisions:type_name -> explore.GetDecisionResponse.Decision
+	5,  // 6: explore.ListMyDecisionsRequest.decision_type:type_name -> explore.DecisionType
+	0,  // 7: explore.ListMyDecisionsRequest.sort_order:type_name -> explore.SortOrder
+	56, // 8: explore.ListMyDecisionsResponse.decisions:type_name -> explore.GetDecisionResponse.Decision
+	0,  // 9: explore.ListDecisionHistoryRequest.sort_order:type_name -> explore.SortOrder
+	57, // 10: explore.ListDecisionHistoryResponse.events:type_name -> explore.ListDecisionHistoryResponse.DecisionEvent
+	0,  // 11: explore.ListMatchesRequest.sort_order:type_name -> explore.SortOrder
+	58, // 12: explore.ListMatchesResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	0,  // 13: explore.ListBlockedRequest.sort_order:type_name -> explore.SortOrder
+	59, // 14: explore.ListBlockedResponse.blocked:type_name -> explore.ListBlockedResponse.BlockedUser
+	1,  // 15: explore.ReportUserRequest.reason:type_name -> explore.ReportReason
+	1,  // 16: explore.Report.reason:type_name -> explore.ReportReason
+	2,  // 17: explore.Report.status:type_name -> explore.ReportStatus
+	3,  // 18: explore.Report.outcome:type_name -> explore.ReportOutcome
+	60, // 19: explore.Report.relationship:type_name -> explore.Report.Relationship
+	2,  // 20: explore.ListReportsRequest.status:type_name -> explore.ReportStatus
+	0,  // 21: explore.ListReportsRequest.sort_order:type_name -> explore.SortOrder
+	36, // 22: explore.ListReportsResponse.reports:type_name -> explore.Report
+	36, // 23: explore.ClaimReportResponse.report:type_name -> explore.Report
+	3,  // 24: explore.ResolveReportRequest.outcome:type_name -> explore.ReportOutcome
+	36, // 25: explore.ResolveReportResponse.report:type_name -> explore.Report
+	43, // 26: explore.DeleteUserResponse.deletion:type_name -> explore.UserDeletion
+	43, // 27: explore.GetUserDeletionResponse.deletion:type_name -> explore.UserDeletion
+	61, // 28: explore.ExportUserDataResponse.user:type_name -> explore.ExportUserDataResponse.User
+	62, // 29: explore.ExportUserDataResponse.like_stats:type_name -> explore.ExportUserDataResponse.LikeStats
+	63, // 30: explore.ExportUserDataResponse.moderation:type_name -> explore.ExportUserDataResponse.Moderation
+	64, // 31: explore.ExportUserDataResponse.decision:type_name -> explore.ExportUserDataResponse.Decision
+	52, // 32: explore.ExportUserDataResponse.like_received:type_name -> explore.ListLikedYouResponse.Liker
+	58, // 33: explore.ExportUserDataResponse.match:type_name -> explore.ListMatchesResponse.Match
+	59, // 34: explore.ExportUserDataResponse.blocked:type_name -> explore.ListBlockedResponse.BlockedUser
+	57, // 35: explore.ExportUserDataResponse.decision_event:type_name -> explore.ListDecisionHistoryResponse.DecisionEvent
+	57, // 36: explore.ExportUserDataResponse.decision_event_received:type_name -> explore.ListDecisionHistoryResponse.DecisionEvent
+	36, // 37: explore.ExportUserDataResponse.report_filed:type_name -> explore.Report
+	36, // 38: explore.ExportUserDataResponse.report_about:type_name -> explore.Report
+	65, // 39: explore.ExportUserDataResponse.idempotency_key:type_name -> explore.ExportUserDataResponse.IdempotencyKey
+	66, // 40: explore.WatchLikesResponse.like_received:type_name -> explore.WatchLikesResponse.LikeReceived
+	67, // 41: explore.WatchLikesResponse.match_created:type_name -> explore.WatchLikesResponse.MatchCreated
+	54, // 42: explore.BatchPutDecisionResponse.Result.error:type_name -> explore.BatchPutDecisionResponse.Error
+	4,  // 43: explore.GetDecisionResponse.Decision.state:type_name -> explore.DecisionState
+	4,  // 44: explore.Report.Relationship.reporter_decision:type_name -> explore.DecisionState
+	4,  // 45: explore.Report.Relationship.reported_decision:type_name -> explore.DecisionState
+	6,  // 46: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	6,  // 47: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	8,  // 48: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	10, // 49: explore.ExploreService.GetUserStats:input_type -> explore.GetUserStatsRequest
+	12, // 50: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	14, // 51: explore.ExploreService.BatchPutDecision:input_type -> explore.BatchPutDecisionRequest
+	16, // 52: explore.ExploreService.GetDecision:input_type -> explore.GetDecisionRequest
+	18, // 53: explore.ExploreService.BatchGetDecision:input_type -> explore.BatchGetDecisionRequest
+	20, // 54: explore.ExploreService.ListMyDecisions:input_type -> explore.ListMyDecisionsRequest
+	22, // 55: explore.ExploreService.ListDecisionHistory:input_type -> explore.ListDecisionHistoryRequest
+	24, // 56: explore.ExploreService.ListMatches:input_type -> explore.ListMatchesRequest
+	26, // 57: explore.ExploreService.Unmatch:input_type -> explore.UnmatchRequest
+	28, // 58: explore.ExploreService.BlockUser:input_type -> explore.BlockUserRequest
+	30, // 59: explore.ExploreService.UnblockUser:input_type -> explore.UnblockUserRequest
+	32, // 60: explore.ExploreService.ListBlocked:input_type -> explore.ListBlockedRequest
+	34, // 61: explore.ExploreService.ReportUser:input_type -> explore.ReportUserRequest
+	37, // 62: explore.ExploreService.ListReports:input_type -> explore.ListReportsRequest
+	39, // 63: explore.ExploreService.ClaimReport:input_type -> explore.ClaimReportRequest
+	41, // 64: explore.ExploreService.ResolveReport:input_type -> explore.ResolveReportRequest
+	44, // 65: explore.ExploreService.DeleteUser:input_type -> explore.DeleteUserRequest
+	46, // 66: explore.ExploreService.GetUserDeletion:input_type -> explore.GetUserDeletionRequest
+	48, // 67: explore.ExploreService.ExportUserData:input_type -> explore.ExportUserDataRequest
+	50, // 68: explore.ExploreService.WatchLikes:input_type -> explore.WatchLikesRequest
+	7,  // 69: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	7,  // 70: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	9,  // 71: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	11, // 72: explore.ExploreService.GetUserStats:output_type -> explore.GetUserStatsResponse
+	13, // 73: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	15, // 74: explore.ExploreService.BatchPutDecision:output_type -> explore.BatchPutDecisionResponse
+	17, // 75: explore.ExploreService.GetDecision:output_type -> explore.GetDecisionResponse
+	19, // 76: explore.ExploreService.BatchGetDecision:output_type -> explore.BatchGetDecisionResponse
+	21, // 77: explore.ExploreService.ListMyDecisions:output_type -> explore.ListMyDecisionsResponse
+	23, // 78: explore.ExploreService.ListDecisionHistory:output_type -> explore.ListDecisionHistoryResponse
+	25, // 79: explore.ExploreService.ListMatches:output_type -> explore.ListMatchesResponse
+	27, // 80: explore.ExploreService.Unmatch:output_type -> explore.UnmatchResponse
+	29, // 81: explore.ExploreService.BlockUser:output_type -> explore.BlockUserResponse
+	31, // 82: explore.ExploreService.UnblockUser:output_type -> explore.UnblockUserResponse
+	33, // 83: explore.ExploreService.ListBlocked:output_type -> explore.ListBlockedResponse
+	35, // 84: explore.ExploreService.ReportUser:output_type -> explore.ReportUserResponse
+	38, // 85: explore.ExploreService.ListReports:output_type -> explore.ListReportsResponse
+	40, // 86: explore.ExploreService.ClaimReport:output_type -> explore.ClaimReportResponse
+	42, // 87: explore.ExploreService.ResolveReport:output_type -> explore.ResolveReportResponse
+	45, // 88: explore.ExploreService.DeleteUser:output_type -> explore.DeleteUserResponse
+	47, // 89: explore.ExploreService.GetUserDeletion:output_type -> explore.GetUserDeletionResponse
+	49, // 90: explore.ExploreService.ExportUserData:output_type -> explore.ExportUserDataResponse
+	51, // 91: explore.ExploreService.WatchLikes:output_type -> explore.WatchLikesResponse
+	69, // [69:92] is the sub-list for method output_type
+	46, // [46:69] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_explore_service_proto_init() }
@@ -458,18 +4657,50 @@ func file_explore_service_proto_init() {
 	}
 	file_explore_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_explore_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[14].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[17].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[19].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[26].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[27].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[31].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[32].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[43].OneofWrappers = []any{
+		(*ExportUserDataResponse_User_)(nil),
+		(*ExportUserDataResponse_LikeStats_)(nil),
+		(*ExportUserDataResponse_Moderation_)(nil),
+		(*ExportUserDataResponse_Decision_)(nil),
+		(*ExportUserDataResponse_LikeReceived)(nil),
+		(*ExportUserDataResponse_Match)(nil),
+		(*ExportUserDataResponse_Blocked)(nil),
+		(*ExportUserDataResponse_DecisionEvent)(nil),
+		(*ExportUserDataResponse_DecisionEventReceived)(nil),
+		(*ExportUserDataResponse_ReportFiled)(nil),
+		(*ExportUserDataResponse_ReportAbout)(nil),
+		(*ExportUserDataResponse_IdempotencyKey_)(nil),
+	}
+	file_explore_service_proto_msgTypes[44].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[45].OneofWrappers = []any{
+		(*WatchLikesResponse_LikeReceived_)(nil),
+		(*WatchLikesResponse_MatchCreated_)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_explore_service_proto_rawDesc), len(file_explore_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      6,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_explore_service_proto_goTypes,
 		DependencyIndexes: file_explore_service_proto_depIdxs,
+		EnumInfos:         file_explore_service_proto_enumTypes,
 		MessageInfos:      file_explore_service_proto_msgTypes,
 	}.Build()
 	File_explore_service_proto = out.File
@@ -0,0 +1,159 @@
+package service
+
+import "context"
+
+// BlockedUser is a user blocked by the listing user
+type BlockedUser struct {
+	ID            uint64
+	BlockedUserID string
+	UnixTimestamp uint64 // block time
+}
+
+type ListBlockedResult struct {
+	Blocked             []BlockedUser
+	NextPaginationToken string
+}
+
+const listBlockedEndpoint = "ListBlocked"
+
+// newUserBlockedError reports a decision between users where one blocked the other
+func newUserBlockedError(actorID, recipientID string) error {
+	return &DomainError{
+		Kind:     ErrPermissionDenied,
+		Reason:   ReasonUserBlocked,
+		Message:  "one of the users blocked the other",
+		Metadata: map[string]string{"actor_user_id": actorID, "recipient_user_id": recipientID},
+	}
+}
+
+// BlockUser blocks the other user, blocking again is a no-op. While the block lasts:
+//   - the blocked user's like no longer shows up in the blocker's ListLikedYou, ListNewLikedYou and CountLikedYou
+//   - neither user can record a decision on the other
+//
+// The blocker's like is turned into a pass, which dissolves their match if they had one
+func (b *ExploreBusiness) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	return b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Look for a block the other way, decisions of the pair committing concurrently are then waited for.
+		// Users being deleted can't be blocked nor block anyone
+		if err := checkNotDeleted(ctx, tx, blockerID, blockedID); err != nil {
+			return err
+		}
+		blockedBack, err := tx.IsBlocked(ctx, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Record the block, once
+		created, err := tx.CreateBlock(ctx, blockerID, blockedID)
+		if err != nil || !created {
+			return err
+		}
+
+		// 3. Take back the blocker's like, flagged as unmatched if it dissolves a match. When the blocked user
+		// blocked the blocker first, that like already left their like_stats and user_stats
+		stats := userStatsChanges{}
+		decision, err := tx.GetDecision(ctx, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		if decision == DecisionLiked {
+			matched, err := tx.DeleteMatch(ctx, blockerID, blockedID)
+			if err != nil {
+				return err
+			}
+			if matched {
+				stats.addMatch(blockerID, blockedID, -1)
+			}
+			counted, err := likesCounted(ctx, tx, blockerID)
+			if err != nil {
+				return err
+			}
+			if err := takeBackLike(ctx, tx, blockerID, blockedID, matched, counted && !blockedBack, stats); err != nil {
+				return err
+			}
+		}
+
+		// 4. like_stats and user_stats leave out blocked actors
+		if err := changeBlockedLikeCount(ctx, tx, stats, blockerID, blockedID, decision, -1); err != nil {
+			return err
+		}
+		return stats.apply(ctx, tx)
+	})
+}
+
+// changeBlockedLikeCount updates the blocker's like_stats by delta if the blocked user likes them, and their
+// new_like_count as well unless blockerDecision, the blocker's decision on the blocked user, answers that like
+func changeBlockedLikeCount(ctx context.Context, tx DecisionTx, stats userStatsChanges, blockerID, blockedID string,
+	blockerDecision DecisionState, delta int) error {
+	likedBlocker, err := tx.HasLiked(ctx, blockedID, blockerID)
+	if err != nil || !likedBlocker {
+		return err
+	}
+	counted, err := likesCounted(ctx, tx, blockedID)
+	if err != nil || !counted {
+		return err
+	}
+	if !answersLike(blockerDecision) {
+		stats.add(blockerID, UserStatsChange{NewLikes: delta})
+	}
+	return applyLikeCountChange(ctx, tx, blockerID, delta)
+}
+
+// UnblockUser removes the block, the blocked user's like shows up again for the blocker.
+// The blocker's like taken back by BlockUser is not restored
+func (b *ExploreBusiness) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	return b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Remove the block, it must exist
+		found, err := tx.DeleteBlock(ctx, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &DomainError{
+				Kind:     ErrNotFound,
+				Reason:   ReasonBlockNotFound,
+				Message:  "user is not blocked",
+				Metadata: map[string]string{"blocker_user_id": blockerID, "blocked_user_id": blockedID},
+			}
+		}
+
+		// 2. Count the blocked user's like again, it could not change during the block
+		decision, err := tx.GetDecision(ctx, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		stats := userStatsChanges{}
+		if err := changeBlockedLikeCount(ctx, tx, stats, blockerID, blockedID, decision, 1); err != nil {
+			return err
+		}
+		return stats.apply(ctx, tx)
+	})
+}
+
+// ListBlocked returns the users blocked by the blocker, ordered by block time
+func (b *ExploreBusiness) ListBlocked(ctx context.Context, blockerID string, pagination PaginationParams) (*ListBlockedResult, error) {
+	cursor, err := b.decodeCursor(pagination, listBlockedEndpoint, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := b.store.ListBlocked(ctx, blockerID, timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListBlockedResult{Blocked: blocked}
+	if len(blocked) == pagination.PageSize {
+		last := blocked[len(blocked)-1]
+		result.NextPaginationToken, err = b.tokens.Encode(listBlockedEndpoint, blockerID, pageCursor{
+			Timestamp:  last.UnixTimestamp,
+			ID:         last.ID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
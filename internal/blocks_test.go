@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockUser_HidesLikesFromTheBlocker(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, actor := range []string{"b", "c"} {
+		_, err := business.RecordDecision(ctx, actor, "a", true)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, business.BlockUser(ctx, "a", "b"))
+	require.NoError(t, business.BlockUser(ctx, "a", "b")) // blocking again changes nothing
+
+	likers, err := business.ListLikedYouUsers(ctx, "a", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectActorIDs(likers))
+	newLikers, err := business.ListNewLikedYouUsers(ctx, "a", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectActorIDs(newLikers))
+	count, err := business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// the block only hides b from a
+	count, err = business.CountLikedYouUsers(ctx, "b", TimeWindow{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// b's like shows up again once unblocked
+	require.NoError(t, business.UnblockUser(ctx, "a", "b"))
+	likers, err = business.ListLikedYouUsers(ctx, "a", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, collectActorIDs(likers))
+	count, err = business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func TestBlockUser_RejectsDecisionsBothWays(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	require.NoError(t, business.BlockUser(ctx, "a", "b"))
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = business.RecordDecision(ctx, "b", "a", false)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// in a batch only the decision on the blocked user fails
+	require.NoError(t, business.UnblockUser(ctx, "a", "b"))
+	require.NoError(t, business.BlockUser(ctx, "b", "a"))
+	results, err := business.RecordDecisions(ctx, "a", []DecisionInput{{RecipientID: "b", Liked: true}})
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, ErrPermissionDenied)
+}
+
+func TestBlockUser_DissolvesMatch(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	for _, pair := range [][2]string{{"a", "b"}, {"b", "a"}} {
+		_, err := business.RecordDecision(ctx, pair[0], pair[1], true)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, business.BlockUser(ctx, "a", "b"))
+
+	for _, user := range []string{"a", "b"} {
+		result, err := business.ListMatches(ctx, user, PaginationParams{PageSize: 10})
+		require.NoError(t, err)
+		assert.Empty(t, result.Matches)
+
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	}
+
+	// a's like was taken back like an unmatch
+	history, err := business.ListDecisionHistory(ctx, "a", "b", PaginationParams{PageSize: 10, Order: SortNewestFirst})
+	require.NoError(t, err)
+	require.NotEmpty(t, history.Events)
+	assert.False(t, history.Events[0].Liked)
+	assert.True(t, history.Events[0].Unmatched)
+
+	blocked, err := business.ListBlocked(ctx, "a", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, blocked.Blocked, 1)
+	assert.Equal(t, "b", blocked.Blocked[0].BlockedUserID)
+}
+
+func TestBlockUser_BlockedBothWays(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, pair := range [][2]string{{"a", "b"}, {"b", "a"}, {"c", "a"}, {"c", "b"}} {
+		_, err := business.RecordDecision(ctx, pair[0], pair[1], true)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, business.BlockUser(ctx, "a", "b"))
+	require.NoError(t, business.BlockUser(ctx, "b", "a"))
+	require.NoError(t, business.UnblockUser(ctx, "a", "b"))
+	require.NoError(t, business.UnblockUser(ctx, "b", "a"))
+
+	// both likes were taken back and subtracted once, c's likes are still counted
+	for _, user := range []string{"a", "b"} {
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+	}
+}
+
+func TestUnblockUser_NotBlocked(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_block`).
+		WithArgs("actor1", "actor2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := service.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		BlockerUserId: "actor1",
+		BlockedUserId: "actor2",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonBlockNotFound, errorInfoOf(t, err).Reason)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPutDecision_BlockedUser(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNotDeleted(mock, "actor1", "actor2")
+	mock.ExpectQuery(`SELECT\s+COUNT\(\*\)\s+FROM user_block\s+WHERE \(blocker_user_id = \? AND blocked_user_id = \?\)\s+OR \(blocker_user_id = \? AND blocked_user_id = \?\)\s+FOR SHARE`).
+		WithArgs("actor1", "actor2", "actor2", "actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     "actor1",
+		RecipientUserId: "actor2",
+		LikedRecipient:  true,
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, ReasonUserBlocked, errorInfoOf(t, err).Reason)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListBlocked_MySQLQuery(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM user_block b\s+WHERE b.blocker_user_id = \?\s+ORDER BY b.created_at ASC, b.id ASC`).
+		WithArgs("actor1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "blocked_user_id", "unix_timestamp"}).
+			AddRow(3, "actor2", 1700000000))
+
+	resp, err := service.ListBlocked(context.Background(), &pb.ListBlockedRequest{UserId: "actor1"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Blocked, 1)
+	assert.Equal(t, "actor2", resp.Blocked[0].BlockedUserId)
+	assert.Equal(t, uint64(1700000000), resp.Blocked[0].UnixTimestamp)
+	assert.Nil(t, resp.NextPaginationToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// DecisionInput is one decision of a batch
+type DecisionInput struct {
+	RecipientID string
+	Liked       bool
+}
+
+// DecisionResult is the outcome of one decision of a batch, Err is set if it was not recorded
+type DecisionResult struct {
+	RecipientID string
+	Mutual      bool
+	Err         error
+}
+
+// isDecisionError reports if err only concerns the decision that returned it, like an unknown recipient.
+// The stores raise these errors before the decision writes anything, so the rest of the batch can go on
+func isDecisionError(err error) bool {
+	return errors.Is(err, ErrInvalidArgument) || errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrFailedPrecondition) || errors.Is(err, ErrPermissionDenied)
+}
+
+// RecordDecisions records a batch of decisions of the actor in a single transaction, in order,
+// with the same rules as RecordDecision. A decision failing on its own, e.g. on an unknown recipient, is reported
+// in its result and skipped. Any other error rolls the whole batch back.
+//
+// A decision sees the ones before it in the batch, so several decisions on the same recipient count like
+// successive calls. The like_stats and user_stats changes are added up per user and written once at the end
+func (b *ExploreBusiness) RecordDecisions(ctx context.Context, actorID string, decisions []DecisionInput) ([]DecisionResult, error) {
+	var results []DecisionResult
+	liked := false
+
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		results = make([]DecisionResult, len(decisions))
+		likeCountDeltas := make(map[string]int)
+		stats := userStatsChanges{}
+		collectLikeCountChange := func(ctx context.Context, tx DecisionTx, recipientID string, delta int) error {
+			likeCountDeltas[recipientID] += delta
+			return nil
+		}
+
+		// 1. Record every decision, collecting the like_stats and user_stats changes
+		for i, decision := range decisions {
+			results[i].RecipientID = decision.RecipientID
+
+			isMutual, err := recordDecision(ctx, tx, actorID, decision.RecipientID, decision.Liked, collectLikeCountChange, stats)
+			if err != nil {
+				if !isDecisionError(err) {
+					return err
+				}
+				results[i].Err = err
+				continue
+			}
+			results[i].Mutual = isMutual
+			liked = liked || decision.Liked
+		}
+
+		// 2. Apply the net like_stats changes. Every recipient nets to -1, 0 or +1 since only the
+		// decision of the actor changes, sorted so concurrent batches lock like_stats rows in the same order
+		recipients := make([]string, 0, len(likeCountDeltas))
+		for recipientID, delta := range likeCountDeltas {
+			if delta != 0 {
+				recipients = append(recipients, recipientID)
+			}
+		}
+		sort.Strings(recipients)
+
+		for _, recipientID := range recipients {
+			if err := applyLikeCountChange(ctx, tx, recipientID, likeCountDeltas[recipientID]); err != nil {
+				return err
+			}
+		}
+		return stats.apply(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Let the WatchLikes streams of this instance know right away
+	if liked && b.watcher != nil {
+		b.watcher.Notify()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRecordDecisions_DuplicatesKeepCountersCorrect(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+	require.NoError(t, store.SetLikeCount("c", 4)) // c already liked by others
+
+	results, err := business.RecordDecisions(ctx, "a", []DecisionInput{
+		{RecipientID: "b", Liked: true},
+		{RecipientID: "b", Liked: true},  // same like twice
+		{RecipientID: "c", Liked: true},  // +1
+		{RecipientID: "c", Liked: false}, // taken back in the same batch
+		{RecipientID: "b", Liked: false},
+		{RecipientID: "b", Liked: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+
+	for user, expected := range map[string]uint64{"b": 1, "c": 4} {
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, count, "like count of %s", user)
+	}
+
+	// every decision is in the history
+	history, err := business.ListDecisionHistory(ctx, "a", "", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Len(t, history.Events, 6)
+}
+
+func TestRecordDecisions_FailedDecisionDoesNotStopTheBatch(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	_, err := business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+
+	results, err := business.RecordDecisions(ctx, "a", []DecisionInput{
+		{RecipientID: "ghost", Liked: true},
+		{RecipientID: "b", Liked: true},
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, results[0].Err, ErrNotFound)
+	assert.NoError(t, results[1].Err)
+	assert.True(t, results[1].Mutual)
+
+	matches, err := business.ListMatches(ctx, "a", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectMatchedUserIDs(matches))
+}
+
+func TestBatchPutDecision_PerDecisionErrors(t *testing.T) {
+	_, business := setupMemoryBusiness(t, "a", "b")
+	service := &ExploreService{
+		Business:  business,
+		Validator: NewRequestValidator(ValidationConfig{MaxPageSize: 100, MaxBatchSize: 10}),
+	}
+
+	resp, err := service.BatchPutDecision(context.Background(), &pb.BatchPutDecisionRequest{
+		ActorUserId: "a",
+		Decisions: []*pb.BatchPutDecisionRequest_Decision{
+			{RecipientUserId: "b", LikedRecipient: true},
+			{RecipientUserId: "ghost", LikedRecipient: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	assert.Equal(t, "b", resp.Results[0].RecipientUserId)
+	assert.Nil(t, resp.Results[0].Error)
+
+	assert.Equal(t, "ghost", resp.Results[1].RecipientUserId)
+	require.NotNil(t, resp.Results[1].Error)
+	assert.Equal(t, uint32(codes.NotFound), resp.Results[1].Error.Code)
+	assert.Equal(t, ReasonUserNotFound, resp.Results[1].Error.Reason)
+}
+
+func TestBatchPutDecision_MySQLAppliesNetLikeCountOnce(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+	business := NewExploreBusiness(NewMySQLStore(&DB{db}), nil)
+
+	expectRecorded := func(recipient string, previous DecisionState, liked bool) {
+		expectDecisionAllowed(mock, "actor1", recipient)
+		expectDecision(mock, "actor1", recipient, previous)
+		expectDecision(mock, recipient, "actor1", DecisionNone)
+		mock.ExpectExec(`INSERT INTO decision \(`).WithArgs("actor1", recipient, liked, false).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	mock.ExpectBegin()
+	// like then pass of actor2: nets to zero, no like_stats statement
+	expectRecorded("actor2", DecisionNone, true)
+	mock.ExpectExec(`INSERT INTO decision_event`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRecorded("actor2", DecisionLiked, false)
+	mock.ExpectExec(`DELETE FROM user_match`).WithArgs("actor1", "actor2", "actor2", "actor1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO decision_event`).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).WillReturnResult(sqlmock.NewResult(2, 1))
+	// unknown recipient, only this decision fails
+	expectDecisionAllowed(mock, "actor1", "ghost")
+	expectDecision(mock, "actor1", "ghost", DecisionNone)
+	expectDecision(mock, "ghost", "actor1", DecisionNone)
+	mock.ExpectExec(`INSERT INTO decision \(`).WithArgs("actor1", "ghost", true, false).
+		WillReturnError(&mysql.MySQLError{Number: mysqlErrNoReferencedRow, Message: "foreign key constraint fails"})
+	// like of actor3
+	expectRecorded("actor3", DecisionNone, true)
+	mock.ExpectExec(`INSERT INTO decision_event`).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).WillReturnResult(sqlmock.NewResult(3, 1))
+	// a single like_stats update, after every decision
+	mock.ExpectExec(`INSERT INTO like_stats`).WithArgs("actor3").WillReturnResult(sqlmock.NewResult(1, 1))
+	// then a single user_stats update per user, actor2 only keeps the pass
+	expectUserStatsChange(mock, "actor2", UserStatsChange{Passes: 1})
+	expectUserStatsChange(mock, "actor3", UserStatsChange{NewLikes: 1})
+	mock.ExpectCommit()
+
+	results, err := business.RecordDecisions(context.Background(), "actor1", []DecisionInput{
+		{RecipientID: "actor2", Liked: true},
+		{RecipientID: "actor2", Liked: false},
+		{RecipientID: "ghost", Liked: true},
+		{RecipientID: "actor3", Liked: true},
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[2].Err, ErrNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
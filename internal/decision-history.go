@@ -0,0 +1,53 @@
+package service
+
+import "context"
+
+// DecisionEvent is one entry of the append-only decision history
+type DecisionEvent struct {
+	ID            uint64
+	ActorID       string
+	RecipientID   string
+	Liked         bool
+	Unmatched     bool // the pass was recorded by Unmatch
+	Matched       bool // the like created a new match
+	UnixTimestamp uint64
+
+	ActorLikesHidden bool // the actor's likes are hidden by moderation, only set by ListDecisionEventsAfter and ListDecisionEventsByID
+}
+
+type DecisionHistoryResult struct {
+	Events              []DecisionEvent
+	NextPaginationToken string
+}
+
+const listDecisionHistoryEndpoint = "ListDecisionHistory"
+
+// ListDecisionHistory returns every decision recorded by the actor, or only those on
+// recipientID when it is not empty, including the ones overwritten since
+func (b *ExploreBusiness) ListDecisionHistory(ctx context.Context, actorID, recipientID string, pagination PaginationParams) (*DecisionHistoryResult, error) {
+	// tokens are bound to the actor and the optional recipient filter
+	subject := actorID + "/" + recipientID
+
+	cursor, err := b.decodeCursor(pagination, listDecisionHistoryEndpoint, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := b.store.ListDecisionHistory(ctx, actorID, recipientID, eventQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DecisionHistoryResult{Events: events}
+	if len(events) == pagination.PageSize {
+		result.NextPaginationToken, err = b.tokens.Encode(listDecisionHistoryEndpoint, subject, pageCursor{
+			ID:         events[len(events)-1].ID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDecisionHistory_KeepsOverwrittenDecisions(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, step := range []struct {
+		recipient string
+		liked     bool
+	}{
+		{"b", true},
+		{"c", true},
+		{"b", false},
+		{"b", true},
+	} {
+		_, err := business.RecordDecision(ctx, "a", step.recipient, step.liked)
+		require.NoError(t, err)
+	}
+
+	// the whole timeline of the pair, page by page
+	var timeline []bool
+	pagination := PaginationParams{PageSize: 2}
+	for {
+		result, err := business.ListDecisionHistory(ctx, "a", "b", pagination)
+		require.NoError(t, err)
+		for _, event := range result.Events {
+			assert.Equal(t, "b", event.RecipientID)
+			timeline = append(timeline, event.Liked)
+		}
+		if result.NextPaginationToken == "" {
+			break
+		}
+		pagination.Token = result.NextPaginationToken
+	}
+	assert.Equal(t, []bool{true, false, true}, timeline)
+
+	// every decision of the actor, newest first
+	result, err := business.ListDecisionHistory(ctx, "a", "", PaginationParams{PageSize: 10, Order: SortNewestFirst})
+	require.NoError(t, err)
+	require.Len(t, result.Events, 4)
+	assert.Equal(t, "b", result.Events[0].RecipientID)
+	assert.Equal(t, "c", result.Events[2].RecipientID)
+}
+
+func TestListDecisionHistory_TokenBoundToRecipientFilter(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, recipient := range []string{"b", "b"} {
+		_, err := business.RecordDecision(ctx, "a", recipient, true)
+		require.NoError(t, err)
+	}
+
+	result, err := business.ListDecisionHistory(ctx, "a", "b", PaginationParams{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.NextPaginationToken)
+
+	_, err = business.ListDecisionHistory(ctx, "a", "", PaginationParams{PageSize: 1, Token: result.NextPaginationToken})
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+}
+
+func TestListDecisionHistory_MySQLQuery(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	recipient := "actor2"
+	mock.ExpectQuery(`FROM decision_event\s+WHERE actor_user_id = \?\s+AND recipient_user_id = \?\s+AND id > \?\s+ORDER BY id ASC`).
+		WithArgs("actor1", "actor2", 0, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "recipient_user_id", "liked_recipient", "unmatched", "matched", "unix_timestamp"}).
+			AddRow(3, "actor1", "actor2", true, false, true, 1700000000).
+			AddRow(9, "actor1", "actor2", false, true, false, 1700001000))
+
+	resp, err := service.ListDecisionHistory(context.Background(), &pb.ListDecisionHistoryRequest{
+		ActorUserId:     "actor1",
+		RecipientUserId: &recipient,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.True(t, resp.Events[0].LikedRecipient)
+	assert.True(t, resp.Events[0].Matched)
+	assert.False(t, resp.Events[1].LikedRecipient)
+	assert.True(t, resp.Events[1].Unmatched)
+	assert.NotNil(t, resp.NextPaginationToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
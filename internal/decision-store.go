@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// LikeRecord is a like as returned by a DecisionStore. It carries the decision id
+// so the business layer can build the next pagination token.
+type LikeRecord struct {
+	DecisionID uint64
+	Liker
+}
+
+// TimeCursor is a keyset position in a list ordered by (timestamp, id), like likes by like time
+// or matches by match time. The zero value starts from the beginning of the list
+type TimeCursor struct {
+	UnixTimestamp uint64
+	ID            uint64
+}
+
+// TimeQuery selects a page of a list ordered by (timestamp, id)
+type TimeQuery struct {
+	After      TimeCursor // last position of the previous page, in the direction of the query
+	Descending bool       // newest first
+	Limit      int
+}
+
+// TimeWindow bounds the likes of a list or count by like time, Since included and Until excluded.
+// A zero bound leaves that side open
+type TimeWindow struct {
+	Since uint64
+	Until uint64
+}
+
+// contains reports if a like recorded at the unix timestamp is within the window
+func (w TimeWindow) contains(unixTimestamp uint64) bool {
+	return unixTimestamp >= w.Since && (w.Until == 0 || unixTimestamp < w.Until)
+}
+
+// EventQuery selects a page of an append-only log ordered by id
+type EventQuery struct {
+	AfterID    uint64 // last id of the previous page, 0 for the first page
+	Descending bool   // newest events first
+	Limit      int
+}
+
+// DecisionStore is the data access layer used by ExploreBusiness.
+// Implementations must keep the decision and like_stats data consistent,
+// the business rules on top of it live in explore-business.go
+type DecisionStore interface {
+	// ListLikedYou returns up to query.Limit likes received by the recipient within the window positioned after the cursor,
+	// ordered by like time and then decision id. Likes of actors blocked by the recipient or hidden by moderation are skipped
+	ListLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error)
+
+	// ListNewLikedYou is like ListLikedYou but skips actors the recipient already liked back or unmatched
+	ListNewLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error)
+
+	// ListLikesReceived is like ListLikedYou without a window, keeping every like including the ones of blocked and hidden actors
+	ListLikesReceived(ctx context.Context, recipientID string, query TimeQuery) ([]LikeRecord, error)
+
+	// GetDecisions returns the current decisions of the actor on the recipients, in no particular order.
+	// Recipients the actor never decided on are left out
+	GetDecisions(ctx context.Context, actorID string, recipientIDs []string) ([]DecisionStatus, error)
+
+	// ListMyDecisions returns up to query.Limit current decisions of the actor, likes or passes, positioned
+	// after the cursor, ordered by decision time and then decision id
+	ListMyDecisions(ctx context.Context, actorID string, liked bool, query TimeQuery) ([]DecisionStatus, error)
+
+	// GetUser returns the user row, a USER_NOT_FOUND error if the user does not exist
+	GetUser(ctx context.Context, userID string) (User, error)
+
+	// ListDecisionsMade returns up to limit current decisions of the actor on recipients after afterRecipientID,
+	// ordered by recipient id. An empty afterRecipientID starts from the first recipient
+	ListDecisionsMade(ctx context.Context, actorID, afterRecipientID string, limit int) ([]Decision, error)
+
+	// CountLikedYou returns the amount of likes received by the recipient within the window, which leaves out blocked and
+	// hidden actors, zero if the user was never liked and a USER_NOT_FOUND error if the user does not exist.
+	// Without bounds, or with only a since bound and no older like, the cached like_stats count is returned.
+	// Otherwise the likes of the window are counted, stopping at limit unless it is zero
+	CountLikedYou(ctx context.Context, recipientID string, window TimeWindow, limit uint64) (uint64, error)
+
+	// GetUserStats returns the like_stats and user_stats counters of the user, zeros for the missing rows
+	// and a USER_NOT_FOUND error if the user does not exist
+	GetUserStats(ctx context.Context, userID string) (UserStats, error)
+
+	// ListBlocked returns up to query.Limit users blocked by the blocker positioned after the cursor,
+	// ordered by block time and then block id
+	ListBlocked(ctx context.Context, blockerID string, query TimeQuery) ([]BlockedUser, error)
+
+	// ListMatches returns up to query.Limit matches of the user positioned after the cursor,
+	// ordered by match time and then match id
+	ListMatches(ctx context.Context, userID string, query TimeQuery) ([]Match, error)
+
+	// ListReports returns up to query.Limit reports in the status positioned after the cursor,
+	// ordered by report time and then report id, with their current relationship
+	ListReports(ctx context.Context, status ReportStatus, query TimeQuery) ([]Report, error)
+
+	// ListDecisionHistory returns decision events of the actor, only those on recipientID unless it is empty
+	ListDecisionHistory(ctx context.Context, actorID, recipientID string, query EventQuery) ([]DecisionEvent, error)
+
+	// ListDecisionEventsReceived returns decision events of other users on the recipient, passes included
+	ListDecisionEventsReceived(ctx context.Context, recipientID string, query EventQuery) ([]DecisionEvent, error)
+
+	// ListUserReports returns reports filed by the user, or about the user when filed is false, ordered by report id
+	// with their current relationship
+	ListUserReports(ctx context.Context, userID string, filed bool, query EventQuery) ([]Report, error)
+
+	// GetUserModeration returns the moderation state of the user without locking it, the zero value with UserID set
+	// if the user was never sanctioned
+	GetUserModeration(ctx context.Context, userID string) (UserModeration, error)
+
+	// ListIdempotencyRecords returns up to limit idempotency keys of the actor after afterKey ordered by key,
+	// expired ones included until they are purged. An empty afterKey starts from the first key
+	ListIdempotencyRecords(ctx context.Context, actorID, afterKey string, limit int) ([]IdempotencyRecord, error)
+
+	// GetUserDeletion returns the deletion of the user, found is false if it was never requested
+	GetUserDeletion(ctx context.Context, userID string) (deletion UserDeletion, found bool, err error)
+
+	// InTx runs fn inside a single transaction. The transaction is committed if fn
+	// returns nil and rolled back otherwise
+	InTx(ctx context.Context, fn func(tx DecisionTx) error) error
+}
+
+// DecisionEventFeed reads the decision history in id order across every user, LikeWatcher uses it
+// to fan out likes and matches recorded by any server instance
+type DecisionEventFeed interface {
+	// LastDecisionEventID returns the id of the newest decision event, 0 if there is none
+	LastDecisionEventID(ctx context.Context) (uint64, error)
+
+	// ListDecisionEventsAfter returns up to limit decision events of every user with an id greater than afterID, ordered by id,
+	// with the current likes_hidden sanction of their actor
+	ListDecisionEventsAfter(ctx context.Context, afterID uint64, limit int) ([]DecisionEvent, error)
+
+	// ListDecisionEventsByID returns the decision events with the given ids that exist, ordered by id,
+	// with the current likes_hidden sanction of their actor
+	ListDecisionEventsByID(ctx context.Context, ids []uint64) ([]DecisionEvent, error)
+
+	// ListLikeEvents returns up to limit decision events with an id in (afterID, untilID] the user is notified of:
+	// likes received from actors whose likes are not hidden and likes of the user that created a match, ordered by id
+	ListLikeEvents(ctx context.Context, userID string, afterID, untilID uint64, limit int) ([]DecisionEvent, error)
+}
+
+// DecisionTx groups the write operations that must run atomically when recording a decision
+type DecisionTx interface {
+	// GetDecision returns the current decision of actor over recipient, DecisionNone if there is none
+	GetDecision(ctx context.Context, actorID, recipientID string) (DecisionState, error)
+
+	// UpsertDecision inserts or overwrites the decision of actor over recipient.
+	// unmatched flags a pass recorded by Unmatch, it is cleared by any later decision
+	UpsertDecision(ctx context.Context, actorID, recipientID string, liked, unmatched bool) error
+
+	// AppendDecisionEvent adds the decision to the append-only decision history,
+	// the event ID and UnixTimestamp are assigned by the store
+	AppendDecisionEvent(ctx context.Context, event DecisionEvent) error
+
+	// AppendOutboxEvent adds a domain event to the outbox, the event ID and UnixTimestamp are assigned by the store
+	AppendOutboxEvent(ctx context.Context, event OutboxEvent) error
+
+	// IncrementLikeCount adds one like to the user like_stats, creating the row if needed
+	IncrementLikeCount(ctx context.Context, userID string) error
+
+	// DecrementLikeCount removes one like from the user like_stats, never going below zero
+	DecrementLikeCount(ctx context.Context, userID string) error
+
+	// ChangeUserStats adds the change to the user_stats counters of the user, creating the row if needed.
+	// Counters never go below zero
+	ChangeUserStats(ctx context.Context, userID string, change UserStatsChange) error
+
+	// HasLiked reports if actor currently likes recipient
+	HasLiked(ctx context.Context, actorID, recipientID string) (bool, error)
+
+	// CreateMatch records the match between both users, keeping the original match time if it already exists.
+	// created is false if they were already matched
+	CreateMatch(ctx context.Context, userID, otherUserID string) (created bool, err error)
+
+	// DeleteMatch removes the match between both users, found is false if they were not matched
+	DeleteMatch(ctx context.Context, userID, otherUserID string) (found bool, err error)
+
+	// IsBlocked reports if either user blocked the other. The check is a locking read, so a block
+	// committed concurrently can't be missed
+	IsBlocked(ctx context.Context, userID, otherUserID string) (bool, error)
+
+	// CreateBlock records that the blocker blocked the other user, created is false if it was already blocked
+	CreateBlock(ctx context.Context, blockerID, blockedID string) (created bool, err error)
+
+	// DeleteBlock removes the block, found is false if there was none
+	DeleteBlock(ctx context.Context, blockerID, blockedID string) (found bool, err error)
+
+	// GetUserModeration returns the moderation state of the user, the zero value with UserID set if the user
+	// was never sanctioned. Like IsBlocked it is a locking read
+	GetUserModeration(ctx context.Context, userID string) (UserModeration, error)
+
+	// SaveUserModeration inserts or overwrites the moderation state of the user
+	SaveUserModeration(ctx context.Context, moderation UserModeration) error
+
+	// UncountLikes removes the current likes of the actor from the like_stats of the liked users,
+	// except the ones who blocked the actor since those likes are not counted already.
+	// The likes are also taken out of the new_like_count of the liked users who did not like back nor unmatch the actor
+	UncountLikes(ctx context.Context, actorID string) error
+
+	// CreateReport queues a report and returns its id, the ID and UnixTimestamp are assigned by the store
+	CreateReport(ctx context.Context, report Report) (uint64, error)
+
+	// GetReport returns the report with its relationship, found is false if there is none.
+	// The report stays locked until the transaction ends
+	GetReport(ctx context.Context, reportID uint64) (report Report, found bool, err error)
+
+	// UpdateReport saves the status, moderator, outcome and resolution note of the report
+	UpdateReport(ctx context.Context, report Report) error
+
+	// IsUserDeleted reports if the deletion of the user was requested. Like IsBlocked it is a locking read
+	IsUserDeleted(ctx context.Context, userID string) (bool, error)
+
+	// CreateUserDeletion requests the deletion of the user and returns it, the existing deletion if there is one.
+	// The RequestedUnixTimestamp is assigned by the store, a USER_NOT_FOUND error is returned if the user does not exist
+	CreateUserDeletion(ctx context.Context, userID string) (UserDeletion, error)
+
+	// LockPendingUserDeletion returns the oldest deletion not completed yet, found is false if there is none.
+	// It stays locked until the transaction ends, deletions locked by other transactions are skipped
+	LockPendingUserDeletion(ctx context.Context) (deletion UserDeletion, found bool, err error)
+
+	// SaveUserDeletion saves the step and counters of the deletion, the CompletedUnixTimestamp is assigned
+	// by the store once the step is DeletionDone
+	SaveUserDeletion(ctx context.Context, deletion UserDeletion) error
+
+	// DeleteActorDecisions deletes up to limit decisions of the actor and returns them, ordered by recipient
+	DeleteActorDecisions(ctx context.Context, actorID string, limit int) ([]DecisionInput, error)
+
+	// DeleteUserMatches deletes both sides of up to limit matches of the user and returns the matched users,
+	// ordered by id
+	DeleteUserMatches(ctx context.Context, userID string, limit int) ([]string, error)
+
+	// DeleteUserRows deletes up to limit rows of the user removed by the deletion step and returns how many were deleted.
+	// Steps other than DeletionDecisionsMade, DeletionMatches and DeletionAccount are supported
+	DeleteUserRows(ctx context.Context, userID string, step DeletionStep, limit int) (int, error)
+
+	// DeleteUserAccount deletes the like_stats, user_stats, moderation and idempotency keys of the user, and the user itself.
+	// Every other row referencing the user must be gone
+	DeleteUserAccount(ctx context.Context, userID string) error
+
+	// GetIdempotencyRecord returns the outcome stored for the idempotency key of the actor, found is false
+	// if the key is unknown or expired. The key stays locked until the transaction ends
+	GetIdempotencyRecord(ctx context.Context, actorID, key string) (record IdempotencyRecord, found bool, err error)
+
+	// SaveIdempotencyRecord stores the outcome of an idempotency key for ttl, replacing an expired one
+	SaveIdempotencyRecord(ctx context.Context, record IdempotencyRecord, ttl time.Duration) error
+}
+
+// IdempotencyKeyStore removes the idempotency keys RecordIdempotentDecision no longer uses
+type IdempotencyKeyStore interface {
+	// DeleteExpiredIdempotencyKeys deletes up to limit expired keys and returns how many were deleted
+	DeleteExpiredIdempotencyKeys(ctx context.Context, limit int) (int, error)
+}
+
+// OutboxStore gives OutboxRelay access to the outbox_event table. Events are claimed in a short transaction
+// and published outside of it, so a slow sink holds no lock the decisions wait for
+type OutboxStore interface {
+	// ClaimOutboxEvents returns up to limit unpublished events ordered by id and leases them, other relays don't
+	// claim events before the lease elapses. Nothing is returned while the first unpublished events are leased
+	// by another relay, so the relays take turns and the events of every key are published in order
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+
+	// MarkOutboxEventsPublished flags the events so they are not relayed again
+	MarkOutboxEventsPublished(ctx context.Context, ids []uint64) error
+
+	// ReleaseOutboxEvents ends the lease of events that were not published, they can be claimed again right away
+	ReleaseOutboxEvents(ctx context.Context, ids []uint64) error
+
+	// DeletePublishedOutboxEvents deletes up to limit events published more than retention ago
+	// and returns how many were deleted
+	DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration, limit int) (int, error)
+}
+
+// LikeCount is the like_stats count of a user next to the count recomputed from the decision table
+type LikeCount struct {
+	UserID string
+	Cached uint64 // like_stats.like_count, 0 when the row is missing
+	Actual uint64 // likes received from actors not blocked by the user nor hidden by moderation
+}
+
+// Drifted reports if like_stats no longer matches the decisions
+func (c LikeCount) Drifted() bool {
+	return c.Cached != c.Actual
+}
+
+// LikeStatsStore recomputes the like_stats counters from the decision table, LikeStatsReconciler uses it
+type LikeStatsStore interface {
+	// CheckLikeCounts returns the like counts of up to limit users with an id greater than afterUserID, ordered by id.
+	// Users being deleted are skipped. It takes no lock, so a decision committed meanwhile can show up as drift
+	CheckLikeCounts(ctx context.Context, afterUserID string, limit int) ([]LikeCount, error)
+
+	// RepairLikeCount recomputes the like count of the user while holding the lock of its like_stats row,
+	// which every decision updating it waits for, and overwrites the row if it drifted. It returns the counts
+	// found under the lock
+	RepairLikeCount(ctx context.Context, userID string) (LikeCount, error)
+}
+
+// WebhookStore keeps the webhook deliveries of WebhookDispatcher. Deliveries that fail every attempt
+// stay in it as dead letters until they are requeued
+type WebhookStore interface {
+	// EnqueueWebhookDeliveries schedules the deliveries for now. A delivery of an event to an endpoint
+	// is only enqueued once, so an event relayed twice is not called twice
+	EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error
+
+	// ClaimWebhookDeliveries returns up to limit due deliveries ordered by due time. They are not due again
+	// before lease elapses, so concurrent dispatchers don't claim the same deliveries
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+
+	// CompleteWebhookDelivery removes a successful delivery
+	CompleteWebhookDelivery(ctx context.Context, id uint64) error
+
+	// FailWebhookDelivery counts a failed attempt, the delivery is due again after retryIn
+	// or becomes a dead letter if dead is true
+	FailWebhookDelivery(ctx context.Context, id uint64, lastError string, retryIn time.Duration, dead bool) error
+
+	// ListWebhookDeadLetters returns up to limit dead letters with an id greater than afterID, ordered by id
+	ListWebhookDeadLetters(ctx context.Context, afterID uint64, limit int) ([]WebhookDelivery, error)
+
+	// RequeueWebhookDeadLetters schedules dead letters again for now with a fresh attempt count,
+	// every dead letter when ids is empty. It returns how many were requeued
+	RequeueWebhookDeadLetters(ctx context.Context, ids []uint64) (int, error)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+)
+
+// DecisionStatus is the current decision of an actor on a recipient, as seen by the actor
+type DecisionStatus struct {
+	ID            uint64 // decision id, only set by ListMyDecisions
+	RecipientID   string
+	State         DecisionState // DecisionNone if the actor never decided on the recipient
+	UnixTimestamp uint64        // time of the latest decision, 0 if there is none
+	Reciprocated  bool          // the actor likes the recipient and is liked back
+}
+
+type ListMyDecisionsResult struct {
+	Decisions           []DecisionStatus
+	NextPaginationToken string
+}
+
+const listMyDecisionsEndpoint = "ListMyDecisions"
+
+// GetDecisions returns the current decision of the actor on every recipient, in the same order.
+// Recipients the actor never decided on, unknown users included, get DecisionNone
+func (b *ExploreBusiness) GetDecisions(ctx context.Context, actorID string, recipientIDs []string) ([]DecisionStatus, error) {
+	found, err := b.store.GetDecisions(ctx, actorID, recipientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byRecipient := make(map[string]DecisionStatus, len(found))
+	for _, status := range found {
+		byRecipient[status.RecipientID] = status
+	}
+
+	statuses := make([]DecisionStatus, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		status, ok := byRecipient[recipientID]
+		if !ok {
+			status = DecisionStatus{RecipientID: recipientID, State: DecisionNone}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ListMyDecisions returns the recipients the actor currently likes, or passed when liked is false,
+// ordered by decision time. Passes recorded by Unmatch and BlockUser are listed with the passes
+func (b *ExploreBusiness) ListMyDecisions(ctx context.Context, actorID string, liked bool, pagination PaginationParams) (*ListMyDecisionsResult, error) {
+	// tokens are bound to the actor and the decision type
+	subject := actorID + "/passes"
+	if liked {
+		subject = actorID + "/likes"
+	}
+
+	cursor, err := b.decodeCursor(pagination, listMyDecisionsEndpoint, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions, err := b.store.ListMyDecisions(ctx, actorID, liked, timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListMyDecisionsResult{Decisions: decisions}
+	if len(decisions) == pagination.PageSize {
+		last := decisions[len(decisions)-1]
+		result.NextPaginationToken, err = b.tokens.Encode(listMyDecisionsEndpoint, subject, pageCursor{
+			Timestamp:  last.UnixTimestamp,
+			ID:         last.ID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
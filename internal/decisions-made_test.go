@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDecisions_InRequestOrder(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c", "d", "e")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"a", "b", true}, {"b", "a", true}, // match
+		{"a", "c", true},
+		{"a", "d", false}, {"d", "a", true}, // a pass is not reciprocated
+		{"e", "a", true},
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+
+	statuses, err := business.GetDecisions(ctx, "a", []string{"e", "d", "c", "b", "ghost"})
+	require.NoError(t, err)
+
+	states := make(map[string]DecisionState)
+	var reciprocated []string
+	for _, status := range statuses {
+		states[status.RecipientID] = status.State
+		if status.Reciprocated {
+			reciprocated = append(reciprocated, status.RecipientID)
+		}
+	}
+	require.Len(t, statuses, 5)
+	assert.Equal(t, []string{"e", "d", "c", "b", "ghost"}, []string{
+		statuses[0].RecipientID, statuses[1].RecipientID, statuses[2].RecipientID, statuses[3].RecipientID, statuses[4].RecipientID,
+	})
+	assert.Equal(t, map[string]DecisionState{
+		"b": DecisionLiked, "c": DecisionLiked, "d": DecisionPassed, "e": DecisionNone, "ghost": DecisionNone,
+	}, states)
+	assert.Equal(t, []string{"b"}, reciprocated)
+	assert.Zero(t, statuses[0].UnixTimestamp)
+	assert.NotZero(t, statuses[1].UnixTimestamp)
+
+	// an unmatch is reported as such, and is no longer reciprocated
+	require.NoError(t, business.Unmatch(ctx, "a", "b"))
+	statuses, err = business.GetDecisions(ctx, "a", []string{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, DecisionUnmatched, statuses[0].State)
+	assert.False(t, statuses[0].Reciprocated)
+}
+
+func TestBatchGetDecision_MySQLSingleQueryOnTheUniqueKey(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+	service.Validator = NewRequestValidator(ValidationConfig{MaxPageSize: 100, MaxBatchSize: 10})
+
+	mock.ExpectQuery(`FROM decision d\s+WHERE d.actor_user_id = \?\s+AND d.recipient_user_id IN \(\?, \?, \?\)`).
+		WithArgs("actor1", "actor2", "actor3", "actor4").
+		WillReturnRows(sqlmock.NewRows([]string{"recipient_user_id", "liked_recipient", "unmatched", "created_at", "reciprocated"}).
+			AddRow("actor4", false, true, 1700000100, false).
+			AddRow("actor2", true, false, 1700000000, true))
+
+	resp, err := service.BatchGetDecision(context.Background(), &pb.BatchGetDecisionRequest{
+		ActorUserId:      "actor1",
+		RecipientUserIds: []string{"actor2", "actor3", "actor4"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Decisions, 3)
+	assert.Equal(t, &pb.GetDecisionResponse_Decision{
+		RecipientUserId: "actor2",
+		State:           pb.DecisionState_DECISION_STATE_LIKED,
+		UnixTimestamp:   1700000000,
+		Reciprocated:    true,
+	}, resp.Decisions[0])
+	assert.Equal(t, "actor3", resp.Decisions[1].RecipientUserId)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_NONE, resp.Decisions[1].State)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_UNMATCHED, resp.Decisions[2].State)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMyDecisions_LikesAndPassesPages(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c", "d", "e")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"a", "b", true}, {"b", "a", true}, // match
+		{"a", "c", true}, {"c", "a", false},
+		{"a", "d", true}, {"d", "a", true}, // match, unmatched below
+		{"a", "e", false}, {"e", "a", true},
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+	require.NoError(t, business.Unmatch(ctx, "a", "d"))
+
+	// likes, two per page
+	first, err := business.ListMyDecisions(ctx, "a", true, PaginationParams{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Decisions, 2)
+	assert.Equal(t, "b", first.Decisions[0].RecipientID)
+	assert.True(t, first.Decisions[0].Reciprocated)
+	assert.Equal(t, "c", first.Decisions[1].RecipientID)
+	assert.False(t, first.Decisions[1].Reciprocated)
+	require.NotEmpty(t, first.NextPaginationToken)
+
+	second, err := business.ListMyDecisions(ctx, "a", true, PaginationParams{PageSize: 2, Token: first.NextPaginationToken})
+	require.NoError(t, err)
+	assert.Empty(t, second.Decisions)
+	assert.Empty(t, second.NextPaginationToken)
+
+	// a likes token can't be used to list passes
+	_, err = business.ListMyDecisions(ctx, "a", false, PaginationParams{PageSize: 2, Token: first.NextPaginationToken})
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+
+	// passes, the unmatch is listed as a pass and a like back is not revealed
+	passes, err := business.ListMyDecisions(ctx, "a", false, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	states := make(map[string]DecisionState)
+	for _, status := range passes.Decisions {
+		states[status.RecipientID] = status.State
+		assert.False(t, status.Reciprocated, status.RecipientID)
+	}
+	assert.Equal(t, map[string]DecisionState{"d": DecisionUnmatched, "e": DecisionPassed}, states)
+}
+
+func TestListMyDecisions_MySQLQuery(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM decision d\s+WHERE\s+d.actor_user_id = \?\s+AND d.liked_recipient = \?\s+ORDER BY d.created_at DESC, d.id DESC`).
+		WithArgs("actor1", false, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient_user_id", "unmatched", "unix_timestamp", "reciprocated"}).
+			AddRow(7, "actor2", true, 1700000000, false))
+
+	resp, err := service.ListMyDecisions(context.Background(), &pb.ListMyDecisionsRequest{
+		ActorUserId:  "actor1",
+		DecisionType: pb.DecisionType_DECISION_TYPE_PASS,
+		SortOrder:    pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Decisions, 1)
+	assert.Equal(t, "actor2", resp.Decisions[0].RecipientUserId)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_UNMATCHED, resp.Decisions[0].State)
+	assert.Equal(t, uint64(1700000000), resp.Decisions[0].UnixTimestamp)
+	assert.Nil(t, resp.NextPaginationToken)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package service
+
+import "errors"
+
+// Error kinds. Every DomainError has one of them as Kind, so callers can match
+// a whole category with errors.Is(err, ErrNotFound) regardless of the specific reason
+var (
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrNotFound           = errors.New("not found")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrAborted            = errors.New("aborted")
+	ErrUnavailable        = errors.New("unavailable")
+	ErrInternal           = errors.New("internal error")
+)
+
+// Stable reasons attached to DomainError, clients branch on them through the gRPC ErrorInfo detail
+const (
+	ReasonInvalidRequest         = "INVALID_REQUEST"
+	ReasonInvalidPaginationToken = "INVALID_PAGINATION_TOKEN"
+	ReasonUserNotFound           = "USER_NOT_FOUND"
+	ReasonMatchNotFound          = "MATCH_NOT_FOUND"
+	ReasonBlockNotFound          = "BLOCK_NOT_FOUND"
+	ReasonUserBlocked            = "USER_BLOCKED"
+	ReasonUserBanned             = "USER_BANNED"
+	ReasonReportNotFound         = "REPORT_NOT_FOUND"
+	ReasonReportClaimed          = "REPORT_CLAIMED"
+	ReasonReportNotClaimed       = "REPORT_NOT_CLAIMED"
+	ReasonReportResolved         = "REPORT_RESOLVED"
+	ReasonDeletionNotFound       = "DELETION_NOT_FOUND"
+	ReasonIdempotencyKeyReused   = "IDEMPOTENCY_KEY_REUSED"
+	ReasonTransactionConflict    = "TRANSACTION_CONFLICT"
+	ReasonStorageUnavailable     = "STORAGE_UNAVAILABLE"
+	ReasonSubscriberLagging      = "SUBSCRIBER_LAGGING"
+	ReasonWatchUnavailable       = "WATCH_UNAVAILABLE"
+	ReasonInternal               = "INTERNAL"
+)
+
+// FieldViolation describes why a single request field is invalid
+type FieldViolation struct {
+	Field       string // proto field name
+	Description string
+}
+
+// DomainError is the error type returned by the business layer and the stores
+type DomainError struct {
+	Kind       error             // one of the error kinds above
+	Reason     string            // stable UPPER_SNAKE_CASE identifier
+	Message    string            // human readable description, safe to show to clients
+	Metadata   map[string]string // extra context, e.g. the offending user id
+	Violations []FieldViolation  // per field details of an invalid request
+	Err        error             // underlying cause, never exposed to clients
+}
+
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is match the error kind
+func (e *DomainError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// ErrInvalidPaginationToken is returned for tokens that are malformed, tampered with,
+// expired or issued for another endpoint, recipient or sort order
+var ErrInvalidPaginationToken = &DomainError{
+	Kind:    ErrInvalidArgument,
+	Reason:  ReasonInvalidPaginationToken,
+	Message: "invalid pagination token",
+}
+
+// newUserNotFoundError reports that a referenced user does not exist
+func newUserNotFoundError(message string, metadata map[string]string, cause error) error {
+	return &DomainError{
+		Kind:     ErrNotFound,
+		Reason:   ReasonUserNotFound,
+		Message:  message,
+		Metadata: metadata,
+		Err:      cause,
+	}
+}
@@ -2,15 +2,22 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
-	"strconv"
+	"time"
 )
 
 // Domain types - independent of gRPC/protobuf
+type SortOrder int
+
+const (
+	SortOldestFirst SortOrder = iota
+	SortNewestFirst
+)
+
 type PaginationParams struct {
 	PageSize int
-	Token    int
+	Token    string // opaque token issued by a previous page, empty for the first page
+	Order    SortOrder
 }
 
 type Liker struct {
@@ -23,131 +30,188 @@ type ListLikedYouResult struct {
 	NextPaginationToken string
 }
 
+// Endpoints a pagination token can be issued for
+const (
+	listLikedYouEndpoint    = "ListLikedYou"
+	listNewLikedYouEndpoint = "ListNewLikedYou"
+)
+
+// DefaultLikesCountCap is the most likes a windowed CountLikedYouUsers counts when nothing is configured
+const DefaultLikesCountCap = 1000
+
 type ExploreBusiness struct {
-	db *DB
+	store          DecisionStore
+	tokens         *TokenSigner
+	watcher        *LikeWatcher  // optional, see AttachLikeWatcher
+	idempotencyTTL time.Duration // see SetIdempotencyTTL
+	likesMaxAge    time.Duration // see SetLikesMaxAge
+	likesCountCap  uint64        // see SetLikesCountCap
+}
+
+// NewExploreBusiness creates a new business logic service on top of a DecisionStore
+func NewExploreBusiness(store DecisionStore, tokens *TokenSigner) *ExploreBusiness {
+	return &ExploreBusiness{
+		store:          store,
+		tokens:         tokens,
+		idempotencyTTL: DefaultIdempotencyTTL,
+		likesCountCap:  DefaultLikesCountCap,
+	}
+}
+
+// SetLikesMaxAge leaves the likes older than maxAge out of ListLikedYouUsers, ListNewLikedYouUsers and
+// CountLikedYouUsers, whatever window they are called with. Zero, the default, keeps every like
+func (b *ExploreBusiness) SetLikesMaxAge(maxAge time.Duration) {
+	b.likesMaxAge = maxAge
+}
+
+// SetLikesCountCap caps the CountLikedYouUsers calls that can't read the like_stats cache, which read one
+// index entry per like of their window. Their count stops at the cap, a count equal to it means at least
+// that many likes. Zero counts every like
+func (b *ExploreBusiness) SetLikesCountCap(maxCount uint64) {
+	b.likesCountCap = maxCount
 }
 
-// NewExploreBusiness creates a new business logic service
-func NewExploreBusiness(db *DB) *ExploreBusiness {
-	return &ExploreBusiness{db: db}
+// likesWindow narrows the window down to the likes max age, if one is set
+func (b *ExploreBusiness) likesWindow(window TimeWindow) TimeWindow {
+	if b.likesMaxAge <= 0 {
+		return window
+	}
+	if oldest := uint64(time.Now().Add(-b.likesMaxAge).Unix()); oldest > window.Since {
+		window.Since = oldest
+	}
+	return window
 }
 
-// parsePaginationParams extracts and validates pagination parameters
+// parsePaginationParams extracts pagination parameters, applying defaults
 // This is centralized to avoid duplication
-func parsePaginationParams(pageSize *uint32, token *string) (PaginationParams, error) {
-	const (
-		defaultPageSize        = 2
-		defaultPaginationToken = 0
-	)
+func parsePaginationParams(pageSize *uint32, token *string, order SortOrder) PaginationParams {
+	const defaultPageSize = 2
 
 	params := PaginationParams{
 		PageSize: defaultPageSize,
-		Token:    defaultPaginationToken,
+		Order:    order,
 	}
 
 	if pageSize != nil && *pageSize > 0 {
 		params.PageSize = int(*pageSize)
 	}
 
-	if token != nil && *token != "" {
-		parsedToken, err := strconv.Atoi(*token)
-		if err != nil {
-			return params, fmt.Errorf("invalid pagination token %s: %w", *token, err)
-		}
-		if parsedToken > 0 {
-			params.Token = parsedToken
-		}
+	if token != nil {
+		params.Token = *token
 	}
 
-	return params, nil
+	return params
 }
 
-// ListLikedYouUsers returns all users who liked the recipient
+// parseTimeWindow extracts the optional like time bounds of a request, missing bounds are open
+func parseTimeWindow(since, until *uint64) TimeWindow {
+	var window TimeWindow
+	if since != nil {
+		window.Since = *since
+	}
+	if until != nil {
+		window.Until = *until
+	}
+	return window
+}
+
+// ListLikedYouUsers returns all users who liked the recipient within the window
 // This is the business logic - it works with domain types, not protobuf
-func (b *ExploreBusiness) ListLikedYouUsers(ctx context.Context, recipientID string, pagination PaginationParams) (*ListLikedYouResult, error) {
-	const query = `
-		SELECT 
-			id,
-			actor_user_id,
-			UNIX_TIMESTAMP(created_at) 
-		FROM decision 
-		WHERE recipient_user_id = ?
-			AND liked_recipient = true
-			AND id > ?
-		ORDER BY id ASC
-		LIMIT ?;
-	`
-
-	result, err := b.db.QueryContext(ctx, query, recipientID, pagination.Token, pagination.PageSize)
+func (b *ExploreBusiness) ListLikedYouUsers(ctx context.Context, recipientID string, window TimeWindow, pagination PaginationParams) (*ListLikedYouResult, error) {
+	subject := likedYouSubject(recipientID, window)
+	cursor, err := b.decodeCursor(pagination, listLikedYouEndpoint, subject)
 	if err != nil {
-		return nil, fmt.Errorf("error querying liked users: %w", err)
+		return nil, err
 	}
-	defer result.Close()
 
-	var likers []Liker
-	var lastId uint64
-	for result.Next() {
-		var liker Liker
-		if err := result.Scan(&lastId, &liker.ActorID, &liker.UnixTimestamp); err != nil {
-			return nil, fmt.Errorf("error scanning liked user: %w", err)
-		}
-		likers = append(likers, liker)
+	records, err := b.store.ListLikedYou(ctx, recipientID, b.likesWindow(window), timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
 	}
 
-	var nextPaginationToken string
-	if len(likers) == pagination.PageSize {
-		nextPaginationToken = fmt.Sprintf("%d", lastId)
+	return b.buildListLikedYouResult(records, pagination, listLikedYouEndpoint, subject)
+}
+
+// ListNewLikedYouUsers returns users who liked the recipient within the window, excluding mutual likes
+func (b *ExploreBusiness) ListNewLikedYouUsers(ctx context.Context, recipientID string, window TimeWindow, pagination PaginationParams) (*ListLikedYouResult, error) {
+	subject := likedYouSubject(recipientID, window)
+	cursor, err := b.decodeCursor(pagination, listNewLikedYouEndpoint, subject)
+	if err != nil {
+		return nil, err
 	}
 
-	return &ListLikedYouResult{
-		Likers:              likers,
-		NextPaginationToken: nextPaginationToken,
-	}, nil
+	records, err := b.store.ListNewLikedYou(ctx, recipientID, b.likesWindow(window), timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildListLikedYouResult(records, pagination, listNewLikedYouEndpoint, subject)
 }
 
-// ListNewLikedYouUsers returns users who liked the recipient, excluding mutual likes
-func (b *ExploreBusiness) ListNewLikedYouUsers(ctx context.Context, recipientID string, pagination PaginationParams) (*ListLikedYouResult, error) {
-	const query = `
-		SELECT
-			d.id,
-			d.actor_user_id, 
-			UNIX_TIMESTAMP(d.created_at)
-		FROM decision d
-		WHERE 
-			d.recipient_user_id = ?
-			AND d.liked_recipient = TRUE
-			AND d.id > ?
-			AND NOT EXISTS (
-				SELECT 1 
-				FROM decision d2
-				WHERE 
-					d2.actor_user_id = ?
-					AND d2.recipient_user_id = d.actor_user_id
-					AND d2.liked_recipient = TRUE
-			)
-		ORDER BY d.id ASC
-		LIMIT ?;
-	`
-
-	result, err := b.db.QueryContext(ctx, query, recipientID, pagination.Token, recipientID, pagination.PageSize)
+// likedYouSubject binds the pagination tokens of the liked-you lists to the recipient and the window of the request,
+// so a token can't be used with other bounds. The LIKES_MAX_AGE bound moves over time and is left out
+func likedYouSubject(recipientID string, window TimeWindow) string {
+	if window == (TimeWindow{}) {
+		return recipientID
+	}
+	return fmt.Sprintf("%s/%d-%d", recipientID, window.Since, window.Until)
+}
+
+// decodeCursor verifies the pagination token against the endpoint, subject (usually the recipient)
+// and sort order it is used for. An empty token starts from the beginning
+func (b *ExploreBusiness) decodeCursor(pagination PaginationParams, endpoint, subject string) (pageCursor, error) {
+	if pagination.Token == "" {
+		return pageCursor{}, nil
+	}
+
+	cursor, err := b.tokens.Decode(pagination.Token, endpoint, subject)
 	if err != nil {
-		return nil, fmt.Errorf("error querying new liked users: %w", err)
+		return pageCursor{}, err
+	}
+	if cursor.Descending != (pagination.Order == SortNewestFirst) {
+		return pageCursor{}, fmt.Errorf("%w: issued for another sort order", ErrInvalidPaginationToken)
+	}
+	return cursor, nil
+}
+
+// timeQuery builds the store query for a page starting after cursor
+func timeQuery(cursor pageCursor, pagination PaginationParams) TimeQuery {
+	return TimeQuery{
+		After:      cursor.timeCursor(),
+		Descending: pagination.Order == SortNewestFirst,
+		Limit:      pagination.PageSize,
+	}
+}
+
+// eventQuery builds the store query for a page of an append-only log starting after cursor
+func eventQuery(cursor pageCursor, pagination PaginationParams) EventQuery {
+	return EventQuery{
+		AfterID:    cursor.ID,
+		Descending: pagination.Order == SortNewestFirst,
+		Limit:      pagination.PageSize,
 	}
-	defer result.Close()
+}
 
+// buildListLikedYouResult converts a page of store records into the domain result,
+// a next token issued for subject is only returned when the page is full
+func (b *ExploreBusiness) buildListLikedYouResult(records []LikeRecord, pagination PaginationParams, endpoint, subject string) (*ListLikedYouResult, error) {
 	var likers []Liker
-	var lastId uint64
-	for result.Next() {
-		var liker Liker
-		if err := result.Scan(&lastId, &liker.ActorID, &liker.UnixTimestamp); err != nil {
-			return nil, fmt.Errorf("error scanning new liked user: %w", err)
-		}
-		likers = append(likers, liker)
+	for _, record := range records {
+		likers = append(likers, record.Liker)
 	}
 
 	var nextPaginationToken string
-	if len(likers) == pagination.PageSize {
-		nextPaginationToken = fmt.Sprintf("%d", lastId)
+	if len(records) == pagination.PageSize {
+		last := records[len(records)-1]
+		token, err := b.tokens.Encode(endpoint, subject, pageCursor{
+			Timestamp:  last.UnixTimestamp,
+			ID:         last.DecisionID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nextPaginationToken = token
 	}
 
 	return &ListLikedYouResult{
@@ -156,129 +220,178 @@ func (b *ExploreBusiness) ListNewLikedYouUsers(ctx context.Context, recipientID
 	}, nil
 }
 
-// CountLikedYouUsers returns the count of users who liked the recipient
-func (b *ExploreBusiness) CountLikedYouUsers(ctx context.Context, recipientID string) (uint64, error) {
-	const query = `
-		SELECT 
-			like_count
-		FROM like_stats
-		WHERE user_id = ?;
-	`
-
-	var count uint64
-	err := b.db.QueryRowContext(ctx, query, recipientID).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("error getting likes count for id %s: %w", recipientID, err)
-	}
-
-	return count, nil
+// CountLikedYouUsers returns the count of users who liked the recipient within the window, see SetLikesCountCap
+func (b *ExploreBusiness) CountLikedYouUsers(ctx context.Context, recipientID string, window TimeWindow) (uint64, error) {
+	return b.store.CountLikedYou(ctx, recipientID, b.likesWindow(window), b.likesCountCap)
 }
 
 // RecordDecision records a user's decision (like/pass) and updates statistics
 // This method handles all the complex business logic including:
 // - Transaction management
 // - Determining if counters should increment/decrement
-// - Checking for mutual likes
+// - Checking for mutual likes and keeping the matches in sync
+// - Keeping the decision history and emitting domain events
 func (b *ExploreBusiness) RecordDecision(ctx context.Context, actorID, recipientID string, likedRecipient bool) (bool, error) {
-	// Start transaction for atomicity
-	tx, err := b.db.BeginTx(ctx, nil)
+	isMutual := false
+
+	// All steps run in a single transaction for atomicity
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		var err error
+		stats := userStatsChanges{}
+		isMutual, err = recordDecision(ctx, tx, actorID, recipientID, likedRecipient, applyLikeCountChange, stats)
+		if err != nil {
+			return err
+		}
+		return stats.apply(ctx, tx)
+	})
 	if err != nil {
-		return false, fmt.Errorf("error beginning transaction: %w", err)
+		return false, err
 	}
-	defer tx.Rollback()
 
-	// 1. Check if previous decision exists
-	var previousLike sql.NullBool
-	const decisionExistQuery = `
-		SELECT
-			liked_recipient
-		FROM decision
-		WHERE actor_user_id = ?
-			AND recipient_user_id = ?
-	`
-	err = tx.QueryRowContext(ctx, decisionExistQuery, actorID, recipientID).Scan(&previousLike)
-	if err != nil && err != sql.ErrNoRows {
-		return false, fmt.Errorf("error getting previous decision (%s -> %s): %w", actorID, recipientID, err)
+	// Let the WatchLikes streams of this instance know right away
+	if likedRecipient && b.watcher != nil {
+		b.watcher.Notify()
+	}
+
+	return isMutual, nil
+}
+
+// likeCountChange updates the like_stats of the recipient by delta, +1 or -1
+type likeCountChange func(ctx context.Context, tx DecisionTx, recipientID string, delta int) error
+
+// skipLikeCountChange leaves like_stats untouched, for likes that are not counted
+func skipLikeCountChange(ctx context.Context, tx DecisionTx, recipientID string, delta int) error {
+	return nil
+}
+
+// applyLikeCountChange updates like_stats right away
+func applyLikeCountChange(ctx context.Context, tx DecisionTx, recipientID string, delta int) error {
+	switch {
+	case delta > 0:
+		return tx.IncrementLikeCount(ctx, recipientID)
+	case delta < 0:
+		return tx.DecrementLikeCount(ctx, recipientID)
+	default:
+		return nil
+	}
+}
+
+// recordDecision runs the steps of recording a decision inside tx and reports if the like is mutual.
+// like_stats updates go through changeLikeCount, the user_stats changes of both users are added to stats
+// and left to the caller
+func recordDecision(ctx context.Context, tx DecisionTx, actorID, recipientID string, likedRecipient bool,
+	changeLikeCount likeCountChange, stats userStatsChanges) (bool, error) {
+	// 0. Users being deleted are gone, users who blocked one another can't decide on each other
+	if err := checkNotDeleted(ctx, tx, actorID, recipientID); err != nil {
+		return false, err
+	}
+	blocked, err := tx.IsBlocked(ctx, actorID, recipientID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, newUserBlockedError(actorID, recipientID)
+	}
+
+	// 0b. Banned actors can't decide, the likes of actors hidden by moderation are not counted
+	moderation, err := tx.GetUserModeration(ctx, actorID)
+	if err != nil {
+		return false, err
+	}
+	if moderation.Banned {
+		return false, newUserBannedError(actorID)
+	}
+	changeLikeCount = moderation.likeCountChange(changeLikeCount)
+
+	// 1. Check the previous decision, and the recipient's decision on the actor
+	previous, err := tx.GetDecision(ctx, actorID, recipientID)
+	if err != nil {
+		return false, err
+	}
+	found, previousLike := previous != DecisionNone, previous == DecisionLiked
+	back, err := tx.GetDecision(ctx, recipientID, actorID)
+	if err != nil {
+		return false, err
 	}
 
 	// 2. Determine if we should update like_stats
 	shouldIncrementLikeCounter := false
 	shouldDecrementLikeCounter := false
-	if !previousLike.Valid {
+	if !found {
 		// No previous decision exists
 		if likedRecipient {
 			shouldIncrementLikeCounter = true
 		}
 	} else {
 		// Previous decision exists
-		if !previousLike.Bool && likedRecipient {
+		if !previousLike && likedRecipient {
 			// Changed from pass to like: increment
 			shouldIncrementLikeCounter = true
-		} else if previousLike.Bool && !likedRecipient {
+		} else if previousLike && !likedRecipient {
 			// Changed from like to pass: decrement
 			shouldDecrementLikeCounter = true
 		}
 	}
 
 	// 3. Insert or update decision
-	const insertQuery = `
-		INSERT INTO decision (actor_user_id, recipient_user_id, liked_recipient)
-		VALUES (?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			liked_recipient = VALUES(liked_recipient),
-			created_at = CURRENT_TIMESTAMP;
-	`
-	if _, err := tx.ExecContext(ctx, insertQuery, actorID, recipientID, likedRecipient); err != nil {
-		return false, fmt.Errorf("error inserting decision (%s -> %s): %w", actorID, recipientID, err)
+	if err := tx.UpsertDecision(ctx, actorID, recipientID, likedRecipient, false); err != nil {
+		return false, err
 	}
 
 	// 4. Update like_stats if needed
 	if shouldIncrementLikeCounter {
-		const incQuery = `
-			INSERT INTO like_stats (user_id, like_count)
-			VALUES (?, 1)
-			ON DUPLICATE KEY UPDATE like_count = like_count + 1;
-		`
-		if _, err := tx.ExecContext(ctx, incQuery, recipientID); err != nil {
-			return false, fmt.Errorf("error incrementing like_count: %w", err)
+		if err := changeLikeCount(ctx, tx, recipientID, 1); err != nil {
+			return false, err
 		}
 	} else if shouldDecrementLikeCounter {
-		const decQuery = `
-			UPDATE like_stats
-			SET like_count = GREATEST(like_count - 1, 0)
-			WHERE user_id = ?;
-		`
-		if _, err := tx.ExecContext(ctx, decQuery, recipientID); err != nil {
-			return false, fmt.Errorf("error decrementing like_count: %w", err)
+		if err := changeLikeCount(ctx, tx, recipientID, -1); err != nil {
+			return false, err
 		}
 	}
 
-	// 5. Check for mutual likes (only if actor liked recipient)
-	isMutual := false
+	// 5. Update the new likes and passes of both users
+	current := DecisionPassed
 	if likedRecipient {
-		const mutualCheckQuery = `
-			SELECT EXISTS (
-				SELECT 1
-				FROM decision
-				WHERE actor_user_id = ?
-					AND recipient_user_id = ?
-					AND liked_recipient = TRUE
-			) AS recipient_liked_actor;
-		`
-
-		var exists bool
-		err := tx.QueryRowContext(ctx, mutualCheckQuery, recipientID, actorID).Scan(&exists)
+		current = DecisionLiked
+	}
+	if err := collectDecisionStats(ctx, tx, stats, actorID, recipientID, previous, current, back, !moderation.LikesHidden); err != nil {
+		return false, err
+	}
+
+	// 6. Persist the match when the like is mutual, or drop it once one of the likes is taken back
+	isMutual := likedRecipient && back == DecisionLiked
+	if isMutual {
+		created, err := tx.CreateMatch(ctx, actorID, recipientID)
 		if err != nil {
-			return false, fmt.Errorf("error checking mutual like between %s and %s: %w",
-				actorID, recipientID, err)
+			return false, err
+		}
+		if created {
+			stats.addMatch(actorID, recipientID, 1)
+		}
+	} else if shouldDecrementLikeCounter {
+		matched, err := tx.DeleteMatch(ctx, actorID, recipientID)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			stats.addMatch(actorID, recipientID, -1)
 		}
-		isMutual = exists
 	}
 
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return false, fmt.Errorf("commit failed: %w", err)
+	// 7. Append the decision to the history, flagging the likes that created a match
+	decision := DecisionEvent{
+		ActorID:     actorID,
+		RecipientID: recipientID,
+		Liked:       likedRecipient,
+		Matched:     isMutual && !(found && previousLike),
+	}
+	if err := tx.AppendDecisionEvent(ctx, decision); err != nil {
+		return false, err
 	}
 
+	// 8. Emit the domain events through the outbox, they are relayed once committed
+	if err := appendDecisionOutboxEvents(ctx, tx, decision); err != nil {
+		return false, err
+	}
 	return isMutual, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMemoryBusiness(t *testing.T, users ...string) (*MemoryStore, *ExploreBusiness) {
+	store := NewMemoryStore()
+	for _, user := range users {
+		store.AddUser(user, "user "+user)
+	}
+	return store, NewExploreBusiness(store, NewTokenSigner([]byte("test-secret"), time.Hour))
+}
+
+func collectActorIDs(result *ListLikedYouResult) []string {
+	var ids []string
+	for _, liker := range result.Likers {
+		ids = append(ids, liker.ActorID)
+	}
+	return ids
+}
+
+func TestRecordDecision_LikeCounterFollowsOverwrites(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	steps := []struct {
+		liked         bool
+		expectedCount uint64
+	}{
+		{liked: true, expectedCount: 1},  // first like
+		{liked: true, expectedCount: 1},  // same like twice
+		{liked: false, expectedCount: 0}, // like to pass
+		{liked: false, expectedCount: 0}, // same pass twice
+		{liked: true, expectedCount: 1},  // pass to like
+	}
+
+	for _, step := range steps {
+		_, err := business.RecordDecision(ctx, "a", "b", step.liked)
+		require.NoError(t, err)
+
+		count, err := business.CountLikedYouUsers(ctx, "b", TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, step.expectedCount, count)
+	}
+}
+
+func TestRecordDecision_MutualLike(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	isMutual, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	assert.False(t, isMutual)
+
+	isMutual, err = business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+	assert.True(t, isMutual)
+
+	// a pass never reports a mutual like
+	isMutual, err = business.RecordDecision(ctx, "a", "b", false)
+	require.NoError(t, err)
+	assert.False(t, isMutual)
+}
+
+func TestRecordDecision_Paths(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	// each step checks the mutual flag, the domain events written to the outbox, keyed by recipient,
+	// and the counters of both users
+
+	steps := []struct {
+		name             string
+		actor, recipient string
+		liked            bool
+		expectedMutual   bool
+		expectedEvents   []string
+		expectedStats    map[string]UserStats
+	}{
+		{
+			name: "like", actor: "a", recipient: "c", liked: true,
+			expectedEvents: []string{EventTypeLikeReceived},
+			expectedStats:  map[string]UserStats{"a": {}, "c": {LikeCount: 1, NewLikeCount: 1}},
+		},
+		{
+			name: "pass", actor: "a", recipient: "b", liked: false,
+			expectedEvents: []string{EventTypePassRecorded},
+			expectedStats:  map[string]UserStats{"a": {}, "b": {PassCount: 1}},
+		},
+		{
+			name: "like answering a like", actor: "c", recipient: "a", liked: true, expectedMutual: true,
+			expectedEvents: []string{EventTypeLikeReceived, EventTypeMatchCreated},
+			expectedStats: map[string]UserStats{
+				"a": {LikeCount: 1, MatchCount: 1},
+				"c": {LikeCount: 1, MatchCount: 1},
+			},
+		},
+		{
+			name: "like of a passed user", actor: "b", recipient: "a", liked: true,
+			expectedEvents: []string{EventTypeLikeReceived},
+			expectedStats: map[string]UserStats{
+				"a": {LikeCount: 2, NewLikeCount: 1, MatchCount: 1},
+				"b": {PassCount: 1},
+			},
+		},
+		{
+			name: "pass to like answering a like", actor: "a", recipient: "b", liked: true, expectedMutual: true,
+			expectedEvents: []string{EventTypeLikeReceived, EventTypeMatchCreated},
+			expectedStats: map[string]UserStats{
+				"a": {LikeCount: 2, MatchCount: 2},
+				"b": {LikeCount: 1, MatchCount: 1},
+			},
+		},
+		{
+			name: "like to pass dropping the match", actor: "a", recipient: "b", liked: false,
+			expectedEvents: []string{EventTypePassRecorded},
+			expectedStats: map[string]UserStats{
+				"a": {LikeCount: 2, NewLikeCount: 1, MatchCount: 1},
+				"b": {PassCount: 1},
+			},
+		},
+	}
+
+	broker := NewBroker()
+	relay := NewOutboxRelay(store, broker, DefaultOutboxRelayConfig())
+
+	for _, step := range steps {
+		isMutual, err := business.RecordDecision(ctx, step.actor, step.recipient, step.liked)
+		require.NoError(t, err, step.name)
+		assert.Equal(t, step.expectedMutual, isMutual, step.name)
+
+		published := len(broker.Events())
+		_, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		events := broker.Events()[published:]
+		assert.Equal(t, step.expectedEvents, eventTypesOf(events), step.name)
+		for _, event := range events {
+			assert.Equal(t, step.recipient, event.Key, step.name)
+		}
+
+		assertUserStats(t, business, step.expectedStats)
+	}
+}
+
+func TestRecordDecision_UnknownUserLeavesNoPartialWrites(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a")
+
+	_, err := business.RecordDecision(ctx, "a", "ghost", true)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	result, err := business.ListLikedYouUsers(ctx, "ghost", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, result.Likers)
+}
+
+func TestListLikedYouUsers_Pagination(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	for _, actor := range []string{"a", "b", "c"} {
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+
+	page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, collectActorIDs(page))
+	require.NotEmpty(t, page.NextPaginationToken)
+
+	pagination := PaginationParams{PageSize: 2, Token: page.NextPaginationToken}
+
+	page, err = business.ListLikedYouUsers(ctx, "r", TimeWindow{}, pagination)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectActorIDs(page))
+	assert.Empty(t, page.NextPaginationToken)
+}
+
+func TestListLikedYouUsers_TokenBoundToEndpointAndRecipient(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "r", "other", "a", "b")
+
+	for _, actor := range []string{"a", "b"} {
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+
+	page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPaginationToken)
+
+	reused := PaginationParams{PageSize: 1, Token: page.NextPaginationToken}
+
+	_, err = business.ListNewLikedYouUsers(ctx, "r", TimeWindow{}, reused)
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+
+	_, err = business.ListLikedYouUsers(ctx, "other", TimeWindow{}, reused)
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+}
+
+func TestListLikedYouUsers_OrderedByMostRecentLike(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	clock := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return clock }
+	decide := func(actor string, liked bool) {
+		clock = clock.Add(time.Minute)
+		_, err := business.RecordDecision(ctx, actor, "r", liked)
+		require.NoError(t, err)
+	}
+
+	decide("a", true)
+	decide("b", true)
+	decide("a", false)
+	decide("c", true)
+	decide("a", true) // re-like keeps the old decision id but moves to the end
+
+	var actorIDs []string
+	pagination := PaginationParams{PageSize: 1}
+	for {
+		page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, pagination)
+		require.NoError(t, err)
+		actorIDs = append(actorIDs, collectActorIDs(page)...)
+		if page.NextPaginationToken == "" {
+			break
+		}
+		pagination.Token = page.NextPaginationToken
+	}
+
+	assert.Equal(t, []string{"b", "c", "a"}, actorIDs)
+}
+
+func TestListLikedYouUsers_SameSecondLikesUseIdTieBreaker(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	clock := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return clock }
+	for _, actor := range []string{"c", "a", "b"} {
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+
+	page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a"}, collectActorIDs(page))
+
+	page, err = business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2, Token: page.NextPaginationToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(page))
+}
+
+func TestListLikedYouUsers_NewestFirst(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	clock := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return clock }
+	for _, actor := range []string{"a", "b", "c"} {
+		clock = clock.Add(time.Minute)
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+
+	page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2, Order: SortNewestFirst})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, collectActorIDs(page))
+
+	// a token can't switch the sort order between pages
+	_, err = business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2, Token: page.NextPaginationToken})
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+
+	page, err = business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 2, Token: page.NextPaginationToken, Order: SortNewestFirst})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, collectActorIDs(page))
+	assert.Empty(t, page.NextPaginationToken)
+}
+
+func TestListNewLikedYouUsers_SkipsMutualLikes(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "r", "a", "b")
+
+	for _, actor := range []string{"a", "b"} {
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+	_, err := business.RecordDecision(ctx, "r", "a", true)
+	require.NoError(t, err)
+
+	all, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, collectActorIDs(all))
+
+	newOnes, err := business.ListNewLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(newOnes))
+}
+
+func TestCountLikedYouUsers_NeverLikedVsUnknownUser(t *testing.T) {
+	_, business := setupMemoryBusiness(t, "a")
+
+	count, err := business.CountLikedYouUsers(context.Background(), "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	_, err = business.CountLikedYouUsers(context.Background(), "ghost", TimeWindow{})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLikedYouUsers_TimeWindow(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	start := time.Unix(1700000000, 0)
+	clock := start
+	store.now = func() time.Time { return clock }
+	for _, actor := range []string{"a", "b", "c"} {
+		clock = clock.Add(time.Minute)
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+
+	// since is included, until is excluded
+	window := TimeWindow{Since: uint64(start.Add(2 * time.Minute).Unix()), Until: uint64(start.Add(3 * time.Minute).Unix())}
+	page, err := business.ListLikedYouUsers(ctx, "r", window, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(page))
+
+	count, err := business.CountLikedYouUsers(ctx, "r", window)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	_, err = business.RecordDecision(ctx, "r", "b", true)
+	require.NoError(t, err)
+	page, err = business.ListNewLikedYouUsers(ctx, "r", TimeWindow{Since: window.Since}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectActorIDs(page))
+}
+
+func TestLikedYouUsers_LikesMaxAge(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b")
+
+	clock := time.Now().Add(-48 * time.Hour)
+	store.now = func() time.Time { return clock }
+	_, err := business.RecordDecision(ctx, "a", "r", true)
+	require.NoError(t, err)
+	clock = time.Now()
+	_, err = business.RecordDecision(ctx, "b", "r", true)
+	require.NoError(t, err)
+
+	count, err := business.CountLikedYouUsers(ctx, "r", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// the max age wins over an older since bound
+	business.SetLikesMaxAge(24 * time.Hour)
+	page, err := business.ListLikedYouUsers(ctx, "r", TimeWindow{Since: 1}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(page))
+
+	page, err = business.ListNewLikedYouUsers(ctx, "r", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(page))
+
+	count, err = business.CountLikedYouUsers(ctx, "r", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestCountLikedYouUsers_LikesCountCap(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+	for _, actor := range []string{"a", "b", "c"} {
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+	window := TimeWindow{Since: 1, Until: uint64(time.Now().Add(time.Hour).Unix())}
+
+	// windowed counts stop at the cap, the cached count is not capped
+	business.SetLikesCountCap(2)
+	count, err := business.CountLikedYouUsers(ctx, "r", window)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	count, err = business.CountLikedYouUsers(ctx, "r", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	business.SetLikesCountCap(0)
+	count, err = business.CountLikedYouUsers(ctx, "r", window)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+func TestLikedYouUsers_TokensAreBoundToTheWindow(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "r", "a", "b", "c")
+
+	clock := time.Now().Add(-time.Hour)
+	store.now = func() time.Time { return clock }
+	for _, actor := range []string{"a", "b", "c"} {
+		clock = clock.Add(time.Minute)
+		_, err := business.RecordDecision(ctx, actor, "r", true)
+		require.NoError(t, err)
+	}
+	window := TimeWindow{Since: uint64(time.Now().Add(-2 * time.Hour).Unix())}
+
+	first, err := business.ListLikedYouUsers(ctx, "r", window, PaginationParams{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextPaginationToken)
+
+	// another window, or none, can't continue the list
+	for _, other := range []TimeWindow{{}, {Since: window.Since + 1}, {Since: window.Since, Until: uint64(time.Now().Unix())}} {
+		_, err = business.ListLikedYouUsers(ctx, "r", other, PaginationParams{PageSize: 1, Token: first.NextPaginationToken})
+		assert.ErrorIs(t, err, ErrInvalidPaginationToken, other)
+	}
+
+	// the max age moves the effective window, but not the one the token was issued for
+	business.SetLikesMaxAge(24 * time.Hour)
+	second, err := business.ListLikedYouUsers(ctx, "r", window, PaginationParams{PageSize: 1, Token: first.NextPaginationToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, collectActorIDs(second))
+}
@@ -2,29 +2,41 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 type ExploreService struct {
 	pb.UnimplementedExploreServiceServer
-	Business *ExploreBusiness
+	Business  *ExploreBusiness
+	Validator *RequestValidator
 }
 
+// idempotencyKeyMetadata is the gRPC metadata key PutDecision also accepts the idempotency key in
+const idempotencyKeyMetadata = "idempotency-key"
+
 // This file is a gRPC handler layer. It delegates any logic to explore-business.go
+// and translates business errors into gRPC status codes with grpc-errors.go
 
 // ListLikedYou List all users who liked the recipient
 func (s *ExploreService) ListLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	// 1. Parse pagination from gRPC request
-	pagination, err := parsePaginationParams(req.PageSize, req.PaginationToken)
-	if err != nil {
-		return nil, err
+	// 0. Validate the request
+	if err := s.Validator.ValidateListLikedYouRequest(req); err != nil {
+		return nil, toStatusError(err)
 	}
 
+	// 1. Parse pagination and time window from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+	window := parseTimeWindow(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+
 	// 2. Call business logic
-	result, err := s.Business.ListLikedYouUsers(ctx, req.RecipientUserId, pagination)
+	result, err := s.Business.ListLikedYouUsers(ctx, req.RecipientUserId, window, pagination)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// 3. Convert domain types to protobuf response
@@ -33,16 +45,19 @@ func (s *ExploreService) ListLikedYou(ctx context.Context, req *pb.ListLikedYouR
 
 // ListNewLikedYou List all users who liked the recipient excluding those who have been liked in return
 func (s *ExploreService) ListNewLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
-	// 1. Parse pagination from gRPC request
-	pagination, err := parsePaginationParams(req.PageSize, req.PaginationToken)
-	if err != nil {
-		return nil, err
+	// 0. Validate the request
+	if err := s.Validator.ValidateListLikedYouRequest(req); err != nil {
+		return nil, toStatusError(err)
 	}
 
+	// 1. Parse pagination and time window from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+	window := parseTimeWindow(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+
 	// 2. Call business logic
-	result, err := s.Business.ListNewLikedYouUsers(ctx, req.RecipientUserId, pagination)
+	result, err := s.Business.ListNewLikedYouUsers(ctx, req.RecipientUserId, window, pagination)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	// 3. Convert to protobuf response
@@ -51,32 +66,693 @@ func (s *ExploreService) ListNewLikedYou(ctx context.Context, req *pb.ListLikedY
 
 // CountLikedYou Count the number of users who liked the recipient
 func (s *ExploreService) CountLikedYou(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
-	// 1. Call business logic
-	count, err := s.Business.CountLikedYouUsers(ctx, req.RecipientUserId)
+	// 0. Validate the request
+	if err := s.Validator.ValidateCountLikedYouRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse time window from gRPC request
+	window := parseTimeWindow(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+
+	// 2. Call business logic
+	count, err := s.Business.CountLikedYouUsers(ctx, req.RecipientUserId, window)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	// 2. Convert to protobuf response
+	// 3. Convert to protobuf response
 	return &pb.CountLikedYouResponse{
 		Count: count,
 	}, nil
 }
 
+// GetUserStats Get the like, new like, match and pass received counters of the user
+func (s *ExploreService) GetUserStats(ctx context.Context, req *pb.GetUserStatsRequest) (*pb.GetUserStatsResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateGetUserStatsRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	stats, err := s.Business.GetUserStats(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.GetUserStatsResponse{
+		LikeCount:    stats.LikeCount,
+		NewLikeCount: stats.NewLikeCount,
+		MatchCount:   stats.MatchCount,
+		PassCount:    stats.PassCount,
+	}, nil
+}
+
 // PutDecision Record the decision of the actor to like or pass the recipient
 func (s *ExploreService) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
-	// 1. Call business logic (handles all transaction and business rules)
-	isMutual, err := s.Business.RecordDecision(ctx, req.ActorUserId, req.RecipientUserId, req.LikedRecipient)
+	// 0. Validate the request
+	if err := s.Validator.ValidatePutDecisionRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Resolve the idempotency key, from the request or the metadata
+	var metadataValues []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		metadataValues = md.Get(idempotencyKeyMetadata)
+	}
+	key, err := s.Validator.ResolveIdempotencyKey(req, metadataValues)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	// 2. Convert to protobuf response
+	// 2. Call business logic (handles all transaction and business rules)
+	isMutual, err := s.Business.RecordIdempotentDecision(ctx, key, req.ActorUserId, req.RecipientUserId, req.LikedRecipient)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
 	return &pb.PutDecisionResponse{
 		MutualLikes: isMutual,
 	}, nil
 }
 
+// BatchPutDecision Record several decisions of the actor at once, each one with its own result
+func (s *ExploreService) BatchPutDecision(ctx context.Context, req *pb.BatchPutDecisionRequest) (*pb.BatchPutDecisionResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateBatchPutDecisionRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Convert the decisions to domain types
+	decisions := make([]DecisionInput, 0, len(req.Decisions))
+	for _, decision := range req.Decisions {
+		decisions = append(decisions, DecisionInput{RecipientID: decision.RecipientUserId, Liked: decision.LikedRecipient})
+	}
+
+	// 2. Call business logic
+	results, err := s.Business.RecordDecisions(ctx, req.ActorUserId, decisions)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	response := &pb.BatchPutDecisionResponse{
+		Results: make([]*pb.BatchPutDecisionResponse_Result, 0, len(results)),
+	}
+	for _, result := range results {
+		converted := &pb.BatchPutDecisionResponse_Result{
+			RecipientUserId: result.RecipientID,
+			MutualLikes:     result.Mutual,
+		}
+		if result.Err != nil {
+			domainErr, code, message := describeError(result.Err)
+			converted.Error = &pb.BatchPutDecisionResponse_Error{
+				Code:    uint32(code),
+				Reason:  domainErr.Reason,
+				Message: message,
+			}
+		}
+		response.Results = append(response.Results, converted)
+	}
+	return response, nil
+}
+
+// GetDecision Get the current decision of the actor on the recipient
+func (s *ExploreService) GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (*pb.GetDecisionResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateGetDecisionRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	statuses, err := s.Business.GetDecisions(ctx, req.ActorUserId, []string{req.RecipientUserId})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.GetDecisionResponse{
+		Decision: convertDecisionStatusToProtobuf(statuses[0]),
+	}, nil
+}
+
+// BatchGetDecision Get the current decisions of the actor on several recipients at once, e.g. a page of profiles
+func (s *ExploreService) BatchGetDecision(ctx context.Context, req *pb.BatchGetDecisionRequest) (*pb.BatchGetDecisionResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateBatchGetDecisionRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	statuses, err := s.Business.GetDecisions(ctx, req.ActorUserId, req.RecipientUserIds)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	response := &pb.BatchGetDecisionResponse{
+		Decisions: make([]*pb.GetDecisionResponse_Decision, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		response.Decisions = append(response.Decisions, convertDecisionStatusToProtobuf(status))
+	}
+	return response, nil
+}
+
+// ListMyDecisions List the users the actor currently likes, or passed, by decision time
+func (s *ExploreService) ListMyDecisions(ctx context.Context, req *pb.ListMyDecisionsRequest) (*pb.ListMyDecisionsResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateListMyDecisionsRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse pagination from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+
+	// 2. Call business logic
+	liked := req.DecisionType == pb.DecisionType_DECISION_TYPE_LIKE
+	result, err := s.Business.ListMyDecisions(ctx, req.ActorUserId, liked, pagination)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	decisions := make([]*pb.GetDecisionResponse_Decision, 0, len(result.Decisions))
+	for _, status := range result.Decisions {
+		decisions = append(decisions, convertDecisionStatusToProtobuf(status))
+	}
+
+	return &pb.ListMyDecisionsResponse{
+		Decisions:           decisions,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
+	}, nil
+}
+
+// convertDecisionStatusToProtobuf converts a decision status to its protobuf message
+func convertDecisionStatusToProtobuf(status DecisionStatus) *pb.GetDecisionResponse_Decision {
+	return &pb.GetDecisionResponse_Decision{
+		RecipientUserId: status.RecipientID,
+		State:           decisionStatesToProtobuf[status.State],
+		UnixTimestamp:   status.UnixTimestamp,
+		Reciprocated:    status.Reciprocated,
+	}
+}
+
+// convertSortOrderFromProtobuf maps the protobuf sort order, unspecified defaults to oldest first
+func convertSortOrderFromProtobuf(order pb.SortOrder) SortOrder {
+	if order == pb.SortOrder_SORT_ORDER_NEWEST_FIRST {
+		return SortNewestFirst
+	}
+	return SortOldestFirst
+}
+
+// ListDecisionHistory List every decision recorded by the actor, including overwritten ones
+func (s *ExploreService) ListDecisionHistory(ctx context.Context, req *pb.ListDecisionHistoryRequest) (*pb.ListDecisionHistoryResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateListDecisionHistoryRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse pagination from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+
+	// 2. Call business logic
+	result, err := s.Business.ListDecisionHistory(ctx, req.ActorUserId, req.GetRecipientUserId(), pagination)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	events := make([]*pb.ListDecisionHistoryResponse_DecisionEvent, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, convertDecisionEventToProtobuf(event))
+	}
+
+	return &pb.ListDecisionHistoryResponse{
+		Events:              events,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
+	}, nil
+}
+
+// ListMatches List all users who like the user and are liked back, ordered by match time
+func (s *ExploreService) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateListMatchesRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse pagination from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+
+	// 2. Call business logic
+	result, err := s.Business.ListMatches(ctx, req.UserId, pagination)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	matches := make([]*pb.ListMatchesResponse_Match, 0, len(result.Matches))
+	for _, match := range result.Matches {
+		matches = append(matches, &pb.ListMatchesResponse_Match{
+			MatchedUserId: match.MatchedUserID,
+			UnixTimestamp: match.UnixTimestamp,
+		})
+	}
+
+	return &pb.ListMatchesResponse{
+		Matches:             matches,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
+	}, nil
+}
+
+// Unmatch Dissolve the match between the actor and the other user, turning the actor's like into a pass
+func (s *ExploreService) Unmatch(ctx context.Context, req *pb.UnmatchRequest) (*pb.UnmatchResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateUnmatchRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	if err := s.Business.Unmatch(ctx, req.ActorUserId, req.OtherUserId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.UnmatchResponse{}, nil
+}
+
+// BlockUser Block a user, hiding their like from the blocker and rejecting decisions between them
+func (s *ExploreService) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateBlockUserRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	if err := s.Business.BlockUser(ctx, req.BlockerUserId, req.BlockedUserId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.BlockUserResponse{}, nil
+}
+
+// UnblockUser Remove a block, the blocked user's like shows up again
+func (s *ExploreService) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateUnblockUserRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	if err := s.Business.UnblockUser(ctx, req.BlockerUserId, req.BlockedUserId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.UnblockUserResponse{}, nil
+}
+
+// ListBlocked List all users blocked by the user, ordered by block time
+func (s *ExploreService) ListBlocked(ctx context.Context, req *pb.ListBlockedRequest) (*pb.ListBlockedResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateListBlockedRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse pagination from gRPC request
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+
+	// 2. Call business logic
+	result, err := s.Business.ListBlocked(ctx, req.UserId, pagination)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	blocked := make([]*pb.ListBlockedResponse_BlockedUser, 0, len(result.Blocked))
+	for _, user := range result.Blocked {
+		blocked = append(blocked, &pb.ListBlockedResponse_BlockedUser{
+			BlockedUserId: user.BlockedUserID,
+			UnixTimestamp: user.UnixTimestamp,
+		})
+	}
+
+	return &pb.ListBlockedResponse{
+		Blocked:             blocked,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
+	}, nil
+}
+
+// ReportUser Report a user to the moderators
+func (s *ExploreService) ReportUser(ctx context.Context, req *pb.ReportUserRequest) (*pb.ReportUserResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateReportUserRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	reportID, err := s.Business.ReportUser(ctx, req.ReporterUserId, req.ReportedUserId, reportReasonsFromProtobuf[req.Reason], req.Details)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.ReportUserResponse{ReportId: reportID}, nil
+}
+
+// ListReports List the reports in a status with the relationship of both users, ordered by report time
+func (s *ExploreService) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.ListReportsResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateListReportsRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Parse the status and pagination from gRPC request
+	status := ReportOpen
+	if req.Status != pb.ReportStatus_REPORT_STATUS_UNSPECIFIED {
+		status = reportStatusesFromProtobuf[req.Status]
+	}
+	pagination := parsePaginationParams(req.PageSize, req.PaginationToken, convertSortOrderFromProtobuf(req.SortOrder))
+
+	// 2. Call business logic
+	result, err := s.Business.ListReports(ctx, status, pagination)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 3. Convert to protobuf response
+	reports := make([]*pb.Report, 0, len(result.Reports))
+	for _, report := range result.Reports {
+		reports = append(reports, convertReportToProtobuf(report))
+	}
+
+	return &pb.ListReportsResponse{
+		Reports:             reports,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
+	}, nil
+}
+
+// ClaimReport Take an open report
+func (s *ExploreService) ClaimReport(ctx context.Context, req *pb.ClaimReportRequest) (*pb.ClaimReportResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateClaimReportRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	report, err := s.Business.ClaimReport(ctx, req.ReportId, req.ModeratorId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.ClaimReportResponse{Report: convertReportToProtobuf(*report)}, nil
+}
+
+// ResolveReport Close a claimed report, sanctioning the reported user
+func (s *ExploreService) ResolveReport(ctx context.Context, req *pb.ResolveReportRequest) (*pb.ResolveReportResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateResolveReportRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	report, err := s.Business.ResolveReport(ctx, req.ReportId, req.ModeratorId, reportOutcomesFromProtobuf[req.Outcome], req.Note)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.ResolveReportResponse{Report: convertReportToProtobuf(*report)}, nil
+}
+
+// DeleteUser Delete every decision, match, block and report of the user, and the user itself
+func (s *ExploreService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateDeleteUserRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic, the rows are deleted in the background
+	deletion, err := s.Business.DeleteUser(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.DeleteUserResponse{Deletion: convertUserDeletionToProtobuf(*deletion)}, nil
+}
+
+// GetUserDeletion Get the progress of a user deletion, its receipt once completed
+func (s *ExploreService) GetUserDeletion(ctx context.Context, req *pb.GetUserDeletionRequest) (*pb.GetUserDeletionResponse, error) {
+	// 0. Validate the request
+	if err := s.Validator.ValidateGetUserDeletionRequest(req); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 1. Call business logic
+	deletion, err := s.Business.GetUserDeletion(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// 2. Convert to protobuf response
+	return &pb.GetUserDeletionResponse{Deletion: convertUserDeletionToProtobuf(*deletion)}, nil
+}
+
+// ExportUserData Stream everything stored about the user, for data access requests
+func (s *ExploreService) ExportUserData(req *pb.ExportUserDataRequest, stream pb.ExploreService_ExportUserDataServer) error {
+	// 0. Validate the request
+	if err := s.Validator.ValidateExportUserDataRequest(req); err != nil {
+		return toStatusError(err)
+	}
+
+	// 1. Call business logic, converting every record to a protobuf message
+	err := s.Business.ExportUserData(stream.Context(), req.UserId, func(record UserDataRecord) error {
+		return stream.Send(convertUserDataRecordToProtobuf(record))
+	})
+	return toStatusError(err)
+}
+
+// WriteUserDataNDJSON writes the export of the user to w as newline-delimited JSON, one ExportUserDataResponse
+// per line with the protobuf field names, the bundle handed out for data access requests
+func WriteUserDataNDJSON(ctx context.Context, business *ExploreBusiness, userID string, w io.Writer) error {
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+	return business.ExportUserData(ctx, userID, func(record UserDataRecord) error {
+		line, err := marshal.Marshal(convertUserDataRecordToProtobuf(record))
+		if err != nil {
+			return fmt.Errorf("error encoding user data: %w", err)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("error writing user data: %w", err)
+		}
+		return nil
+	})
+}
+
+// convertUserDataRecordToProtobuf converts an ExportUserData record to its stream message
+func convertUserDataRecordToProtobuf(record UserDataRecord) *pb.ExportUserDataResponse {
+	response := &pb.ExportUserDataResponse{}
+	switch {
+	case record.User != nil:
+		response.Record = &pb.ExportUserDataResponse_User_{User: &pb.ExportUserDataResponse_User{
+			UserId:               record.User.ID,
+			Name:                 record.User.Name,
+			CreatedUnixTimestamp: record.User.CreatedUnixTimestamp,
+		}}
+	case record.Stats != nil:
+		response.Record = &pb.ExportUserDataResponse_LikeStats_{LikeStats: &pb.ExportUserDataResponse_LikeStats{
+			LikeCount:    record.Stats.LikeCount,
+			NewLikeCount: record.Stats.NewLikeCount,
+			MatchCount:   record.Stats.MatchCount,
+			PassCount:    record.Stats.PassCount,
+		}}
+	case record.Moderation != nil:
+		response.Record = &pb.ExportUserDataResponse_Moderation_{Moderation: &pb.ExportUserDataResponse_Moderation{
+			Warnings:    record.Moderation.Warnings,
+			LikesHidden: record.Moderation.LikesHidden,
+			Banned:      record.Moderation.Banned,
+		}}
+	case record.Decision != nil:
+		response.Record = &pb.ExportUserDataResponse_Decision_{Decision: &pb.ExportUserDataResponse_Decision{
+			RecipientUserId: record.Decision.RecipientID,
+			LikedRecipient:  record.Decision.Liked,
+			Unmatched:       record.Decision.Unmatched,
+			UnixTimestamp:   record.Decision.UnixTimestamp,
+		}}
+	case record.LikeReceived != nil:
+		response.Record = &pb.ExportUserDataResponse_LikeReceived{LikeReceived: &pb.ListLikedYouResponse_Liker{
+			ActorId:       record.LikeReceived.ActorID,
+			UnixTimestamp: record.LikeReceived.UnixTimestamp,
+		}}
+	case record.Match != nil:
+		response.Record = &pb.ExportUserDataResponse_Match{Match: &pb.ListMatchesResponse_Match{
+			MatchedUserId: record.Match.MatchedUserID,
+			UnixTimestamp: record.Match.UnixTimestamp,
+		}}
+	case record.Blocked != nil:
+		response.Record = &pb.ExportUserDataResponse_Blocked{Blocked: &pb.ListBlockedResponse_BlockedUser{
+			BlockedUserId: record.Blocked.BlockedUserID,
+			UnixTimestamp: record.Blocked.UnixTimestamp,
+		}}
+	case record.DecisionEvent != nil:
+		response.Record = &pb.ExportUserDataResponse_DecisionEvent{DecisionEvent: convertDecisionEventToProtobuf(*record.DecisionEvent)}
+	case record.EventReceived != nil:
+		response.Record = &pb.ExportUserDataResponse_DecisionEventReceived{
+			DecisionEventReceived: convertDecisionEventToProtobuf(*record.EventReceived),
+		}
+	case record.ReportFiled != nil:
+		report := convertReportToProtobuf(*record.ReportFiled)
+		report.Relationship = nil
+		response.Record = &pb.ExportUserDataResponse_ReportFiled{ReportFiled: report}
+	case record.ReportAbout != nil:
+		report := convertReportToProtobuf(*record.ReportAbout)
+		report.Relationship = nil
+		response.Record = &pb.ExportUserDataResponse_ReportAbout{ReportAbout: report}
+	case record.Idempotency != nil:
+		response.Record = &pb.ExportUserDataResponse_IdempotencyKey_{IdempotencyKey: &pb.ExportUserDataResponse_IdempotencyKey{
+			IdempotencyKey:  record.Idempotency.Key,
+			RecipientUserId: record.Idempotency.RecipientID,
+			LikedRecipient:  record.Idempotency.Liked,
+			MutualLikes:     record.Idempotency.MutualLikes,
+		}}
+	}
+	return response
+}
+
+func convertDecisionEventToProtobuf(event DecisionEvent) *pb.ListDecisionHistoryResponse_DecisionEvent {
+	return &pb.ListDecisionHistoryResponse_DecisionEvent{
+		ActorUserId:     event.ActorID,
+		RecipientUserId: event.RecipientID,
+		LikedRecipient:  event.Liked,
+		Unmatched:       event.Unmatched,
+		Matched:         event.Matched,
+		UnixTimestamp:   event.UnixTimestamp,
+	}
+}
+
+// WatchLikes Stream the likes received and matches created for the user as they are recorded
+func (s *ExploreService) WatchLikes(req *pb.WatchLikesRequest, stream pb.ExploreService_WatchLikesServer) error {
+	// 0. Validate the request
+	if err := s.Validator.ValidateWatchLikesRequest(req); err != nil {
+		return toStatusError(err)
+	}
+
+	// 1. Call business logic, converting every notification to a protobuf message
+	err := s.Business.WatchLikes(stream.Context(), req.UserId, req.GetResumeToken(), func(notification LikeNotification, resumeToken string) error {
+		return stream.Send(convertLikeNotificationToProtobuf(notification, resumeToken))
+	})
+	return toStatusError(err)
+}
+
+// convertLikeNotificationToProtobuf converts a WatchLikes notification to its stream message
+func convertLikeNotificationToProtobuf(notification LikeNotification, resumeToken string) *pb.WatchLikesResponse {
+	response := &pb.WatchLikesResponse{ResumeToken: resumeToken}
+	switch notification.Kind {
+	case NotificationMatchCreated:
+		response.Event = &pb.WatchLikesResponse_MatchCreated_{MatchCreated: &pb.WatchLikesResponse_MatchCreated{
+			MatchedUserId: notification.OtherUserID,
+			UnixTimestamp: notification.UnixTimestamp,
+		}}
+	default:
+		response.Event = &pb.WatchLikesResponse_LikeReceived_{LikeReceived: &pb.WatchLikesResponse_LikeReceived{
+			ActorUserId:   notification.OtherUserID,
+			UnixTimestamp: notification.UnixTimestamp,
+		}}
+	}
+	return response
+}
+
+// Report enums, the domain values are the enum names without their prefix
+var (
+	reportReasonsFromProtobuf = map[pb.ReportReason]ReportReason{
+		pb.ReportReason_REPORT_REASON_SPAM:                  ReportSpam,
+		pb.ReportReason_REPORT_REASON_HARASSMENT:            ReportHarassment,
+		pb.ReportReason_REPORT_REASON_INAPPROPRIATE_CONTENT: ReportInappropriateContent,
+		pb.ReportReason_REPORT_REASON_FAKE_PROFILE:          ReportFakeProfile,
+		pb.ReportReason_REPORT_REASON_UNDERAGE:              ReportUnderage,
+		pb.ReportReason_REPORT_REASON_OTHER:                 ReportOther,
+	}
+	reportStatusesFromProtobuf = map[pb.ReportStatus]ReportStatus{
+		pb.ReportStatus_REPORT_STATUS_OPEN:     ReportOpen,
+		pb.ReportStatus_REPORT_STATUS_CLAIMED:  ReportClaimed,
+		pb.ReportStatus_REPORT_STATUS_RESOLVED: ReportResolved,
+	}
+	reportOutcomesFromProtobuf = map[pb.ReportOutcome]ReportOutcome{
+		pb.ReportOutcome_REPORT_OUTCOME_DISMISSED:    OutcomeDismissed,
+		pb.ReportOutcome_REPORT_OUTCOME_WARNING:      OutcomeWarning,
+		pb.ReportOutcome_REPORT_OUTCOME_LIKES_HIDDEN: OutcomeLikesHidden,
+		pb.ReportOutcome_REPORT_OUTCOME_BANNED:       OutcomeBanned,
+	}
+	decisionStatesToProtobuf = map[DecisionState]pb.DecisionState{
+		DecisionNone:      pb.DecisionState_DECISION_STATE_NONE,
+		DecisionLiked:     pb.DecisionState_DECISION_STATE_LIKED,
+		DecisionPassed:    pb.DecisionState_DECISION_STATE_PASSED,
+		DecisionUnmatched: pb.DecisionState_DECISION_STATE_UNMATCHED,
+	}
+)
+
+// reverseMap returns the protobuf value of every domain value of a conversion map
+func reverseMap[P, D comparable](values map[P]D) map[D]P {
+	reversed := make(map[D]P, len(values))
+	for protobufValue, domainValue := range values {
+		reversed[domainValue] = protobufValue
+	}
+	return reversed
+}
+
+var (
+	reportReasonsToProtobuf  = reverseMap(reportReasonsFromProtobuf)
+	reportStatusesToProtobuf = reverseMap(reportStatusesFromProtobuf)
+	reportOutcomesToProtobuf = reverseMap(reportOutcomesFromProtobuf)
+)
+
+func convertUserDeletionToProtobuf(deletion UserDeletion) *pb.UserDeletion {
+	return &pb.UserDeletion{
+		UserId:                 deletion.UserID,
+		Completed:              deletion.Completed(),
+		DecisionsDeleted:       deletion.DecisionsDeleted,
+		LikeCountsRepaired:     deletion.LikeCountsRepaired,
+		DecisionEventsDeleted:  deletion.DecisionEventsDeleted,
+		MatchesDeleted:         deletion.MatchesDeleted,
+		BlocksDeleted:          deletion.BlocksDeleted,
+		ReportsDeleted:         deletion.ReportsDeleted,
+		RequestedUnixTimestamp: deletion.RequestedUnixTimestamp,
+		CompletedUnixTimestamp: deletion.CompletedUnixTimestamp,
+	}
+}
+
+func convertReportToProtobuf(report Report) *pb.Report {
+	return &pb.Report{
+		ReportId:       report.ID,
+		ReporterUserId: report.ReporterID,
+		ReportedUserId: report.ReportedID,
+		Reason:         reportReasonsToProtobuf[report.Reason],
+		Details:        report.Details,
+		Status:         reportStatusesToProtobuf[report.Status],
+		ModeratorId:    report.ModeratorID,
+		Outcome:        reportOutcomesToProtobuf[report.Outcome],
+		ResolutionNote: report.ResolutionNote,
+		UnixTimestamp:  report.UnixTimestamp,
+		Relationship: &pb.Report_Relationship{
+			ReporterDecision: decisionStatesToProtobuf[report.Relationship.ReporterDecision],
+			ReportedDecision: decisionStatesToProtobuf[report.Relationship.ReportedDecision],
+			Matched:          report.Relationship.Matched,
+			Blocked:          report.Relationship.Blocked,
+		},
+	}
+}
+
+// optionalString maps an empty string to an unset optional field
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 // Helper function to convert domain types to protobuf
 func convertListLikedYouResultToProtobuf(result *ListLikedYouResult) *pb.ListLikedYouResponse {
 	var likers []*pb.ListLikedYouResponse_Liker
@@ -87,13 +763,8 @@ func convertListLikedYouResultToProtobuf(result *ListLikedYouResult) *pb.ListLik
 		})
 	}
 
-	var nextToken *string
-	if result.NextPaginationToken != "" {
-		nextToken = &result.NextPaginationToken
-	}
-
 	return &pb.ListLikedYouResponse{
 		Likers:              likers,
-		NextPaginationToken: nextToken,
+		NextPaginationToken: optionalString(result.NextPaginationToken),
 	}
 }
@@ -3,19 +3,29 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *ExploreService, func()) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err, "failed to create sqlmock")
+	// readable ids are used in these tests, UUID checks are covered in validation_test.go
 	service := &ExploreService{
-		Business: NewExploreBusiness(&DB{db}),
+		Business:  NewExploreBusiness(NewMySQLStore(&DB{db}), NewTokenSigner([]byte("test-secret"), time.Hour)),
+		Validator: NewRequestValidator(ValidationConfig{MaxPageSize: 100}),
 	}
 
 	cleanup := func() {
@@ -25,11 +35,55 @@ func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *ExploreService, func(
 	return db, mock, service, cleanup
 }
 
+// expectNotDeleted expects the deletion checks of the users, finding no deletion
+func expectNotDeleted(mock sqlmock.Sqlmock, userIDs ...string) {
+	for _, userID := range userIDs {
+		mock.ExpectQuery(`SELECT\s+COUNT\(\*\)\s+FROM user_deletion\s+WHERE user_id = \?\s+FOR SHARE`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	}
+}
+
+// expectDecisionAllowed expects the checks starting every decision, finding no deletion, no block and an actor never sanctioned
+func expectDecisionAllowed(mock sqlmock.Sqlmock, actorID, recipientID string) {
+	expectNotDeleted(mock, actorID, recipientID)
+	mock.ExpectQuery(`SELECT\s+COUNT\(\*\)\s+FROM user_block`).
+		WithArgs(actorID, recipientID, recipientID, actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	expectNotSanctioned(mock, actorID)
+}
+
+// expectNotSanctioned expects the moderation read of a user who was never sanctioned
+func expectNotSanctioned(mock sqlmock.Sqlmock, userID string) {
+	mock.ExpectQuery(`FROM user_moderation\s+WHERE user_id = \?\s+FOR SHARE`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"warnings", "likes_hidden", "banned"}))
+}
+
+// expectDecision expects the read of the actor's decision on the recipient, finding none for DecisionNone
+func expectDecision(mock sqlmock.Sqlmock, actorID, recipientID string, state DecisionState) {
+	query := mock.ExpectQuery(`SELECT\s+liked_recipient,\s+unmatched\s+FROM decision`).
+		WithArgs(actorID, recipientID)
+	if state == DecisionNone {
+		query.WillReturnError(sql.ErrNoRows)
+		return
+	}
+	query.WillReturnRows(sqlmock.NewRows([]string{"liked_recipient", "unmatched"}).
+		AddRow(state == DecisionLiked, state == DecisionUnmatched))
+}
+
+// expectUserStatsChange expects the user_stats upsert adding the change to the counters of the user
+func expectUserStatsChange(mock sqlmock.Sqlmock, userID string, change UserStatsChange) {
+	mock.ExpectExec(`INSERT INTO user_stats`).
+		WithArgs(userID, change.NewLikes, change.Matches, change.Passes, change.NewLikes, change.Matches, change.Passes).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
 func TestCountLikedYou(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
-	mock.ExpectQuery(`SELECT like_count`).
+	mock.ExpectQuery(`SELECT\s+COALESCE\(ls\.like_count, 0\)\s+FROM user u\s+LEFT JOIN like_stats ls`).
 		WithArgs("user123").
 		WillReturnRows(sqlmock.NewRows([]string{"like_count"}).AddRow(3))
 
@@ -44,21 +98,220 @@ func TestCountLikedYou(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestListLikedYou(t *testing.T) {
+func TestCountLikedYou_TimeWindowCountsTheIndexRange(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
-	pagination, err := parsePaginationParams(nil, nil)
+	// the like_stats cache can't be windowed, the likes are counted on the user row up to the cap
+	mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM \(\s+SELECT 1\s+FROM decision d\s+WHERE d.recipient_user_id = \?\s+AND d.liked_recipient = TRUE\s+`+
+		`AND d.created_at >= FROM_UNIXTIME\(\?\)\s+AND d.created_at < FROM_UNIXTIME\(\?\).*LIMIT \?\s+\) likes\s+\)\s+FROM user u\s+WHERE u.id = \?`).
+		WithArgs("user123", uint64(1700000000), uint64(1700086400), uint64(DefaultLikesCountCap), "user123").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	since, until := uint64(1700000000), uint64(1700086400)
+	resp, err := service.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId:    "user123",
+		SinceUnixTimestamp: &since,
+		UntilUnixTimestamp: &until,
+	})
+
 	require.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.Count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountLikedYou_SinceOnlyReadsTheCacheWithoutOlderLikes(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	since := uint64(1700000000)
+	countSince := func(t *testing.T) uint64 {
+		resp, err := service.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId:    "user123",
+			SinceUnixTimestamp: &since,
+		})
+		require.NoError(t, err)
+		return resp.Count
+	}
+	cachedCount := `SELECT\s+COALESCE\(ls.like_count, 0\),\s+EXISTS \(\s+SELECT 1\s+FROM decision d\s+WHERE d.recipient_user_id = u.id\s+` +
+		`AND d.liked_recipient = TRUE\s+AND d.created_at < FROM_UNIXTIME\(\?\)\s+\)\s+FROM user u\s+LEFT JOIN like_stats ls`
+
+	// every like is recent, the cache is the count of the window
+	mock.ExpectQuery(cachedCount).
+		WithArgs(since, "user123").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count", "older"}).AddRow(4, false))
+	assert.Equal(t, uint64(4), countSince(t))
+
+	// older likes, the window is counted
+	mock.ExpectQuery(cachedCount).
+		WithArgs(since, "user123").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count", "older"}).AddRow(4, true))
+	mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM \(\s+SELECT 1\s+FROM decision d\s+WHERE d.recipient_user_id = \?\s+AND d.liked_recipient = TRUE\s+`+
+		`AND d.created_at >= FROM_UNIXTIME\(\?\)\s+AND NOT EXISTS`).
+		WithArgs("user123", since, uint64(DefaultLikesCountCap), "user123").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	assert.Equal(t, uint64(3), countSince(t))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// BenchmarkCountLikedYou_MySQLWindow measures the windowed count, which reads one index entry and two primary
+// key probes per like, on a recipient with 20000 likes, with and without the default cap. It needs a scratch
+// database with the db/01-init.sql schema in BENCH_MYSQL_DSN, e.g. root:rootsecret@tcp(127.0.0.1:3306)/myapp_db,
+// and is skipped without it
+func BenchmarkCountLikedYou_MySQLWindow(b *testing.B) {
+	dsn := os.Getenv("BENCH_MYSQL_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_MYSQL_DSN is not set")
+	}
+	ctx := context.Background()
+	db, err := NewDB(ctx, dsn)
+	require.NoError(b, err)
+	defer db.Close()
+
+	const likes, chunk = 20000, 1000
+	recipientID := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	_, err = db.ExecContext(ctx, `INSERT INTO user (id, name) VALUES (?, ?)`, recipientID, "bench")
+	require.NoError(b, err)
+	defer func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM decision WHERE recipient_user_id = ?`, recipientID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM user WHERE id LIKE ?`, recipientID+"%")
+	}()
+	for start := 0; start < likes; start += chunk {
+		var users, decisions []string
+		var userArgs, decisionArgs []any
+		for i := start; i < start+chunk; i++ {
+			actorID := fmt.Sprintf("%s-%d", recipientID, i)
+			users = append(users, "(?, ?)")
+			userArgs = append(userArgs, actorID, "bench")
+			decisions = append(decisions, "(?, ?, TRUE)")
+			decisionArgs = append(decisionArgs, actorID, recipientID)
+		}
+		_, err = db.ExecContext(ctx, `INSERT INTO user (id, name) VALUES `+strings.Join(users, ", "), userArgs...)
+		require.NoError(b, err)
+		_, err = db.ExecContext(ctx, `INSERT INTO decision (actor_user_id, recipient_user_id, liked_recipient) VALUES `+
+			strings.Join(decisions, ", "), decisionArgs...)
+		require.NoError(b, err)
+	}
+
+	store := NewMySQLStore(db)
+	window := TimeWindow{Since: 1, Until: uint64(time.Now().Add(time.Hour).Unix())}
+	for _, limit := range []uint64{0, DefaultLikesCountCap} {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := store.CountLikedYou(ctx, recipientID, window, limit); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestListLikedYou_TimeWindowNarrowsTheIndexRange(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`WHERE d.recipient_user_id = \?\s+AND d.liked_recipient = true\s+AND d.created_at >= FROM_UNIXTIME\(\?\)\s+AND NOT EXISTS`).
+		WithArgs("user123", uint64(1700000000), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "unix_timestamp"}).
+			AddRow(1, "actor1", 1700000100))
+
+	since := uint64(1700000000)
+	resp, err := service.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId:    "user123",
+		SinceUnixTimestamp: &since,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Likers, 1)
+	assert.Equal(t, "actor1", resp.Likers[0].ActorId)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// errorInfoOf returns the ErrorInfo detail of a gRPC status error
+func errorInfoOf(t *testing.T, err error) *errdetails.ErrorInfo {
+	st, ok := status.FromError(err)
+	require.True(t, ok, "expected a gRPC status error, got %v", err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	require.Fail(t, "status has no ErrorInfo detail")
+	return nil
+}
+
+func TestCountLikedYou_NeverLiked(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// the user exists but has no like_stats row, COALESCE turns it into 0
+	mock.ExpectQuery(`SELECT\s+COALESCE\(ls\.like_count, 0\)`).
+		WithArgs("user123").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count"}).AddRow(0))
+
+	resp, err := service.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId: "user123",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), resp.Count)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountLikedYou_UnknownUser(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT\s+COALESCE\(ls\.like_count, 0\)`).
+		WithArgs("ghost").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count"}))
+
+	_, err := service.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId: "ghost",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonUserNotFound, errorInfoOf(t, err).Reason)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountLikedYou_DatabaseUnavailable(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT\s+COALESCE\(ls\.like_count, 0\)`).
+		WithArgs("user123").
+		WillReturnError(mysql.ErrInvalidConn)
+
+	_, err := service.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId: "user123",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, ReasonStorageUnavailable, errorInfoOf(t, err).Reason)
+	assert.NotContains(t, status.Convert(err).Message(), "invalid connection")
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListLikedYou(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	pagination := parsePaginationParams(nil, nil, SortOldestFirst)
 
 	sqlRowsQueryResult := sqlmock.NewRows([]string{"id", "actor_user_id", "unix_timestamp"}).
 		AddRow(1, "uuid-user-A", 1700000000).
 		AddRow(2, "uuid-user-B", 1700001000)
 
-	mock.ExpectQuery(`SELECT\s+id,\s+actor_user_id,\s+UNIX_TIMESTAMP\(created_at\)`).
+	mock.ExpectQuery(`SELECT\s+d\.id,\s+d\.actor_user_id,\s+UNIX_TIMESTAMP\(d\.created_at\)\s+FROM decision d\s+WHERE d\.recipient_user_id = \?\s+AND d\.liked_recipient = true\s+AND NOT EXISTS \(\s+SELECT 1\s+FROM user_block b\s+WHERE\s+b\.blocker_user_id = d\.recipient_user_id\s+AND b\.blocked_user_id = d\.actor_user_id\s+\)\s+AND NOT EXISTS \(\s+SELECT 1\s+FROM user_moderation um\s+WHERE\s+um\.user_id = d\.actor_user_id\s+AND um\.likes_hidden = TRUE\s+\)\s+ORDER BY d\.created_at ASC`).
 		WithArgs(
 			"uuid-recipient",
-			pagination.Token,
 			pagination.PageSize,
 		).
 		WillReturnRows(sqlRowsQueryResult)
@@ -81,8 +334,7 @@ func TestListNewLikedYou(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
-	pagination, err := parsePaginationParams(nil, nil)
-	require.NoError(t, err)
+	pagination := parsePaginationParams(nil, nil, SortOldestFirst)
 
 	rows := sqlmock.NewRows([]string{"id", "actor_user_id", "unix_timestamp"}).
 		AddRow(3, "uuid-user-X", 1700002000).
@@ -91,7 +343,6 @@ func TestListNewLikedYou(t *testing.T) {
 	mock.ExpectQuery(`SELECT\s+d\.id,\s+d\.actor_user_id,\s+UNIX_TIMESTAMP\(d\.created_at\)`).
 		WithArgs(
 			"uuid-recipient-2",
-			pagination.Token,
 			"uuid-recipient-2",
 			pagination.PageSize,
 		).
@@ -111,194 +362,205 @@ func TestListNewLikedYou(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPutDecision_MutualLike_WithoutPrevious(t *testing.T) {
+func TestListLikedYou_NewestFirst(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
-	mock.ExpectBegin()
+	pageSize := uint32(1)
+	mock.ExpectQuery(`ORDER BY d\.created_at DESC, d\.id DESC`).
+		WithArgs("uuid-recipient", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "unix_timestamp"}).
+			AddRow(7, "uuid-user-A", 1700005000))
 
-	// Step 1: Check previous decision (no previous record)
-	mock.ExpectQuery(`SELECT liked_recipient FROM decision`).
-		WithArgs("actor1", "actor2").
-		WillReturnError(sql.ErrNoRows)
+	resp, err := service.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: "uuid-recipient",
+		PageSize:        &pageSize,
+		SortOrder:       pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.NextPaginationToken)
 
-	// Step 2: Insert new decision
-	mock.ExpectExec(`INSERT INTO decision`).
-		WithArgs("actor1", "actor2", true).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	// the next page seeks to older likes than the cursor
+	mock.ExpectQuery(`d\.created_at < FROM_UNIXTIME\(\?\)\s+OR \(d\.created_at = FROM_UNIXTIME\(\?\) AND d\.id < \?\)`).
+		WithArgs("uuid-recipient", 1700005000, 1700005000, 7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "unix_timestamp"}))
 
-	// Step 3: Increment like_count (first like for recipient)
-	mock.ExpectExec(`INSERT INTO like_stats`).
-		WithArgs("actor2").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	resp, err = service.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: "uuid-recipient",
+		PageSize:        &pageSize,
+		PaginationToken: resp.NextPaginationToken,
+		SortOrder:       pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Likers)
+	assert.Nil(t, resp.NextPaginationToken)
 
-	// Step 4: Check mutual like
-	mock.ExpectQuery(`SELECT EXISTS`).
-		WithArgs("actor2", "actor1").
-		WillReturnRows(sqlmock.NewRows([]string{"recipient_liked_actor"}).AddRow(true))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
 
-	mock.ExpectCommit()
+func TestListLikedYou_InvalidPaginationToken(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
 
-	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
-		ActorUserId:     "actor1",
-		RecipientUserId: "actor2",
-		LikedRecipient:  true,
+	forged := "42"
+	_, err := service.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: "uuid-recipient",
+		PaginationToken: &forged,
 	})
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	assert.True(t, resp.MutualLikes)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonInvalidPaginationToken, errorInfoOf(t, err).Reason)
 
+	// the token is rejected before reaching the database
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPutDecision_NoMutualLike_WithoutPrevious(t *testing.T) {
+func TestPutDecision_MutualLike_MySQLStatements(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	// the statements of a like creating a match, TestRecordDecision_Paths checks the outcome of every path
 	mock.ExpectBegin()
-
-	// Step 1: Check previous decision (no previous record)
-	mock.ExpectQuery(`SELECT liked_recipient FROM decision`).
-		WithArgs("actor1", "actor3").
-		WillReturnError(sql.ErrNoRows)
-
-	// Step 2: Insert new decision
+	expectDecisionAllowed(mock, "actor1", "actor2")
+	expectDecision(mock, "actor1", "actor2", DecisionNone)
+	expectDecision(mock, "actor2", "actor1", DecisionLiked)
 	mock.ExpectExec(`INSERT INTO decision`).
-		WithArgs("actor1", "actor3", true).
+		WithArgs("actor1", "actor2", true, false).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	// Step 3: Increment like_count (first like for recipient)
 	mock.ExpectExec(`INSERT INTO like_stats`).
-		WithArgs("actor3").
+		WithArgs("actor2").
 		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	// Step 4: Check with no mutual like
-	mock.ExpectQuery(`SELECT EXISTS`).
-		WithArgs("actor3", "actor1").
-		WillReturnRows(sqlmock.NewRows([]string{"recipient_liked_actor"}).AddRow(false))
-
+	expectNotSanctioned(mock, "actor2")
+	mock.ExpectExec(`INSERT INTO user_match`).
+		WithArgs("actor1", "actor2", "actor2", "actor1").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(`INSERT INTO decision_event`).
+		WithArgs("actor1", "actor2", true, false, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).
+		WithArgs("LikeReceived", "actor2", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).
+		WithArgs("MatchCreated", "actor2", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectUserStatsChange(mock, "actor1", UserStatsChange{NewLikes: -1, Matches: 1})
+	expectUserStatsChange(mock, "actor2", UserStatsChange{Matches: 1})
 	mock.ExpectCommit()
 
 	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
 		ActorUserId:     "actor1",
-		RecipientUserId: "actor3",
+		RecipientUserId: "actor2",
 		LikedRecipient:  true,
 	})
 
 	require.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.False(t, resp.MutualLikes)
+	assert.True(t, resp.MutualLikes)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPutDecision_Pass_WithoutPrevious(t *testing.T) {
+func TestPutDecision_LikeToPass_MySQLStatements(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
+	// the statements of a like taken back, dropping the match
 	mock.ExpectBegin()
-
-	// Step 1: Check previous decision (no previous record)
-	mock.ExpectQuery(`SELECT liked_recipient FROM decision`).
-		WithArgs("actor4", "actor5").
-		WillReturnError(sql.ErrNoRows)
-
-	// Step 2: Insert new decision
+	expectDecisionAllowed(mock, "actor1", "actor2")
+	expectDecision(mock, "actor1", "actor2", DecisionLiked)
+	expectDecision(mock, "actor2", "actor1", DecisionLiked)
 	mock.ExpectExec(`INSERT INTO decision`).
-		WithArgs("actor4", "actor5", false).
+		WithArgs("actor1", "actor2", false, false).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	// Since it's a "pass" (liked_recipient = false), no like_stats update and no mutual check
+	mock.ExpectExec(`UPDATE like_stats`).
+		WithArgs("actor2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectNotSanctioned(mock, "actor2")
+	mock.ExpectExec(`DELETE FROM user_match`).
+		WithArgs("actor1", "actor2", "actor2", "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO decision_event`).
+		WithArgs("actor1", "actor2", false, false, false).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox_event`).
+		WithArgs("PassRecorded", "actor2", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectUserStatsChange(mock, "actor1", UserStatsChange{NewLikes: 1, Matches: -1})
+	expectUserStatsChange(mock, "actor2", UserStatsChange{Matches: -1, Passes: 1})
 	mock.ExpectCommit()
 
 	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
-		ActorUserId:     "actor4",
-		RecipientUserId: "actor5",
+		ActorUserId:     "actor1",
+		RecipientUserId: "actor2",
 		LikedRecipient:  false,
 	})
 
 	require.NoError(t, err)
-	assert.NotNil(t, resp)
 	assert.False(t, resp.MutualLikes)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
-
-func TestPutDecision_WithPreviousDecision_PassToLike(t *testing.T) {
+func TestPutDecision_UnknownUser(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	mock.ExpectBegin()
 
-	// Step 1: Check previous decision and find it
-	mock.ExpectQuery(`SELECT liked_recipient FROM decision`).
-		WithArgs("actor1", "actor2").
-		WillReturnRows(sqlmock.NewRows([]string{"liked_recipient"}).AddRow(false))
+	expectDecisionAllowed(mock, "actor1", "ghost")
+	expectDecision(mock, "actor1", "ghost", DecisionNone)
+	expectDecision(mock, "ghost", "actor1", DecisionNone)
 
-	// Step 2: Insert new decision
+	// the foreign key on recipient_user_id fails
 	mock.ExpectExec(`INSERT INTO decision`).
-		WithArgs("actor1", "actor2", true).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	// Step 3: Increment like_count (first like for recipient)
-	mock.ExpectExec(`INSERT INTO like_stats`).
-		WithArgs("actor2").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WithArgs("actor1", "ghost", true, false).
+		WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
 
-	// Step 4: Check mutual like
-	mock.ExpectQuery(`SELECT EXISTS`).
-		WithArgs("actor2", "actor1").
-		WillReturnRows(sqlmock.NewRows([]string{"recipient_liked_actor"}).AddRow(true))
-
-	mock.ExpectCommit()
+	mock.ExpectRollback()
 
-	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+	_, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
 		ActorUserId:     "actor1",
-		RecipientUserId: "actor2",
+		RecipientUserId: "ghost",
 		LikedRecipient:  true,
 	})
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	assert.True(t, resp.MutualLikes)
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	info := errorInfoOf(t, err)
+	assert.Equal(t, ReasonUserNotFound, info.Reason)
+	assert.Equal(t, "ghost", info.Metadata["recipient_user_id"])
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPutDecision_WithPreviousDecision_LikeToPass(t *testing.T) {
+func TestPutDecision_Deadlock(t *testing.T) {
 	_, mock, service, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	mock.ExpectBegin()
 
-	// Step 1: Check previous decision and find it
-	mock.ExpectQuery(`SELECT liked_recipient FROM decision`).
+	expectDecisionAllowed(mock, "actor1", "actor2")
+	mock.ExpectQuery(`SELECT\s+liked_recipient,\s+unmatched\s+FROM decision`).
 		WithArgs("actor1", "actor2").
-		WillReturnRows(sqlmock.NewRows([]string{"liked_recipient"}).AddRow(true))
+		WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
 
-	// Step 2: Insert new decision (pass)
-	mock.ExpectExec(`INSERT INTO decision`).
-		WithArgs("actor1", "actor2", false).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	// Step 3: Decrement recipient like_count
-	mock.ExpectExec(`UPDATE like_stats`).
-		WithArgs("actor2").
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
 
-	// No mutual like check
-	mock.ExpectCommit()
-
-	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+	_, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
 		ActorUserId:     "actor1",
 		RecipientUserId: "actor2",
-		LikedRecipient:  false,
+		LikedRecipient:  true,
 	})
 
-	require.NoError(t, err)
-	require.NotNil(t, resp)
-	assert.False(t, resp.MutualLikes)
+	require.Error(t, err)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	assert.NotNil(t, retryInfo)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
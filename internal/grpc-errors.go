@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the ErrorInfo domain of every error returned by this service
+const errorDomain = "explore-service"
+
+// retryDelay is suggested to clients through RetryInfo on retryable errors
+const retryDelay = time.Second
+
+// codeForKind maps each error kind to its canonical gRPC status code
+func codeForKind(kind error) codes.Code {
+	switch kind {
+	case ErrInvalidArgument:
+		return codes.InvalidArgument
+	case ErrNotFound:
+		return codes.NotFound
+	case ErrFailedPrecondition:
+		return codes.FailedPrecondition
+	case ErrPermissionDenied:
+		return codes.PermissionDenied
+	case ErrAborted:
+		return codes.Aborted
+	case ErrUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// describeError returns the DomainError behind err, its status code and the message clients may see:
+// the full error for client errors, only the DomainError message for server errors
+func describeError(err error) (*DomainError, codes.Code, string) {
+	var domainErr *DomainError
+	if !errors.As(err, &domainErr) {
+		domainErr = &DomainError{Kind: ErrInternal, Reason: ReasonInternal, Message: "internal error", Err: err}
+	}
+
+	code := codeForKind(domainErr.Kind)
+	switch code {
+	case codes.Internal, codes.Unavailable, codes.Aborted:
+		return domainErr, code, domainErr.Message
+	default:
+		return domainErr, code, err.Error()
+	}
+}
+
+// toStatusError translates business errors into gRPC status errors.
+// Client errors keep their message, server errors are logged and only expose the DomainError message.
+// Every status carries an ErrorInfo detail with the reason, invalid requests a BadRequest
+// with the field violations and retryable ones a RetryInfo
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	domainErr, code, message := describeError(err)
+	if code == codes.Internal || code == codes.Unavailable || code == codes.Aborted {
+		log.Printf("request failed with %s: %v", code, err)
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   domainErr.Reason,
+			Domain:   errorDomain,
+			Metadata: domainErr.Metadata,
+		},
+	}
+	if len(domainErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if code == codes.Unavailable || code == codes.Aborted {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	}
+
+	st, detailsErr := status.New(code, message).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long the outcome of an idempotency key is kept when nothing is configured
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// purgeBatchSize is the amount of expired idempotency keys, or published outbox events, deleted per statement
+const purgeBatchSize = 1000
+
+// IdempotencyRecord is the stored outcome of a PutDecision sent with an idempotency key
+type IdempotencyRecord struct {
+	ActorID     string
+	Key         string // chosen by the client, unique per actor
+	RecipientID string // decision the key was first used for
+	Liked       bool
+	MutualLikes bool // original response
+}
+
+// SetIdempotencyTTL sets how long RecordIdempotentDecision keeps the outcome of a key
+func (b *ExploreBusiness) SetIdempotencyTTL(ttl time.Duration) {
+	b.idempotencyTTL = ttl
+}
+
+// RecordIdempotentDecision is RecordDecision deduplicated by an idempotency key of the actor. The first call records
+// the decision and stores its outcome in the same transaction, retries until the key expires return that outcome
+// without recording the decision again. Reusing a live key for another decision fails with IDEMPOTENCY_KEY_REUSED.
+// An empty key records the decision without deduplication
+func (b *ExploreBusiness) RecordIdempotentDecision(ctx context.Context, key, actorID, recipientID string, likedRecipient bool) (bool, error) {
+	if key == "" {
+		return b.RecordDecision(ctx, actorID, recipientID, likedRecipient)
+	}
+
+	isMutual, replayed := false, false
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Look the key up, it stays locked so concurrent retries wait for this transaction
+		record, found, err := tx.GetIdempotencyRecord(ctx, actorID, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			if record.RecipientID != recipientID || record.Liked != likedRecipient {
+				return &DomainError{
+					Kind:     ErrFailedPrecondition,
+					Reason:   ReasonIdempotencyKeyReused,
+					Message:  "idempotency key already used for another decision",
+					Metadata: map[string]string{"idempotency_key": key},
+				}
+			}
+			isMutual, replayed = record.MutualLikes, true
+			return nil
+		}
+
+		// 2. Record the decision
+		stats := userStatsChanges{}
+		isMutual, err = recordDecision(ctx, tx, actorID, recipientID, likedRecipient, applyLikeCountChange, stats)
+		if err != nil {
+			return err
+		}
+		if err := stats.apply(ctx, tx); err != nil {
+			return err
+		}
+
+		// 3. Store its outcome for the retries
+		return tx.SaveIdempotencyRecord(ctx, IdempotencyRecord{
+			ActorID:     actorID,
+			Key:         key,
+			RecipientID: recipientID,
+			Liked:       likedRecipient,
+			MutualLikes: isMutual,
+		}, b.idempotencyTTL)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Let the WatchLikes streams of this instance know right away
+	if likedRecipient && !replayed && b.watcher != nil {
+		b.watcher.Notify()
+	}
+
+	return isMutual, nil
+}
+
+// PurgeExpiredIdempotencyKeys deletes the expired idempotency keys every interval until ctx is done.
+// Expired keys are already ignored, this only keeps the table small
+func PurgeExpiredIdempotencyKeys(ctx context.Context, store IdempotencyKeyStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			deleted, err := store.DeleteExpiredIdempotencyKeys(ctx, purgeBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("error purging expired idempotency keys: %v", err)
+				}
+				break
+			}
+			if deleted < purgeBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecordIdempotentDecision_RetryReturnsOriginalResponse(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	isMutual, err := business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", true)
+	require.NoError(t, err)
+	assert.False(t, isMutual)
+
+	// b likes back, a's retry still gets the original response and records nothing
+	_, err = business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+
+	isMutual, err = business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", true)
+	require.NoError(t, err)
+	assert.False(t, isMutual)
+
+	history, err := business.ListDecisionHistory(ctx, "a", "", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Len(t, history.Events, 1)
+
+	count, err := business.CountLikedYouUsers(ctx, "b", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestRecordIdempotentDecision_KeyReusedForAnotherDecision(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	_, err := business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", true)
+	require.NoError(t, err)
+
+	_, err = business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", false)
+	assert.ErrorIs(t, err, ErrFailedPrecondition)
+	_, err = business.RecordIdempotentDecision(ctx, "swipe-1", "a", "c", true)
+	assert.ErrorIs(t, err, ErrFailedPrecondition)
+
+	// keys belong to each actor
+	_, err = business.RecordIdempotentDecision(ctx, "swipe-1", "c", "b", true)
+	assert.NoError(t, err)
+}
+
+func TestRecordIdempotentDecision_ExpiredKeyRecordsAgain(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b")
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	business.SetIdempotencyTTL(time.Hour)
+
+	_, err := business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", true)
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	_, err = business.RecordIdempotentDecision(ctx, "swipe-1", "a", "b", true)
+	require.NoError(t, err)
+
+	history, err := business.ListDecisionHistory(ctx, "a", "", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Len(t, history.Events, 2)
+
+	// the renewed key is not purged, an expired one is
+	deleted, err := store.DeleteExpiredIdempotencyKeys(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	now = now.Add(time.Hour)
+	deleted, err = store.DeleteExpiredIdempotencyKeys(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestPutDecision_IdempotencyKeyMetadata(t *testing.T) {
+	_, business := setupMemoryBusiness(t, "a", "b")
+	service := &ExploreService{Business: business, Validator: NewRequestValidator(ValidationConfig{})}
+	req := &pb.PutDecisionRequest{ActorUserId: "a", RecipientUserId: "b", LikedRecipient: true}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyMetadata, "swipe-1"))
+	_, err := service.PutDecision(ctx, req)
+	require.NoError(t, err)
+
+	// the same key sent in the request is a retry
+	key := "swipe-1"
+	_, err = service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId: "a", RecipientUserId: "b", LikedRecipient: false, IdempotencyKey: &key,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, ReasonIdempotencyKeyReused, errorInfoOf(t, err).Reason)
+
+	// different keys in the request and the metadata
+	other := "swipe-2"
+	req.IdempotencyKey = &other
+	_, err = service.PutDecision(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, fieldViolationsOf(t, err), idempotencyKeyMetadata)
+}
+
+func TestPutDecision_MySQLIdempotentRetry(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM idempotency_key\s+WHERE actor_user_id = \?\s+AND idempotency_key = \?\s+AND expires_at > CURRENT_TIMESTAMP\s+FOR UPDATE`).
+		WithArgs("actor1", "swipe-1").
+		WillReturnRows(sqlmock.NewRows([]string{"recipient_user_id", "liked_recipient", "mutual_likes"}).
+			AddRow("actor2", true, true))
+	mock.ExpectCommit()
+
+	key := "swipe-1"
+	resp, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     "actor1",
+		RecipientUserId: "actor2",
+		LikedRecipient:  true,
+		IdempotencyKey:  &key,
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.MutualLikes)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// LikeStatsReconcilerConfig holds the chunking and scheduling settings of LikeStatsReconciler
+type LikeStatsReconcilerConfig struct {
+	Interval  time.Duration // delay between two passes of Run
+	ChunkSize int           // users checked per query
+	Repair    bool          // overwrite the drifted counts, they are only reported otherwise
+}
+
+// DefaultLikeStatsReconcilerConfig returns the settings used when nothing is configured
+func DefaultLikeStatsReconcilerConfig() LikeStatsReconcilerConfig {
+	return LikeStatsReconcilerConfig{
+		Interval:  24 * time.Hour,
+		ChunkSize: 500,
+	}
+}
+
+// LikeStatsReport sums up a reconciliation pass
+type LikeStatsReport struct {
+	UsersChecked int
+	Drifted      int // users whose like_stats did not match their decisions
+	Repaired     int
+}
+
+// LikeStatsReconciler recomputes the like_stats counters from the decision table, the same way
+// the decisions maintain them: likes of actors blocked by the user or hidden by moderation are not counted.
+// Users are checked in chunks with non-locking reads, only the drifted ones are checked again
+// under the lock of their like_stats row before being repaired, so a decision committed during the pass
+// is not mistaken for drift
+type LikeStatsReconciler struct {
+	store  LikeStatsStore
+	config LikeStatsReconcilerConfig
+}
+
+// NewLikeStatsReconciler creates a reconciler for the like_stats of the store
+func NewLikeStatsReconciler(store LikeStatsStore, config LikeStatsReconcilerConfig) *LikeStatsReconciler {
+	return &LikeStatsReconciler{store: store, config: config}
+}
+
+// Run reconciles every user once per interval until ctx is done, logging the drifted counts
+func (r *LikeStatsReconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		report, err := r.Reconcile(ctx, func(count LikeCount) error {
+			log.Printf("like_stats of user %s drifted: %d cached, %d counted", count.UserID, count.Cached, count.Actual)
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("error reconciling like_stats: %v", err)
+			continue
+		}
+		if report.Drifted > 0 {
+			log.Printf("like_stats reconciled: %d users checked, %d drifted, %d repaired",
+				report.UsersChecked, report.Drifted, report.Repaired)
+		}
+	}
+}
+
+// Reconcile checks the like_stats of every user and passes the drifted ones to onDrift. When repairing,
+// onDrift gets the counts found under the lock, after the row was overwritten
+func (r *LikeStatsReconciler) Reconcile(ctx context.Context, onDrift func(LikeCount) error) (LikeStatsReport, error) {
+	var report LikeStatsReport
+	afterUserID := ""
+	for {
+		counts, err := r.store.CheckLikeCounts(ctx, afterUserID, r.config.ChunkSize)
+		if err != nil {
+			return report, err
+		}
+
+		for _, count := range counts {
+			report.UsersChecked++
+			if !count.Drifted() {
+				continue
+			}
+			if r.config.Repair {
+				// the drift may come from a decision committed since the chunk was read
+				count, err = r.store.RepairLikeCount(ctx, count.UserID)
+				if errors.Is(err, ErrNotFound) {
+					continue // deleted meanwhile
+				}
+				if err != nil {
+					return report, err
+				}
+				if !count.Drifted() {
+					continue
+				}
+				report.Repaired++
+			}
+			report.Drifted++
+			if err := onDrift(count); err != nil {
+				return report, err
+			}
+		}
+
+		if len(counts) < r.config.ChunkSize {
+			return report, nil
+		}
+		afterUserID = counts[len(counts)-1].UserID
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLikeStatsReconciler_ReportsAndRepairsDrift(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d", "e")
+
+	for _, actor := range []string{"b", "c", "d"} {
+		_, err := business.RecordDecision(ctx, actor, "a", true)
+		require.NoError(t, err)
+	}
+	require.NoError(t, business.BlockUser(ctx, "a", "c"))
+	resolveReport(t, business, "e", "d", OutcomeLikesHidden)
+
+	// a counts b only, e is being deleted
+	require.NoError(t, store.SetLikeCount("a", 3))
+	require.NoError(t, store.SetLikeCount("b", 1))
+	require.NoError(t, store.SetLikeCount("e", 7))
+	_, err := business.DeleteUser(ctx, "e")
+	require.NoError(t, err)
+
+	var drifted []LikeCount
+	collect := func(count LikeCount) error {
+		drifted = append(drifted, count)
+		return nil
+	}
+
+	reconciler := NewLikeStatsReconciler(store, LikeStatsReconcilerConfig{ChunkSize: 2})
+	report, err := reconciler.Reconcile(ctx, collect)
+	require.NoError(t, err)
+	assert.Equal(t, LikeStatsReport{UsersChecked: 4, Drifted: 2}, report)
+	assert.Equal(t, []LikeCount{{UserID: "a", Cached: 3, Actual: 1}, {UserID: "b", Cached: 1, Actual: 0}}, drifted)
+
+	// only reported so far
+	count, err := business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	drifted = nil
+	reconciler = NewLikeStatsReconciler(store, LikeStatsReconcilerConfig{ChunkSize: 2, Repair: true})
+	report, err = reconciler.Reconcile(ctx, collect)
+	require.NoError(t, err)
+	assert.Equal(t, LikeStatsReport{UsersChecked: 4, Drifted: 2, Repaired: 2}, report)
+	assert.Len(t, drifted, 2)
+
+	for user, expected := range map[string]uint64{"a": 1, "b": 0} {
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, count, user)
+	}
+
+	report, err = reconciler.Reconcile(ctx, collect)
+	require.NoError(t, err)
+	assert.Zero(t, report.Drifted)
+}
+
+// driftedLikeStatsStore reports a drift that a concurrent decision already fixed when the row is locked
+type driftedLikeStatsStore struct {
+	LikeStatsStore
+}
+
+func (s driftedLikeStatsStore) CheckLikeCounts(ctx context.Context, afterUserID string, limit int) ([]LikeCount, error) {
+	return []LikeCount{{UserID: "a", Cached: 1, Actual: 2}}, nil
+}
+
+func (s driftedLikeStatsStore) RepairLikeCount(ctx context.Context, userID string) (LikeCount, error) {
+	return LikeCount{UserID: userID, Cached: 2, Actual: 2}, nil
+}
+
+func TestLikeStatsReconciler_DriftGoneUnderLock(t *testing.T) {
+	reconciler := NewLikeStatsReconciler(driftedLikeStatsStore{}, LikeStatsReconcilerConfig{ChunkSize: 10, Repair: true})
+
+	report, err := reconciler.Reconcile(context.Background(), func(LikeCount) error {
+		t.Fatal("no drift expected")
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, LikeStatsReport{UsersChecked: 1}, report)
+}
+
+func TestCheckLikeCounts_MySQLSkipsDeletedUsers(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM decision d\s+WHERE d.recipient_user_id = u.id\s+AND d.liked_recipient = TRUE\s+AND NOT EXISTS .+`+
+		`FROM user u\s+LEFT JOIN like_stats ls ON ls.user_id = u.id\s+WHERE u.id > \?\s+AND NOT EXISTS \(\s+SELECT 1\s+FROM user_deletion ud`).
+		WithArgs("actor1", 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "like_count", "actual"}).
+			AddRow("actor2", 3, 2).
+			AddRow("actor3", 0, 0))
+
+	counts, err := NewMySQLStore(&DB{db}).CheckLikeCounts(context.Background(), "actor1", 500)
+
+	require.NoError(t, err)
+	assert.Equal(t, []LikeCount{{UserID: "actor2", Cached: 3, Actual: 2}, {UserID: "actor3"}}, counts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepairLikeCount_MySQLLocksBeforeRecounting(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO like_stats \(user_id, like_count\)\s+VALUES \(\?, 0\)\s+ON DUPLICATE KEY UPDATE like_count = like_count`).
+		WithArgs("actor1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT\s+ls.like_count,.+FROM user u\s+JOIN like_stats ls ON ls.user_id = u.id\s+WHERE u.id = \?`).
+		WithArgs("actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count", "actual"}).AddRow(3, 2))
+	mock.ExpectExec(`UPDATE like_stats\s+SET like_count = \?\s+WHERE user_id = \?`).
+		WithArgs(uint64(2), "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	count, err := NewMySQLStore(&DB{db}).RepairLikeCount(context.Background(), "actor1")
+
+	require.NoError(t, err)
+	assert.Equal(t, LikeCount{UserID: "actor1", Cached: 3, Actual: 2}, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LikeNotificationKind tells what a LikeNotification is about
+type LikeNotificationKind int
+
+const (
+	NotificationLikeReceived LikeNotificationKind = iota // someone liked the user
+	NotificationMatchCreated                             // the user and someone else now like each other
+)
+
+// LikeNotification is pushed to the WatchLikes streams of a user
+type LikeNotification struct {
+	EventID       uint64 // decision_event id, the position streams resume from
+	Kind          LikeNotificationKind
+	UserID        string // notified user
+	OtherUserID   string // user who liked or matched the notified user
+	UnixTimestamp uint64
+	Late          bool // the event committed after the watcher stopped waiting for it, streams may be past its id
+}
+
+// notificationsOf returns the notifications of a decision event. A like notifies its recipient,
+// a like creating a match notifies both users of the match instead. Like ListLikeEvents, the recipient
+// is not notified of the likes of an actor whose likes are hidden, the actor still hears of its match
+func notificationsOf(event DecisionEvent) []LikeNotification {
+	switch {
+	case !event.Liked:
+		return nil
+	case event.Matched && event.ActorLikesHidden:
+		return []LikeNotification{
+			{EventID: event.ID, Kind: NotificationMatchCreated, UserID: event.ActorID, OtherUserID: event.RecipientID, UnixTimestamp: event.UnixTimestamp},
+		}
+	case event.ActorLikesHidden:
+		return nil
+	case event.Matched:
+		return []LikeNotification{
+			{EventID: event.ID, Kind: NotificationMatchCreated, UserID: event.RecipientID, OtherUserID: event.ActorID, UnixTimestamp: event.UnixTimestamp},
+			{EventID: event.ID, Kind: NotificationMatchCreated, UserID: event.ActorID, OtherUserID: event.RecipientID, UnixTimestamp: event.UnixTimestamp},
+		}
+	default:
+		return []LikeNotification{
+			{EventID: event.ID, Kind: NotificationLikeReceived, UserID: event.RecipientID, OtherUserID: event.ActorID, UnixTimestamp: event.UnixTimestamp},
+		}
+	}
+}
+
+// WatchConfig holds the polling settings of LikeWatcher
+type WatchConfig struct {
+	PollInterval     time.Duration // delay between polls, local commits wake the watcher up earlier
+	GapTimeout       time.Duration // how long a missing event id is waited for before assuming it was rolled back
+	LateEventTimeout time.Duration // how long the ids skipped after GapTimeout are still looked for
+	BatchSize        int           // decision events read per query
+	SubscriberBuffer int           // notifications buffered per stream before it is dropped as lagging
+}
+
+// DefaultWatchConfig returns the settings used when nothing is configured
+func DefaultWatchConfig() WatchConfig {
+	return WatchConfig{
+		PollInterval:     time.Second,
+		GapTimeout:       5 * time.Second,
+		LateEventTimeout: 10 * time.Minute,
+		BatchSize:        500,
+		SubscriberBuffer: 64,
+	}
+}
+
+// LikeWatcher tails the decision history and fans the notifications out to the WatchLikes streams
+// of this server instance. The history is shared by every instance, so likes recorded by any of them
+// reach every stream.
+//
+// Event ids are assigned on insert but only become visible on commit, so a missing id can be a
+// transaction still in flight. The watcher stops at the first missing id until it shows up or
+// GapTimeout elapses (rolled back transactions leave permanent gaps), this way a notification is never
+// delivered before the ones with smaller ids and resuming from an event id does not skip any.
+//
+// A transaction can still commit after GapTimeout, so the skipped ids are looked up again on every poll
+// for LateEventTimeout. Events showing up meanwhile are published as Late, and resume tokens carry the
+// skipped ids below their position so a stream reconnecting in between still gets them
+type LikeWatcher struct {
+	feed   DecisionEventFeed
+	config WatchConfig
+	now    func() time.Time
+	wake   chan struct{}
+
+	mu          sync.Mutex
+	position    uint64               // every event up to this id was dispatched
+	gapSince    time.Time            // when the ids after position were first seen missing, zero if there is no gap
+	gapEnd      uint64               // id of the event that revealed the gap
+	skipped     map[uint64]time.Time // ids skipped after GapTimeout and still looked for, with when they were skipped
+	stopped     bool
+	subscribers map[string]map[*LikeSubscription]struct{}
+}
+
+// NewLikeWatcher creates a watcher on top of the decision history, Start must be called before use
+func NewLikeWatcher(feed DecisionEventFeed, config WatchConfig) *LikeWatcher {
+	return &LikeWatcher{
+		feed:        feed,
+		config:      config,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+		skipped:     make(map[uint64]time.Time),
+		subscribers: make(map[string]map[*LikeSubscription]struct{}),
+	}
+}
+
+// maxSkippedEvents bounds the ids a watcher looks for after skipping them, a larger gap is given up for good
+const maxSkippedEvents = 1000
+
+// maxResumeSkippedEvents bounds the skipped ids carried by a resume token, the most recent ones are kept
+const maxResumeSkippedEvents = 32
+
+// Start positions the watcher at the end of the decision history and polls it in the background
+// until ctx is done, then every subscription is closed
+func (w *LikeWatcher) Start(ctx context.Context) error {
+	position, err := w.feed.LastDecisionEventID(ctx)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.position = position
+	w.mu.Unlock()
+
+	go w.run(ctx)
+	return nil
+}
+
+func (w *LikeWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("error polling decision events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.stop()
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// Notify wakes the watcher up after a local commit, so local streams don't wait for the next poll
+func (w *LikeWatcher) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// poll dispatches every new event, then the skipped events that have committed since
+func (w *LikeWatcher) poll(ctx context.Context) error {
+	if err := w.pollNew(ctx); err != nil {
+		return err
+	}
+	return w.pollSkipped(ctx)
+}
+
+// pollNew dispatches every new event, reading them in batches
+func (w *LikeWatcher) pollNew(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		after := w.position
+		w.mu.Unlock()
+
+		events, err := w.feed.ListDecisionEventsAfter(ctx, after, w.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		complete := w.dispatch(events)
+		w.mu.Unlock()
+
+		if !complete || len(events) < w.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// pollSkipped looks the skipped ids up again and publishes the events found as Late.
+// Ids skipped for longer than LateEventTimeout are given up
+func (w *LikeWatcher) pollSkipped(ctx context.Context) error {
+	w.mu.Lock()
+	var ids []uint64
+	for id, skippedAt := range w.skipped {
+		switch {
+		case w.now().Sub(skippedAt) >= w.config.LateEventTimeout:
+			delete(w.skipped, id)
+		case id <= w.position: // ids above are still read by pollNew
+			ids = append(ids, id)
+		}
+	}
+	w.mu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	events, err := w.feed.ListDecisionEventsByID(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, event := range events {
+		if _, ok := w.skipped[event.ID]; !ok {
+			continue
+		}
+		delete(w.skipped, event.ID)
+		for _, notification := range notificationsOf(event) {
+			notification.Late = true
+			w.publish(notification)
+		}
+	}
+	return nil
+}
+
+// dispatch publishes the events in id order, stopping at a gap that is still waited for.
+// It reports if every event was dispatched. Must be called with w.mu held
+func (w *LikeWatcher) dispatch(events []DecisionEvent) bool {
+	for _, event := range events {
+		if event.ID > w.position+1 {
+			if w.gapSince.IsZero() {
+				w.gapSince, w.gapEnd = w.now(), event.ID
+			}
+			if w.now().Sub(w.gapSince) < w.config.GapTimeout {
+				return false
+			}
+			// the missing ids did not show up, most likely rolled back, pollSkipped still looks for them
+			w.skip(w.position+1, event.ID-1)
+		}
+
+		w.position = event.ID
+		if w.position+1 >= w.gapEnd {
+			w.gapSince = time.Time{}
+		}
+		// a resumed stream asked for it, it is below the position of that stream
+		_, late := w.skipped[event.ID]
+		delete(w.skipped, event.ID)
+		for _, notification := range notificationsOf(event) {
+			notification.Late = late
+			w.publish(notification)
+		}
+	}
+	return true
+}
+
+// skip records the ids from first to last as skipped. Must be called with w.mu held
+func (w *LikeWatcher) skip(first, last uint64) {
+	for id := first; id <= last; id++ {
+		if len(w.skipped) >= maxSkippedEvents {
+			log.Printf("too many skipped decision events, events %d to %d are not looked for", id, last)
+			return
+		}
+		w.skipped[id] = w.now()
+	}
+}
+
+// lookFor adds the skipped ids of a resume token, that this watcher may not know of
+func (w *LikeWatcher) lookFor(ids []uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := w.skipped[id]; !ok && len(w.skipped) < maxSkippedEvents {
+			w.skipped[id] = w.now()
+		}
+	}
+}
+
+// skippedUpTo returns the most recent skipped ids up to lastID for a resume token, leaving out those
+// the stream already got
+func (w *LikeWatcher) skippedUpTo(lastID uint64, delivered map[uint64]struct{}) []uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var ids []uint64
+	for id := range w.skipped {
+		if _, ok := delivered[id]; !ok && id <= lastID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > maxResumeSkippedEvents {
+		ids = ids[len(ids)-maxResumeSkippedEvents:]
+	}
+	return ids
+}
+
+// publish hands the notification to the user streams, a stream whose buffer is full is dropped
+// instead of blocking everyone else. Must be called with w.mu held
+func (w *LikeWatcher) publish(notification LikeNotification) {
+	for subscription := range w.subscribers[notification.UserID] {
+		select {
+		case subscription.notifications <- notification:
+		default:
+			subscription.lagging = true
+			w.remove(subscription)
+		}
+	}
+}
+
+// stop closes every subscription, must not be called with w.mu held
+func (w *LikeWatcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stopped = true
+	for _, subscriptions := range w.subscribers {
+		for subscription := range subscriptions {
+			w.remove(subscription)
+		}
+	}
+}
+
+// remove unregisters the subscription and closes its channel. Must be called with w.mu held
+func (w *LikeWatcher) remove(subscription *LikeSubscription) {
+	subscriptions := w.subscribers[subscription.userID]
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(w.subscribers, subscription.userID)
+	}
+	close(subscription.notifications)
+}
+
+// Subscribe registers a stream for the notifications of the user with an event id greater than Start()
+func (w *LikeWatcher) Subscribe(userID string) *LikeSubscription {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscription := &LikeSubscription{
+		watcher:       w,
+		userID:        userID,
+		start:         w.position,
+		skipped:       make(map[uint64]struct{}, len(w.skipped)),
+		notifications: make(chan LikeNotification, w.config.SubscriberBuffer),
+	}
+	for id := range w.skipped {
+		subscription.skipped[id] = struct{}{}
+	}
+	if w.stopped {
+		close(subscription.notifications)
+		return subscription
+	}
+
+	if w.subscribers[userID] == nil {
+		w.subscribers[userID] = make(map[*LikeSubscription]struct{})
+	}
+	w.subscribers[userID][subscription] = struct{}{}
+	return subscription
+}
+
+// LikeSubscription receives the live notifications of a single user
+type LikeSubscription struct {
+	watcher       *LikeWatcher
+	userID        string
+	start         uint64
+	skipped       map[uint64]struct{} // ids skipped by the watcher when subscribing, they may still come as Late
+	notifications chan LikeNotification
+	lagging       bool // dropped for not keeping up, only read once notifications is closed
+}
+
+// Start returns the watcher position when subscribing, earlier events must be read from the DecisionEventFeed
+func (s *LikeSubscription) Start() uint64 {
+	return s.start
+}
+
+// Notifications is closed when the subscription is dropped, Err then tells why
+func (s *LikeSubscription) Notifications() <-chan LikeNotification {
+	return s.notifications
+}
+
+// Err returns why the notifications channel was closed
+func (s *LikeSubscription) Err() error {
+	s.watcher.mu.Lock()
+	defer s.watcher.mu.Unlock()
+
+	if s.lagging {
+		return &DomainError{
+			Kind:    ErrAborted,
+			Reason:  ReasonSubscriberLagging,
+			Message: "stream fell behind, resume it with the last resume token",
+		}
+	}
+	return &DomainError{
+		Kind:    ErrUnavailable,
+		Reason:  ReasonWatchUnavailable,
+		Message: "like notifications are not available, retry later",
+	}
+}
+
+// Close unregisters the subscription
+func (s *LikeSubscription) Close() {
+	s.watcher.mu.Lock()
+	defer s.watcher.mu.Unlock()
+	s.watcher.remove(s)
+}
+
+const watchLikesEndpoint = "WatchLikes"
+
+// replayBatchSize is the amount of missed events read per query when resuming a stream
+const replayBatchSize = 100
+
+// AttachLikeWatcher enables WatchLikes, RecordDecision then wakes the watcher up after every like
+func (b *ExploreBusiness) AttachLikeWatcher(watcher *LikeWatcher) {
+	b.watcher = watcher
+}
+
+// WatchLikes calls send with every notification of the user until ctx is done or the stream is dropped.
+// Each notification comes with a resume token, when one is given the notifications recorded since
+// it was issued are replayed first
+func (b *ExploreBusiness) WatchLikes(ctx context.Context, userID, resumeToken string, send func(notification LikeNotification, resumeToken string) error) error {
+	if b.watcher == nil {
+		return &DomainError{Kind: ErrUnavailable, Reason: ReasonWatchUnavailable, Message: "like notifications are not enabled"}
+	}
+
+	var cursor pageCursor
+	if resumeToken != "" {
+		var err error
+		cursor, err = b.tokens.Decode(resumeToken, watchLikesEndpoint, userID)
+		if err != nil {
+			return err
+		}
+	}
+	lastID := cursor.ID
+
+	// late events already sent, the watcher may still publish them
+	delivered := make(map[uint64]struct{})
+	deliver := func(notification LikeNotification) error {
+		if notification.Late {
+			delivered[notification.EventID] = struct{}{}
+		} else {
+			lastID = notification.EventID
+		}
+		token, err := b.tokens.Encode(watchLikesEndpoint, userID, pageCursor{ID: lastID, Skipped: b.watcher.skippedUpTo(lastID, delivered)})
+		if err != nil {
+			return err
+		}
+		return send(notification, token)
+	}
+	deliverEvent := func(event DecisionEvent, late bool) error {
+		for _, notification := range notificationsOf(event) {
+			if notification.UserID != userID {
+				continue
+			}
+			notification.Late = late
+			if err := deliver(notification); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// subscribe first, so nothing committed during the replay is lost
+	subscription := b.watcher.Subscribe(userID)
+	defer subscription.Close()
+
+	// 1. Send the skipped events of the resume token that have committed since, the watcher looks for the others
+	if len(cursor.Skipped) > 0 {
+		events, err := b.watcher.feed.ListDecisionEventsByID(ctx, cursor.Skipped)
+		if err != nil {
+			return err
+		}
+		found := make(map[uint64]bool, len(events))
+		for _, event := range events {
+			found[event.ID] = true
+			delivered[event.ID] = struct{}{}
+			if err := deliverEvent(event, true); err != nil {
+				return err
+			}
+		}
+		var missing []uint64
+		for _, id := range cursor.Skipped {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		b.watcher.lookFor(missing)
+	}
+
+	// 2. Replay the events missed since the resume token, up to where the subscription starts
+	for resumeToken != "" && lastID < subscription.Start() {
+		events, err := b.watcher.feed.ListLikeEvents(ctx, userID, lastID, subscription.Start(), replayBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, event := range events {
+			if _, ok := subscription.skipped[event.ID]; ok {
+				delivered[event.ID] = struct{}{}
+			}
+			if err := deliverEvent(event, false); err != nil {
+				return err
+			}
+			lastID = event.ID
+		}
+	}
+
+	// 3. Live notifications, skipping those already sent
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification, ok := <-subscription.Notifications():
+			if !ok {
+				return subscription.Err()
+			}
+			if _, ok := delivered[notification.EventID]; ok && notification.Late {
+				continue
+			}
+			if notification.EventID <= lastID && !notification.Late {
+				continue
+			}
+			if err := deliver(notification); err != nil {
+				return err
+			}
+		}
+	}
+}
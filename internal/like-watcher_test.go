@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLikeWatcher attaches a watcher to the business without starting its background loop,
+// tests call poll themselves
+func setupLikeWatcher(t *testing.T, store *MemoryStore, business *ExploreBusiness) *LikeWatcher {
+	watcher := NewLikeWatcher(store, DefaultWatchConfig())
+	business.AttachLikeWatcher(watcher)
+	return watcher
+}
+
+// watchedNotification is a notification received through ExploreBusiness.WatchLikes
+type watchedNotification struct {
+	LikeNotification
+	resumeToken string
+}
+
+// watchLikes runs WatchLikes in the background until the test ends, the returned channel
+// gets every notification sent to the stream
+func watchLikes(t *testing.T, business *ExploreBusiness, userID, resumeToken string) <-chan watchedNotification {
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan watchedNotification, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = business.WatchLikes(ctx, userID, resumeToken, func(notification LikeNotification, token string) error {
+			received <- watchedNotification{notification, token}
+			return nil
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return received
+}
+
+func nextNotification(t *testing.T, received <-chan watchedNotification) watchedNotification {
+	select {
+	case notification := <-received:
+		return notification
+	case <-time.After(time.Second):
+		require.FailNow(t, "no notification received")
+		return watchedNotification{}
+	}
+}
+
+// waitForSubscribers waits until the WatchLikes stream of the user is subscribed
+func waitForSubscribers(t *testing.T, watcher *LikeWatcher, userID string) {
+	require.Eventually(t, func() bool {
+		watcher.mu.Lock()
+		defer watcher.mu.Unlock()
+		return len(watcher.subscribers[userID]) > 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWatchLikes_LikesAndMatches(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+	watcher := setupLikeWatcher(t, store, business)
+
+	received := watchLikes(t, business, "a", "")
+	waitForSubscribers(t, watcher, "a")
+
+	_, err := business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "c", "b", true) // not about a
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "a", "b", true) // a likes back
+	require.NoError(t, err)
+	require.NoError(t, watcher.poll(ctx))
+
+	like := nextNotification(t, received)
+	assert.Equal(t, NotificationLikeReceived, like.Kind)
+	assert.Equal(t, "b", like.OtherUserID)
+	assert.NotEmpty(t, like.resumeToken)
+
+	match := nextNotification(t, received)
+	assert.Equal(t, NotificationMatchCreated, match.Kind)
+	assert.Equal(t, "b", match.OtherUserID)
+}
+
+func TestWatchLikes_HiddenActorLikesAreNotDeliveredLive(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+	watcher := setupLikeWatcher(t, store, business)
+
+	resolveReport(t, business, "a", "b", OutcomeLikesHidden)
+	received := watchLikes(t, business, "a", "")
+	watchedByB := watchLikes(t, business, "b", "")
+	waitForSubscribers(t, watcher, "a")
+	waitForSubscribers(t, watcher, "b")
+
+	_, err := business.RecordDecision(ctx, "b", "a", true) // hidden
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "c", "a", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "d", "b", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "d", true) // hidden, but b still hears of its match
+	require.NoError(t, err)
+	require.NoError(t, watcher.poll(ctx))
+
+	like := nextNotification(t, received)
+	assert.Equal(t, NotificationLikeReceived, like.Kind)
+	assert.Equal(t, "c", like.OtherUserID)
+
+	assert.Equal(t, NotificationLikeReceived, nextNotification(t, watchedByB).Kind)
+	match := nextNotification(t, watchedByB)
+	assert.Equal(t, NotificationMatchCreated, match.Kind)
+	assert.Equal(t, "d", match.OtherUserID)
+
+	select {
+	case notification := <-received:
+		assert.Fail(t, "unexpected notification", "%+v", notification)
+	default:
+	}
+}
+
+func TestWatchLikes_ResumeReplaysMissedEvents(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+	watcher := setupLikeWatcher(t, store, business)
+
+	// first connection, receives the like of b
+	_, err := business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+	require.NoError(t, watcher.poll(ctx))
+	first, err := store.ListLikeEvents(ctx, "a", 0, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	resumeToken, err := business.tokens.Encode(watchLikesEndpoint, "a", pageCursor{ID: first[0].ID})
+	require.NoError(t, err)
+
+	// while disconnected
+	_, err = business.RecordDecision(ctx, "c", "a", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "d", "a", false)
+	require.NoError(t, err)
+	require.NoError(t, watcher.poll(ctx))
+
+	// reconnecting replays the like of c, then goes on live
+	received := watchLikes(t, business, "a", resumeToken)
+	replayed := nextNotification(t, received)
+	assert.Equal(t, "c", replayed.OtherUserID)
+
+	waitForSubscribers(t, watcher, "a")
+	_, err = business.RecordDecision(ctx, "d", "a", true)
+	require.NoError(t, err)
+	require.NoError(t, watcher.poll(ctx))
+	assert.Equal(t, "d", nextNotification(t, received).OtherUserID)
+}
+
+func TestWatchLikes_ResumeTokenBoundToUser(t *testing.T) {
+	store, business := setupMemoryBusiness(t, "a", "b")
+	setupLikeWatcher(t, store, business)
+
+	token, err := business.tokens.Encode(watchLikesEndpoint, "a", pageCursor{ID: 1})
+	require.NoError(t, err)
+
+	err = business.WatchLikes(context.Background(), "b", token, func(LikeNotification, string) error { return nil })
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+}
+
+func TestLikeWatcher_HoldsGapsUntilTimeout(t *testing.T) {
+	watcher := NewLikeWatcher(nil, WatchConfig{GapTimeout: 5 * time.Second, SubscriberBuffer: 10})
+	now := time.Unix(1700000000, 0)
+	watcher.now = func() time.Time { return now }
+	subscription := watcher.Subscribe("a")
+
+	like := func(id uint64) DecisionEvent {
+		return DecisionEvent{ID: id, ActorID: "b", RecipientID: "a", Liked: true}
+	}
+
+	// event 2 is not committed yet, 3 waits for it
+	assert.False(t, watcher.dispatch([]DecisionEvent{like(1), like(3)}))
+	assert.Equal(t, uint64(1), watcher.position)
+
+	// 2 commits, both are delivered in order
+	assert.True(t, watcher.dispatch([]DecisionEvent{like(2), like(3)}))
+
+	// 4 is never committed, 5 is delivered once the gap times out
+	assert.False(t, watcher.dispatch([]DecisionEvent{like(5)}))
+	now = now.Add(5 * time.Second)
+	assert.True(t, watcher.dispatch([]DecisionEvent{like(5)}))
+
+	var ids []uint64
+	for len(subscription.Notifications()) > 0 {
+		ids = append(ids, (<-subscription.Notifications()).EventID)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 5}, ids)
+}
+
+func TestLikeWatcher_DropsLaggingSubscribers(t *testing.T) {
+	watcher := NewLikeWatcher(nil, WatchConfig{SubscriberBuffer: 1})
+	subscription := watcher.Subscribe("a")
+
+	watcher.dispatch([]DecisionEvent{
+		{ID: 1, ActorID: "b", RecipientID: "a", Liked: true},
+		{ID: 2, ActorID: "c", RecipientID: "a", Liked: true},
+	})
+
+	<-subscription.Notifications()
+	_, open := <-subscription.Notifications()
+	assert.False(t, open)
+	assert.ErrorIs(t, subscription.Err(), ErrAborted)
+}
+
+// eventFeed is a DecisionEventFeed whose events are added by the test, in any id order
+type eventFeed struct {
+	mu     sync.Mutex
+	events map[uint64]DecisionEvent
+}
+
+func (f *eventFeed) commit(events ...DecisionEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, event := range events {
+		f.events[event.ID] = event
+	}
+}
+
+func (f *eventFeed) list(keep func(DecisionEvent) bool, limit int) []DecisionEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var events []DecisionEvent
+	for _, event := range f.events {
+		if keep(event) {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
+
+func (f *eventFeed) LastDecisionEventID(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (f *eventFeed) ListDecisionEventsAfter(ctx context.Context, afterID uint64, limit int) ([]DecisionEvent, error) {
+	return f.list(func(event DecisionEvent) bool { return event.ID > afterID }, limit), nil
+}
+
+func (f *eventFeed) ListDecisionEventsByID(ctx context.Context, ids []uint64) ([]DecisionEvent, error) {
+	return f.list(func(event DecisionEvent) bool { return slices.Contains(ids, event.ID) }, 0), nil
+}
+
+func (f *eventFeed) ListLikeEvents(ctx context.Context, userID string, afterID, untilID uint64, limit int) ([]DecisionEvent, error) {
+	notified := func(event DecisionEvent) bool {
+		return event.ID > afterID && event.ID <= untilID &&
+			((event.RecipientID == userID && event.Liked) || (event.ActorID == userID && event.Matched))
+	}
+	return f.list(notified, limit), nil
+}
+
+func TestLikeWatcher_DeliversGapsFilledAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+	feed := &eventFeed{events: make(map[uint64]DecisionEvent)}
+	watcher := NewLikeWatcher(feed, WatchConfig{GapTimeout: 5 * time.Second, LateEventTimeout: time.Minute, BatchSize: 10, SubscriberBuffer: 10})
+	now := time.Unix(1700000000, 0)
+	watcher.now = func() time.Time { return now }
+	subscription := watcher.Subscribe("a")
+
+	like := func(id uint64) DecisionEvent {
+		return DecisionEvent{ID: id, ActorID: "b", RecipientID: "a", Liked: true}
+	}
+
+	// 2 is still in flight after the gap timeout, 3 is delivered without it
+	feed.commit(like(1), like(3))
+	require.NoError(t, watcher.poll(ctx))
+	now = now.Add(5 * time.Second)
+	require.NoError(t, watcher.poll(ctx))
+
+	// 2 commits late and is still delivered
+	feed.commit(like(2))
+	require.NoError(t, watcher.poll(ctx))
+
+	// 4 and 5 commit after the late event timeout, they are given up
+	feed.commit(like(6))
+	require.NoError(t, watcher.poll(ctx))
+	now = now.Add(5 * time.Second)
+	require.NoError(t, watcher.poll(ctx))
+	now = now.Add(time.Minute)
+	require.NoError(t, watcher.poll(ctx))
+	feed.commit(like(4), like(5))
+	require.NoError(t, watcher.poll(ctx))
+
+	var ids []uint64
+	var late []bool
+	for len(subscription.Notifications()) > 0 {
+		notification := <-subscription.Notifications()
+		ids = append(ids, notification.EventID)
+		late = append(late, notification.Late)
+	}
+	assert.Equal(t, []uint64{1, 3, 2, 6}, ids)
+	assert.Equal(t, []bool{false, false, true, false}, late)
+}
+
+func TestWatchLikes_LateEventsReachLiveAndResumedStreams(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a")
+	feed := &eventFeed{events: make(map[uint64]DecisionEvent)}
+	watcher := NewLikeWatcher(feed, WatchConfig{GapTimeout: 5 * time.Second, LateEventTimeout: time.Minute, BatchSize: 10, SubscriberBuffer: 10})
+	now := time.Unix(1700000000, 0)
+	watcher.now = func() time.Time { return now }
+	business.AttachLikeWatcher(watcher)
+
+	live := watchLikes(t, business, "a", "")
+	waitForSubscribers(t, watcher, "a")
+
+	// the like of c is delivered once the like of b, still in flight, times out
+	feed.commit(
+		DecisionEvent{ID: 1, ActorID: "c", RecipientID: "a", Liked: true},
+		DecisionEvent{ID: 3, ActorID: "d", RecipientID: "a", Liked: true},
+	)
+	require.NoError(t, watcher.poll(ctx))
+	now = now.Add(5 * time.Second)
+	require.NoError(t, watcher.poll(ctx))
+	assert.Equal(t, "c", nextNotification(t, live).OtherUserID)
+	skippedOver := nextNotification(t, live)
+	assert.Equal(t, "d", skippedOver.OtherUserID)
+
+	cursor, err := business.tokens.Decode(skippedOver.resumeToken, watchLikesEndpoint, "a")
+	require.NoError(t, err)
+	assert.Equal(t, pageCursor{ID: 3, Skipped: []uint64{2}}, cursor)
+
+	// b commits while another stream is disconnected, resuming after d still gets it
+	feed.commit(DecisionEvent{ID: 2, ActorID: "b", RecipientID: "a", Liked: true})
+	resumed := watchLikes(t, business, "a", skippedOver.resumeToken)
+	late := nextNotification(t, resumed)
+	assert.Equal(t, "b", late.OtherUserID)
+	assert.True(t, late.Late)
+
+	cursor, err = business.tokens.Decode(late.resumeToken, watchLikesEndpoint, "a")
+	require.NoError(t, err)
+	assert.Equal(t, pageCursor{ID: 3}, cursor)
+
+	// the live stream gets it on the next poll
+	require.NoError(t, watcher.poll(ctx))
+	late = nextNotification(t, live)
+	assert.Equal(t, "b", late.OtherUserID)
+	assert.True(t, late.Late)
+
+	select {
+	case notification := <-resumed:
+		assert.Fail(t, "unexpected notification", "%+v", notification)
+	default:
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import "context"
+
+// Match is a mutual like as seen by one of the two users
+type Match struct {
+	ID            uint64
+	MatchedUserID string
+	UnixTimestamp uint64 // time the like completing the match was recorded
+}
+
+type ListMatchesResult struct {
+	Matches             []Match
+	NextPaginationToken string
+}
+
+const listMatchesEndpoint = "ListMatches"
+
+// ListMatches returns the users who mutually like the user, ordered by match time.
+// Matches are created by RecordDecision in the same transaction as the like completing them
+func (b *ExploreBusiness) ListMatches(ctx context.Context, userID string, pagination PaginationParams) (*ListMatchesResult, error) {
+	cursor, err := b.decodeCursor(pagination, listMatchesEndpoint, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := b.store.ListMatches(ctx, userID, timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListMatchesResult{Matches: matches}
+	if len(matches) == pagination.PageSize {
+		last := matches[len(matches)-1]
+		result.NextPaginationToken, err = b.tokens.Encode(listMatchesEndpoint, userID, pageCursor{
+			Timestamp:  last.UnixTimestamp,
+			ID:         last.ID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Unmatch dissolves the match between the actor and the other user. The actor's like is turned
+// into a pass flagged as unmatched, so the other user's like no longer shows up in the actor's
+// ListNewLikedYou, until the actor records a new decision on them
+func (b *ExploreBusiness) Unmatch(ctx context.Context, actorID, otherUserID string) error {
+	return b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Remove the match, it must exist and neither user can be being deleted
+		if err := checkNotDeleted(ctx, tx, actorID, otherUserID); err != nil {
+			return err
+		}
+		found, err := tx.DeleteMatch(ctx, actorID, otherUserID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return &DomainError{
+				Kind:     ErrNotFound,
+				Reason:   ReasonMatchNotFound,
+				Message:  "users are not matched",
+				Metadata: map[string]string{"actor_user_id": actorID, "other_user_id": otherUserID},
+			}
+		}
+
+		// 2. Turn the actor's like into an unmatched pass
+		stats := userStatsChanges{}
+		stats.addMatch(actorID, otherUserID, -1)
+		counted, err := likesCounted(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		if err := takeBackLike(ctx, tx, actorID, otherUserID, true, counted, stats); err != nil {
+			return err
+		}
+		return stats.apply(ctx, tx)
+	})
+}
+
+// takeBackLike overwrites the actor's decision on the other user with a pass, flagged as unmatched
+// when it dissolves a match, and records it like RecordDecision does. counted tells if the like is counted
+// in the other user's like_stats and user_stats, the user_stats changes are added to stats.
+// The match itself is left to the caller
+func takeBackLike(ctx context.Context, tx DecisionTx, actorID, otherUserID string, unmatched, counted bool, stats userStatsChanges) error {
+	// 1. Check the previous decision, callers only take back likes
+	previous, err := tx.GetDecision(ctx, actorID, otherUserID)
+	if err != nil {
+		return err
+	}
+	previousLike := previous == DecisionLiked
+
+	// 2. Overwrite it with a pass, and append it to the history
+	if err := tx.UpsertDecision(ctx, actorID, otherUserID, false, unmatched); err != nil {
+		return err
+	}
+	decision := DecisionEvent{ActorID: actorID, RecipientID: otherUserID, Unmatched: unmatched}
+	if err := tx.AppendDecisionEvent(ctx, decision); err != nil {
+		return err
+	}
+
+	// 3. Like to pass: decrement, same as RecordDecision. The other user's like on the actor, if any, stays answered:
+	// by the unmatch, or by the block which leaves it out anyway
+	if previousLike {
+		stats.add(otherUserID, UserStatsChange{Passes: 1})
+		if counted {
+			if err := tx.DecrementLikeCount(ctx, otherUserID); err != nil {
+				return err
+			}
+			back, err := tx.GetDecision(ctx, otherUserID, actorID)
+			if err != nil {
+				return err
+			}
+			if !answersLike(back) {
+				stats.add(otherUserID, UserStatsChange{NewLikes: -1})
+			}
+		}
+	}
+
+	// 4. Emit a PassRecorded event
+	return appendDecisionOutboxEvents(ctx, tx, decision)
+}
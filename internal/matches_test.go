@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func collectMatchedUserIDs(result *ListMatchesResult) []string {
+	var ids []string
+	for _, match := range result.Matches {
+		ids = append(ids, match.MatchedUserID)
+	}
+	return ids
+}
+
+func TestRecordDecision_MatchFollowsMutualLike(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	result, err := business.ListMatches(ctx, "a", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, result.Matches)
+
+	// the like back creates the match on both sides
+	_, err = business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+	for user, other := range map[string]string{"a": "b", "b": "a"} {
+		result, err := business.ListMatches(ctx, user, PaginationParams{PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{other}, collectMatchedUserIDs(result))
+	}
+
+	// taking a like back dissolves it
+	_, err = business.RecordDecision(ctx, "a", "b", false)
+	require.NoError(t, err)
+	for _, user := range []string{"a", "b"} {
+		result, err := business.ListMatches(ctx, user, PaginationParams{PageSize: 10})
+		require.NoError(t, err)
+		assert.Empty(t, result.Matches)
+	}
+}
+
+func TestRecordDecision_RepeatedLikeKeepsMatchTime(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b")
+
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	_, err = business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+
+	result, err := business.ListMatches(ctx, "a", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Matches, 1)
+	assert.Equal(t, uint64(1700000000), result.Matches[0].UnixTimestamp)
+}
+
+func TestListMatches_PaginationNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	for _, other := range []string{"b", "c", "d"} {
+		_, err := business.RecordDecision(ctx, other, "a", true)
+		require.NoError(t, err)
+		_, err = business.RecordDecision(ctx, "a", other, true)
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+	}
+
+	var matched []string
+	pagination := PaginationParams{PageSize: 2, Order: SortNewestFirst}
+	for {
+		result, err := business.ListMatches(ctx, "a", pagination)
+		require.NoError(t, err)
+		matched = append(matched, collectMatchedUserIDs(result)...)
+		if result.NextPaginationToken == "" {
+			break
+		}
+		pagination.Token = result.NextPaginationToken
+	}
+	assert.Equal(t, []string{"d", "c", "b"}, matched)
+
+	// tokens are bound to the user they were issued for
+	first, err := business.ListMatches(ctx, "a", PaginationParams{PageSize: 1})
+	require.NoError(t, err)
+	_, err = business.ListMatches(ctx, "b", PaginationParams{PageSize: 1, Token: first.NextPaginationToken})
+	assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+}
+
+func TestUnmatch_DissolvesMatchAndHidesBothUsers(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	for _, pair := range [][2]string{{"a", "b"}, {"b", "a"}} {
+		_, err := business.RecordDecision(ctx, pair[0], pair[1], true)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, business.Unmatch(ctx, "a", "b"))
+
+	for _, user := range []string{"a", "b"} {
+		result, err := business.ListMatches(ctx, user, PaginationParams{PageSize: 10})
+		require.NoError(t, err)
+		assert.Empty(t, result.Matches)
+
+		newLikers, err := business.ListNewLikedYouUsers(ctx, user, TimeWindow{}, PaginationParams{PageSize: 10})
+		require.NoError(t, err)
+		assert.Empty(t, newLikers.Likers)
+	}
+
+	// a's like was turned into a pass, b's like is kept
+	count, err := business.CountLikedYouUsers(ctx, "b", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+	count, err = business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// the history tells the unmatch apart from an ordinary pass
+	history, err := business.ListDecisionHistory(ctx, "a", "b", PaginationParams{PageSize: 10, Order: SortNewestFirst})
+	require.NoError(t, err)
+	require.NotEmpty(t, history.Events)
+	assert.False(t, history.Events[0].Liked)
+	assert.True(t, history.Events[0].Unmatched)
+
+	// there is nothing left to unmatch
+	err = business.Unmatch(ctx, "b", "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// a new like from the actor matches them again
+	isMutual, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	assert.True(t, isMutual)
+}
+
+func TestUnmatch_NotMatched(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNotDeleted(mock, "actor1", "actor2")
+	mock.ExpectExec(`DELETE FROM user_match`).
+		WithArgs("actor1", "actor2", "actor2", "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := service.Unmatch(context.Background(), &pb.UnmatchRequest{
+		ActorUserId: "actor1",
+		OtherUserId: "actor2",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonMatchNotFound, errorInfoOf(t, err).Reason)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMatches_MySQLQuery(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM user_match m\s+WHERE m.user_id = \?\s+ORDER BY m.created_at DESC, m.id DESC`).
+		WithArgs("actor1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "matched_user_id", "unix_timestamp"}).
+			AddRow(8, "actor3", 1700001000).
+			AddRow(5, "actor2", 1700000000))
+
+	resp, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{
+		UserId:    "actor1",
+		SortOrder: pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Matches, 2)
+	assert.Equal(t, "actor3", resp.Matches[0].MatchedUserId)
+	assert.Equal(t, uint64(1700000000), resp.Matches[1].UnixTimestamp)
+	assert.NotNil(t, resp.NextPaginationToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package service
+
+import "context"
+
+// Demo user ids, the same as in db/02-data.sql
+const (
+	demoLily      = "11111111-1111-4111-8111-111111111111"
+	demoMatt      = "22222222-2222-4222-8222-222222222222"
+	demoKevin     = "33333333-3333-4333-8333-333333333333"
+	demoAlice     = "44444444-4444-4444-8444-444444444444"
+	demoAnna      = "55555555-5555-4555-8555-555555555555"
+	demoSebastian = "66666666-6666-4666-8666-666666666666"
+)
+
+// SeedDemoData loads the same users, decisions, matches and counters as db/02-data.sql
+// so the test client behaves the same against both backends
+func SeedDemoData(ctx context.Context, store *MemoryStore) error {
+	users := []struct{ id, name string }{
+		{demoLily, "Lily"},
+		{demoMatt, "Matt"},
+		{demoKevin, "Kevin"}, // likes everyone
+		{demoAlice, "Alice"},
+		{demoAnna, "Anna"}, // liked by everyone
+		{demoSebastian, "Sebastian"},
+	}
+	for _, user := range users {
+		store.AddUser(user.id, user.name)
+	}
+
+	decisions := []struct {
+		actorID, recipientID string
+		liked                bool
+	}{
+		{demoLily, demoMatt, true},
+		{demoMatt, demoLily, true},
+		{demoMatt, demoAlice, false},
+		{demoKevin, demoLily, true},
+		{demoKevin, demoMatt, true},
+		{demoKevin, demoAlice, true},
+		{demoAlice, demoLily, true},
+		{demoLily, demoAnna, true},
+		{demoMatt, demoAnna, true},
+		{demoKevin, demoAnna, true},
+		{demoAlice, demoAnna, true},
+		{demoSebastian, demoAnna, true},
+	}
+	err := store.InTx(ctx, func(tx DecisionTx) error {
+		for _, decision := range decisions {
+			if err := tx.UpsertDecision(ctx, decision.actorID, decision.recipientID, decision.liked, false); err != nil {
+				return err
+			}
+			likedBack, err := tx.HasLiked(ctx, decision.recipientID, decision.actorID)
+			if err != nil {
+				return err
+			}
+			event := DecisionEvent{
+				ActorID:     decision.actorID,
+				RecipientID: decision.recipientID,
+				Liked:       decision.liked,
+				Matched:     decision.liked && likedBack,
+			}
+			if err := tx.AppendDecisionEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		// Lily and Matt like each other
+		_, err := tx.CreateMatch(ctx, demoLily, demoMatt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	likeCounts := map[string]uint64{
+		demoLily:      3, // liked by Matt, Kevin, Alice
+		demoMatt:      2, // liked by Lily, Kevin
+		demoKevin:     0, // not liked by anyone
+		demoAlice:     1, // liked by Kevin
+		demoAnna:      5, // liked by everyone
+		demoSebastian: 0, // not liked by anyone
+	}
+	for userID, count := range likeCounts {
+		if err := store.SetLikeCount(userID, count); err != nil {
+			return err
+		}
+	}
+
+	userStats := []UserStats{
+		{UserID: demoLily, NewLikeCount: 2, MatchCount: 1}, // Kevin and Alice are new, Matt is a match
+		{UserID: demoMatt, NewLikeCount: 1, MatchCount: 1}, // Kevin is new, Lily is a match
+		{UserID: demoKevin},
+		{UserID: demoAlice, NewLikeCount: 1, PassCount: 1}, // liked by Kevin, passed by Matt
+		{UserID: demoAnna, NewLikeCount: 5},                // never decided
+		{UserID: demoSebastian},
+	}
+	for _, stats := range userStats {
+		if err := store.SetUserStats(stats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,1466 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process DecisionStore mirroring the semantics of the MySQL queries.
+// It is meant for local development and tests, data is lost when the process exits.
+type MemoryStore struct {
+	mu sync.Mutex
+
+	users     map[string]string // user id -> name
+	decisions map[decisionKey]*memoryDecision
+	events    []DecisionEvent           // decision_event, ordered by id
+	matches   map[matchKey]*memoryMatch // user_match, one entry per side
+	blocks    map[blockKey]*memoryBlock // user_block
+	reports   []*Report                 // user_report, ordered by id
+	moderated map[string]UserModeration // user_moderation
+	deletions []*UserDeletion           // user_deletion, ordered by request time
+	outbox    []memoryOutboxEvent       // outbox_event, ordered by id
+	likeStats map[string]uint64         // user id -> like_count
+	userStats map[string]UserStats      // user_stats, LikeCount is not used
+	keys      map[idempotencyKeyID]*memoryIdempotencyRecord
+	now       func() time.Time
+
+	// auto-increment counters
+	lastID       uint64
+	lastEventID  uint64
+	lastMatchID  uint64
+	lastBlockID  uint64
+	lastReportID uint64
+	lastOutboxID uint64
+
+	// webhook_delivery has its own lock so deliveries are enqueued and called without holding mu,
+	// taken after mu when both are needed
+	webhookMu             sync.Mutex
+	webhookDeliveries     []*memoryWebhookDelivery // ordered by id
+	lastWebhookDeliveryID uint64
+}
+
+type decisionKey struct {
+	actorID     string
+	recipientID string
+}
+
+type memoryDecision struct {
+	id        uint64
+	liked     bool
+	unmatched bool
+	createdAt time.Time
+}
+
+// matchKey is one side of a match, like the user_match table every match is stored once per side
+type matchKey struct {
+	userID        string
+	matchedUserID string
+}
+
+type memoryMatch struct {
+	id        uint64
+	createdAt time.Time
+}
+
+type blockKey struct {
+	blockerID string
+	blockedID string
+}
+
+type memoryBlock struct {
+	id        uint64
+	createdAt time.Time
+}
+
+// idempotencyKeyID is the primary key of idempotency_key, keys are chosen by each actor
+type idempotencyKeyID struct {
+	actorID string
+	key     string
+}
+
+type memoryIdempotencyRecord struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+type memoryOutboxEvent struct {
+	event        OutboxEvent
+	published    time.Time // zero until published
+	claimedUntil time.Time
+}
+
+type memoryWebhookDelivery struct {
+	delivery WebhookDelivery
+	dueAt    time.Time
+	dead     bool
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:     make(map[string]string),
+		decisions: make(map[decisionKey]*memoryDecision),
+		matches:   make(map[matchKey]*memoryMatch),
+		blocks:    make(map[blockKey]*memoryBlock),
+		moderated: make(map[string]UserModeration),
+		likeStats: make(map[string]uint64),
+		userStats: make(map[string]UserStats),
+		keys:      make(map[idempotencyKeyID]*memoryIdempotencyRecord),
+		now:       time.Now,
+	}
+}
+
+// AddUser registers a user, decisions and like_stats can only reference registered users
+func (s *MemoryStore) AddUser(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[id] = name
+}
+
+// SetLikeCount overwrites the like_stats row of a registered user
+func (s *MemoryStore) SetLikeCount(userID string, count uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[userID]; !ok {
+		return fmt.Errorf("error setting like_count: unknown user %s", userID)
+	}
+	s.likeStats[userID] = count
+	return nil
+}
+
+// SetUserStats overwrites the user_stats row of a registered user, stats.LikeCount is ignored
+func (s *MemoryStore) SetUserStats(stats UserStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[stats.UserID]; !ok {
+		return fmt.Errorf("error setting user_stats: unknown user %s", stats.UserID)
+	}
+	stats.LikeCount = 0
+	s.userStats[stats.UserID] = stats
+	return nil
+}
+
+func (s *MemoryStore) ListLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listLikes(recipientID, window, query, func(actorID string) bool { return s.visibleLiker(recipientID, actorID) }), nil
+}
+
+func (s *MemoryStore) ListNewLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// same as the NOT EXISTS sub-query: skip actors already liked back or unmatched by the recipient
+	return s.listLikes(recipientID, window, query, func(actorID string) bool {
+		back, ok := s.decisions[decisionKey{actorID: recipientID, recipientID: actorID}]
+		return s.visibleLiker(recipientID, actorID) && (!ok || (!back.liked && !back.unmatched))
+	}), nil
+}
+
+func (s *MemoryStore) ListLikesReceived(ctx context.Context, recipientID string, query TimeQuery) ([]LikeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listLikes(recipientID, TimeWindow{}, query, func(actorID string) bool { return true }), nil
+}
+
+// visibleLiker reports if the likes of the actor are shown to the recipient, same as the NOT EXISTS sub-queries:
+// the actor is not blocked by the recipient nor hidden by moderation. Must be called with s.mu held
+func (s *MemoryStore) visibleLiker(recipientID, actorID string) bool {
+	if _, blocked := s.blocks[blockKey{blockerID: recipientID, blockedID: actorID}]; blocked {
+		return false
+	}
+	return !s.moderated[actorID].LikesHidden
+}
+
+// listLikes returns likes received by the recipient within the window ordered by (like time, decision id) in the query direction.
+// Must be called with s.mu held. Like times are truncated to seconds, as MySQL TIMESTAMP columns are.
+// It scans every decision, which is fine for the data sizes this store is meant for
+func (s *MemoryStore) listLikes(recipientID string, window TimeWindow, query TimeQuery, keep func(actorID string) bool) []LikeRecord {
+	var records []LikeRecord
+	for key, decision := range s.decisions {
+		if key.recipientID != recipientID || !decision.liked || !keep(key.actorID) {
+			continue
+		}
+		record := LikeRecord{
+			DecisionID: decision.id,
+			Liker: Liker{
+				ActorID:       key.actorID,
+				UnixTimestamp: uint64(decision.createdAt.Unix()),
+			},
+		}
+		if window.contains(record.UnixTimestamp) {
+			records = append(records, record)
+		}
+	}
+
+	return pageByTime(records, cursorOf, query)
+}
+
+func (s *MemoryStore) GetDecisions(ctx context.Context, actorID string, recipientIDs []string) ([]DecisionStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var statuses []DecisionStatus
+	seen := make(map[string]bool, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		decision, ok := s.decisions[decisionKey{actorID: actorID, recipientID: recipientID}]
+		if !ok || seen[recipientID] {
+			continue
+		}
+		seen[recipientID] = true
+		back, ok := s.decisions[decisionKey{actorID: recipientID, recipientID: actorID}]
+		statuses = append(statuses, DecisionStatus{
+			RecipientID:   recipientID,
+			State:         decisionStateOf(true, decision.liked, decision.unmatched),
+			UnixTimestamp: uint64(decision.createdAt.Unix()),
+			Reciprocated:  decision.liked && ok && back.liked,
+		})
+	}
+	return statuses, nil
+}
+
+func (s *MemoryStore) ListMyDecisions(ctx context.Context, actorID string, liked bool, query TimeQuery) ([]DecisionStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var statuses []DecisionStatus
+	for key, decision := range s.decisions {
+		if key.actorID != actorID || decision.liked != liked {
+			continue
+		}
+		back, ok := s.decisions[decisionKey{actorID: key.recipientID, recipientID: actorID}]
+		status := DecisionStatus{
+			ID:            decision.id,
+			RecipientID:   key.recipientID,
+			State:         decisionStateOf(true, decision.liked, decision.unmatched),
+			UnixTimestamp: uint64(decision.createdAt.Unix()),
+			Reciprocated:  decision.liked && ok && back.liked,
+		}
+		statuses = append(statuses, status)
+	}
+
+	return pageByTime(statuses, func(status DecisionStatus) TimeCursor {
+		return TimeCursor{UnixTimestamp: status.UnixTimestamp, ID: status.ID}
+	}, query), nil
+}
+
+func (s *MemoryStore) GetUser(ctx context.Context, userID string) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, ok := s.users[userID]
+	if !ok {
+		return User{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+	// user.created_at is not tracked by this store
+	return User{ID: userID, Name: name}, nil
+}
+
+func (s *MemoryStore) ListDecisionsMade(ctx context.Context, actorID, afterRecipientID string, limit int) ([]Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var decisions []Decision
+	for key, decision := range s.decisions {
+		if key.actorID != actorID || key.recipientID <= afterRecipientID {
+			continue
+		}
+		decisions = append(decisions, Decision{
+			ID:            decision.id,
+			ActorID:       actorID,
+			RecipientID:   key.recipientID,
+			Liked:         decision.liked,
+			Unmatched:     decision.unmatched,
+			UnixTimestamp: uint64(decision.createdAt.Unix()),
+		})
+	}
+
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].RecipientID < decisions[j].RecipientID })
+	if len(decisions) > limit {
+		decisions = decisions[:limit]
+	}
+	return decisions, nil
+}
+
+func (s *MemoryStore) ListMatches(ctx context.Context, userID string, query TimeQuery) ([]Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []Match
+	for key, stored := range s.matches {
+		if key.userID != userID {
+			continue
+		}
+		match := Match{
+			ID:            stored.id,
+			MatchedUserID: key.matchedUserID,
+			UnixTimestamp: uint64(stored.createdAt.Unix()),
+		}
+		matches = append(matches, match)
+	}
+
+	return pageByTime(matches, func(match Match) TimeCursor {
+		return TimeCursor{UnixTimestamp: match.UnixTimestamp, ID: match.ID}
+	}, query), nil
+}
+
+func (s *MemoryStore) ListBlocked(ctx context.Context, blockerID string, query TimeQuery) ([]BlockedUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var blocked []BlockedUser
+	for key, stored := range s.blocks {
+		if key.blockerID != blockerID {
+			continue
+		}
+		user := BlockedUser{
+			ID:            stored.id,
+			BlockedUserID: key.blockedID,
+			UnixTimestamp: uint64(stored.createdAt.Unix()),
+		}
+		blocked = append(blocked, user)
+	}
+
+	return pageByTime(blocked, func(blocked BlockedUser) TimeCursor {
+		return TimeCursor{UnixTimestamp: blocked.UnixTimestamp, ID: blocked.ID}
+	}, query), nil
+}
+
+func (s *MemoryStore) ListReports(ctx context.Context, status ReportStatus, query TimeQuery) ([]Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reports []Report
+	for _, stored := range s.reports {
+		if stored.Status == status {
+			reports = append(reports, *stored)
+		}
+	}
+
+	reports = pageByTime(reports, func(report Report) TimeCursor {
+		return TimeCursor{UnixTimestamp: report.UnixTimestamp, ID: report.ID}
+	}, query)
+	for i := range reports {
+		reports[i].Relationship = s.relationshipOf(reports[i].ReporterID, reports[i].ReportedID)
+	}
+	return reports, nil
+}
+
+// relationshipOf reads the decisions, match and block between the users. Must be called with s.mu held
+func (s *MemoryStore) relationshipOf(reporterID, reportedID string) Relationship {
+	stateOf := func(actorID, recipientID string) DecisionState {
+		decision, ok := s.decisions[decisionKey{actorID: actorID, recipientID: recipientID}]
+		if !ok {
+			return DecisionNone
+		}
+		return decisionStateOf(true, decision.liked, decision.unmatched)
+	}
+	_, matched := s.matches[matchKey{userID: reporterID, matchedUserID: reportedID}]
+	_, blocked := s.blocks[blockKey{blockerID: reporterID, blockedID: reportedID}]
+
+	return Relationship{
+		ReporterDecision: stateOf(reporterID, reportedID),
+		ReportedDecision: stateOf(reportedID, reporterID),
+		Matched:          matched,
+		Blocked:          blocked,
+	}
+}
+
+func (s *MemoryStore) ListDecisionHistory(ctx context.Context, actorID, recipientID string, query EventQuery) ([]DecisionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := func(event DecisionEvent) bool {
+		return event.ActorID == actorID && (recipientID == "" || event.RecipientID == recipientID)
+	}
+	return pageEvents(s.events, query, func(event DecisionEvent) uint64 { return event.ID }, matches), nil
+}
+
+func (s *MemoryStore) ListDecisionEventsReceived(ctx context.Context, recipientID string, query EventQuery) ([]DecisionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	received := func(event DecisionEvent) bool { return event.RecipientID == recipientID }
+	return pageEvents(s.events, query, func(event DecisionEvent) uint64 { return event.ID }, received), nil
+}
+
+func (s *MemoryStore) ListUserReports(ctx context.Context, userID string, filed bool, query EventQuery) ([]Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ofUser := func(report *Report) bool {
+		if filed {
+			return report.ReporterID == userID
+		}
+		return report.ReportedID == userID
+	}
+	stored := pageEvents(s.reports, query, func(report *Report) uint64 { return report.ID }, ofUser)
+
+	reports := make([]Report, 0, len(stored))
+	for _, report := range stored {
+		report := *report
+		report.Relationship = s.relationshipOf(report.ReporterID, report.ReportedID)
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (s *MemoryStore) GetUserModeration(ctx context.Context, userID string) (UserModeration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	moderation, ok := s.moderated[userID]
+	if !ok {
+		return UserModeration{UserID: userID}, nil
+	}
+	return moderation, nil
+}
+
+func (s *MemoryStore) ListIdempotencyRecords(ctx context.Context, actorID, afterKey string, limit int) ([]IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []IdempotencyRecord
+	for id, stored := range s.keys {
+		if id.actorID == actorID && id.key > afterKey {
+			records = append(records, stored.record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func (s *MemoryStore) GetUserDeletion(ctx context.Context, userID string) (UserDeletion, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deletion := s.findUserDeletion(userID); deletion != nil {
+		return *deletion, true, nil
+	}
+	return UserDeletion{}, false, nil
+}
+
+// findUserDeletion returns the deletion of the user or nil, mu must be held
+func (s *MemoryStore) findUserDeletion(userID string) *UserDeletion {
+	for _, deletion := range s.deletions {
+		if deletion.UserID == userID {
+			return deletion
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) LastDecisionEventID(ctx context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].ID, nil
+}
+
+func (s *MemoryStore) ListDecisionEventsAfter(ctx context.Context, afterID uint64, limit int) ([]DecisionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := EventQuery{AfterID: afterID, Limit: limit}
+	events := pageEvents(s.events, query, func(event DecisionEvent) uint64 { return event.ID }, func(DecisionEvent) bool { return true })
+	for i := range events {
+		events[i].ActorLikesHidden = s.moderated[events[i].ActorID].LikesHidden
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) ListDecisionEventsByID(ctx context.Context, ids []uint64) ([]DecisionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	var events []DecisionEvent
+	for _, event := range s.events {
+		if wanted[event.ID] {
+			event.ActorLikesHidden = s.moderated[event.ActorID].LikesHidden
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) ListLikeEvents(ctx context.Context, userID string, afterID, untilID uint64, limit int) ([]DecisionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notified := func(event DecisionEvent) bool {
+		return event.ID <= untilID &&
+			((event.RecipientID == userID && event.Liked && !s.moderated[event.ActorID].LikesHidden) ||
+				(event.ActorID == userID && event.Matched))
+	}
+	query := EventQuery{AfterID: afterID, Limit: limit}
+	return pageEvents(s.events, query, func(event DecisionEvent) uint64 { return event.ID }, notified), nil
+}
+
+// pageEvents returns a page of an append-only log ordered by id, keeping the items matching keep
+func pageEvents[T any](log []T, query EventQuery, idOf func(T) uint64, keep func(T) bool) []T {
+	var page []T
+	for i := range log {
+		item := log[i]
+		if query.Descending {
+			item = log[len(log)-1-i]
+		}
+
+		id := idOf(item)
+		if query.AfterID != 0 && ((query.Descending && id >= query.AfterID) || (!query.Descending && id <= query.AfterID)) {
+			continue
+		}
+		if !keep(item) {
+			continue
+		}
+
+		page = append(page, item)
+		if len(page) == query.Limit {
+			break
+		}
+	}
+	return page
+}
+
+// pageByTime returns the items positioned after query.After, in (time, id) order in the query direction,
+// up to query.Limit of them. key gives the position of an item
+func pageByTime[T any](items []T, key func(T) TimeCursor, query TimeQuery) []T {
+	// before reports if a comes first in the query direction
+	before := timeCursorLess
+	if query.Descending {
+		before = func(a, b TimeCursor) bool { return timeCursorLess(b, a) }
+	}
+
+	var page []T
+	for _, item := range items {
+		if query.After == (TimeCursor{}) || before(query.After, key(item)) {
+			page = append(page, item)
+		}
+	}
+
+	sort.Slice(page, func(i, j int) bool { return before(key(page[i]), key(page[j])) })
+	if len(page) > query.Limit {
+		page = page[:query.Limit]
+	}
+	return page
+}
+
+func cursorOf(record LikeRecord) TimeCursor {
+	return TimeCursor{UnixTimestamp: record.UnixTimestamp, ID: record.DecisionID}
+}
+
+// timeCursorLess compares two positions in (timestamp, id) order
+func timeCursorLess(a, b TimeCursor) bool {
+	if a.UnixTimestamp != b.UnixTimestamp {
+		return a.UnixTimestamp < b.UnixTimestamp
+	}
+	return a.ID < b.ID
+}
+
+func (s *MemoryStore) CountLikedYou(ctx context.Context, recipientID string, window TimeWindow, limit uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// a missing like_stats row means no likes yet, only unknown users are an error
+	if _, ok := s.users[recipientID]; !ok {
+		return 0, newUserNotFoundError("user not found", map[string]string{"user_id": recipientID}, nil)
+	}
+	if window == (TimeWindow{}) {
+		return s.likeStats[recipientID], nil
+	}
+
+	var count uint64
+	for key, decision := range s.decisions {
+		if key.recipientID == recipientID && decision.liked && s.visibleLiker(recipientID, key.actorID) &&
+			window.contains(uint64(decision.createdAt.Unix())) {
+			count++
+		}
+		if limit > 0 && count == limit {
+			break
+		}
+	}
+	return count, nil
+}
+
+func (s *MemoryStore) GetUserStats(ctx context.Context, userID string) (UserStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return UserStats{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+	stats := s.userStats[userID]
+	stats.UserID = userID
+	stats.LikeCount = s.likeStats[userID]
+	return stats, nil
+}
+
+func (s *MemoryStore) CheckLikeCounts(ctx context.Context, afterUserID string, limit int) ([]LikeCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var userIDs []string
+	for userID := range s.users {
+		if userID > afterUserID && s.findUserDeletion(userID) == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Strings(userIDs)
+	if len(userIDs) > limit {
+		userIDs = userIDs[:limit]
+	}
+
+	counts := make([]LikeCount, 0, len(userIDs))
+	for _, userID := range userIDs {
+		counts = append(counts, s.likeCountOf(userID))
+	}
+	return counts, nil
+}
+
+func (s *MemoryStore) RepairLikeCount(ctx context.Context, userID string) (LikeCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return LikeCount{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+	count := s.likeCountOf(userID)
+	s.likeStats[userID] = count.Actual
+	return count, nil
+}
+
+// likeCountOf recomputes the like count of the user like the likeCountSubquery of MySQL, mu must be held
+func (s *MemoryStore) likeCountOf(userID string) LikeCount {
+	count := LikeCount{UserID: userID, Cached: s.likeStats[userID]}
+	for key, decision := range s.decisions {
+		if key.recipientID == userID && decision.liked && s.visibleLiker(userID, key.actorID) {
+			count.Actual++
+		}
+	}
+	return count
+}
+
+// InTx serializes transactions with the store lock. Every change is recorded in an undo log
+// that is replayed backwards if fn fails, so a failed transaction leaves no partial writes
+func (s *MemoryStore) InTx(ctx context.Context, fn func(tx DecisionTx) error) error {
+	return s.inTx(ctx, func(tx *memoryTx) error { return fn(tx) })
+}
+
+func (s *MemoryStore) inTx(ctx context.Context, fn func(tx *memoryTx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &memoryTx{store: s}
+	if err := fn(tx); err != nil {
+		tx.rollback()
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		tx.rollback()
+		return err
+	}
+	return nil
+}
+
+// memoryTx implements DecisionTx, the store lock is held for its whole lifetime
+type memoryTx struct {
+	store *MemoryStore
+	undo  []func()
+}
+
+func (t *memoryTx) rollback() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.undo = nil
+}
+
+// checkUser emulates the foreign key constraints on the user table
+func (t *memoryTx) checkUser(userID string) error {
+	if _, ok := t.store.users[userID]; !ok {
+		return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+	return nil
+}
+
+func (t *memoryTx) GetDecision(ctx context.Context, actorID, recipientID string) (DecisionState, error) {
+	decision, ok := t.store.decisions[decisionKey{actorID: actorID, recipientID: recipientID}]
+	if !ok {
+		return DecisionNone, nil
+	}
+	return decisionStateOf(true, decision.liked, decision.unmatched), nil
+}
+
+func (t *memoryTx) UpsertDecision(ctx context.Context, actorID, recipientID string, liked, unmatched bool) error {
+	for _, userID := range []string{actorID, recipientID} {
+		if err := t.checkUser(userID); err != nil {
+			return fmt.Errorf("error inserting decision (%s -> %s): %w", actorID, recipientID, err)
+		}
+	}
+
+	key := decisionKey{actorID: actorID, recipientID: recipientID}
+	if previous, ok := t.store.decisions[key]; ok {
+		// ON DUPLICATE KEY UPDATE keeps the id and resets created_at
+		saved := *previous
+		previous.liked = liked
+		previous.unmatched = unmatched
+		previous.createdAt = t.store.now()
+		t.undo = append(t.undo, func() { *previous = saved })
+		return nil
+	}
+
+	t.store.lastID++
+	t.store.decisions[key] = &memoryDecision{
+		id:        t.store.lastID,
+		liked:     liked,
+		unmatched: unmatched,
+		createdAt: t.store.now(),
+	}
+	t.undo = append(t.undo, func() { delete(t.store.decisions, key) })
+	return nil
+}
+
+func (t *memoryTx) AppendDecisionEvent(ctx context.Context, event DecisionEvent) error {
+	for _, userID := range []string{event.ActorID, event.RecipientID} {
+		if err := t.checkUser(userID); err != nil {
+			return fmt.Errorf("error appending decision event (%s -> %s): %w", event.ActorID, event.RecipientID, err)
+		}
+	}
+
+	t.store.lastEventID++
+	event.ID = t.store.lastEventID
+	event.UnixTimestamp = uint64(t.store.now().Unix())
+	t.store.events = append(t.store.events, event)
+	t.undo = append(t.undo, func() { t.store.events = t.store.events[:len(t.store.events)-1] })
+	return nil
+}
+
+func (t *memoryTx) AppendOutboxEvent(ctx context.Context, event OutboxEvent) error {
+	t.store.lastOutboxID++
+	event.ID = t.store.lastOutboxID
+	event.UnixTimestamp = uint64(t.store.now().Unix())
+	t.store.outbox = append(t.store.outbox, memoryOutboxEvent{event: event})
+	t.undo = append(t.undo, func() { t.store.outbox = t.store.outbox[:len(t.store.outbox)-1] })
+	return nil
+}
+
+func (t *memoryTx) IncrementLikeCount(ctx context.Context, userID string) error {
+	if err := t.checkUser(userID); err != nil {
+		return fmt.Errorf("error incrementing like_count: %w", err)
+	}
+
+	previous, existed := t.store.likeStats[userID]
+	t.store.likeStats[userID] = previous + 1
+	t.undo = append(t.undo, func() {
+		if existed {
+			t.store.likeStats[userID] = previous
+		} else {
+			delete(t.store.likeStats, userID)
+		}
+	})
+	return nil
+}
+
+func (t *memoryTx) DecrementLikeCount(ctx context.Context, userID string) error {
+	previous, ok := t.store.likeStats[userID]
+	if !ok || previous == 0 {
+		return nil
+	}
+
+	t.store.likeStats[userID] = previous - 1
+	t.undo = append(t.undo, func() { t.store.likeStats[userID] = previous })
+	return nil
+}
+
+func (t *memoryTx) ChangeUserStats(ctx context.Context, userID string, change UserStatsChange) error {
+	if err := t.checkUser(userID); err != nil {
+		return fmt.Errorf("error changing user_stats: %w", err)
+	}
+
+	previous, existed := t.store.userStats[userID]
+	stats := previous
+	stats.UserID = userID
+	stats.NewLikeCount = addClamped(stats.NewLikeCount, change.NewLikes)
+	stats.MatchCount = addClamped(stats.MatchCount, change.Matches)
+	stats.PassCount = addClamped(stats.PassCount, change.Passes)
+	t.store.userStats[userID] = stats
+	t.undo = append(t.undo, func() {
+		if existed {
+			t.store.userStats[userID] = previous
+		} else {
+			delete(t.store.userStats, userID)
+		}
+	})
+	return nil
+}
+
+// addClamped adds delta to count, never going below zero like GREATEST(count + delta, 0)
+func addClamped(count uint64, delta int) uint64 {
+	if delta < 0 && uint64(-delta) > count {
+		return 0
+	}
+	return uint64(int64(count) + int64(delta))
+}
+
+func (t *memoryTx) HasLiked(ctx context.Context, actorID, recipientID string) (bool, error) {
+	decision, ok := t.store.decisions[decisionKey{actorID: actorID, recipientID: recipientID}]
+	return ok && decision.liked, nil
+}
+
+func (t *memoryTx) CreateMatch(ctx context.Context, userID, otherUserID string) (bool, error) {
+	created := false
+	for _, key := range []matchKey{{userID, otherUserID}, {otherUserID, userID}} {
+		if _, ok := t.store.matches[key]; ok {
+			// ON DUPLICATE KEY UPDATE keeps the original match
+			continue
+		}
+		if err := t.checkUser(key.userID); err != nil {
+			return false, fmt.Errorf("error creating match (%s <-> %s): %w", userID, otherUserID, err)
+		}
+		created = true
+
+		t.store.lastMatchID++
+		t.store.matches[key] = &memoryMatch{
+			id:        t.store.lastMatchID,
+			createdAt: t.store.now(),
+		}
+		t.undo = append(t.undo, func() { delete(t.store.matches, key) })
+	}
+	return created, nil
+}
+
+func (t *memoryTx) DeleteMatch(ctx context.Context, userID, otherUserID string) (bool, error) {
+	found := false
+	for _, key := range []matchKey{{userID, otherUserID}, {otherUserID, userID}} {
+		match, ok := t.store.matches[key]
+		if !ok {
+			continue
+		}
+		found = true
+		delete(t.store.matches, key)
+		t.undo = append(t.undo, func() { t.store.matches[key] = match })
+	}
+	return found, nil
+}
+
+func (t *memoryTx) IsBlocked(ctx context.Context, userID, otherUserID string) (bool, error) {
+	for _, key := range []blockKey{{userID, otherUserID}, {otherUserID, userID}} {
+		if _, ok := t.store.blocks[key]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *memoryTx) CreateBlock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	key := blockKey{blockerID: blockerID, blockedID: blockedID}
+	if _, ok := t.store.blocks[key]; ok {
+		// ON DUPLICATE KEY UPDATE keeps the original block
+		return false, nil
+	}
+	for _, userID := range []string{blockerID, blockedID} {
+		if err := t.checkUser(userID); err != nil {
+			return false, fmt.Errorf("error creating block (%s -> %s): %w", blockerID, blockedID, err)
+		}
+	}
+
+	t.store.lastBlockID++
+	t.store.blocks[key] = &memoryBlock{
+		id:        t.store.lastBlockID,
+		createdAt: t.store.now(),
+	}
+	t.undo = append(t.undo, func() { delete(t.store.blocks, key) })
+	return true, nil
+}
+
+func (t *memoryTx) DeleteBlock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	key := blockKey{blockerID: blockerID, blockedID: blockedID}
+	block, ok := t.store.blocks[key]
+	if !ok {
+		return false, nil
+	}
+	delete(t.store.blocks, key)
+	t.undo = append(t.undo, func() { t.store.blocks[key] = block })
+	return true, nil
+}
+
+func (t *memoryTx) GetUserModeration(ctx context.Context, userID string) (UserModeration, error) {
+	moderation, ok := t.store.moderated[userID]
+	if !ok {
+		return UserModeration{UserID: userID}, nil
+	}
+	return moderation, nil
+}
+
+func (t *memoryTx) SaveUserModeration(ctx context.Context, moderation UserModeration) error {
+	if err := t.checkUser(moderation.UserID); err != nil {
+		return fmt.Errorf("error saving moderation: %w", err)
+	}
+
+	previous, existed := t.store.moderated[moderation.UserID]
+	t.store.moderated[moderation.UserID] = moderation
+	t.undo = append(t.undo, func() {
+		if existed {
+			t.store.moderated[moderation.UserID] = previous
+		} else {
+			delete(t.store.moderated, moderation.UserID)
+		}
+	})
+	return nil
+}
+
+func (t *memoryTx) UncountLikes(ctx context.Context, actorID string) error {
+	for key, decision := range t.store.decisions {
+		if key.actorID != actorID || !decision.liked {
+			continue
+		}
+		if _, blocked := t.store.blocks[blockKey{blockerID: key.recipientID, blockedID: actorID}]; blocked {
+			continue
+		}
+		if err := t.DecrementLikeCount(ctx, key.recipientID); err != nil {
+			return err
+		}
+		back, err := t.GetDecision(ctx, key.recipientID, actorID)
+		if err != nil {
+			return err
+		}
+		if answersLike(back) || t.store.userStats[key.recipientID].NewLikeCount == 0 {
+			continue
+		}
+		if err := t.ChangeUserStats(ctx, key.recipientID, UserStatsChange{NewLikes: -1}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *memoryTx) CreateReport(ctx context.Context, report Report) (uint64, error) {
+	for _, userID := range []string{report.ReporterID, report.ReportedID} {
+		if err := t.checkUser(userID); err != nil {
+			return 0, fmt.Errorf("error creating report (%s -> %s): %w", report.ReporterID, report.ReportedID, err)
+		}
+	}
+
+	t.store.lastReportID++
+	report.ID = t.store.lastReportID
+	report.UnixTimestamp = uint64(t.store.now().Unix())
+	report.Relationship = Relationship{}
+	t.store.reports = append(t.store.reports, &report)
+	t.undo = append(t.undo, func() { t.store.reports = t.store.reports[:len(t.store.reports)-1] })
+	return report.ID, nil
+}
+
+func (t *memoryTx) GetReport(ctx context.Context, reportID uint64) (Report, bool, error) {
+	for _, stored := range t.store.reports {
+		if stored.ID == reportID {
+			report := *stored
+			report.Relationship = t.store.relationshipOf(report.ReporterID, report.ReportedID)
+			return report, true, nil
+		}
+	}
+	return Report{}, false, nil
+}
+
+func (t *memoryTx) UpdateReport(ctx context.Context, report Report) error {
+	for _, stored := range t.store.reports {
+		if stored.ID != report.ID {
+			continue
+		}
+		saved := *stored
+		stored.Status = report.Status
+		stored.ModeratorID = report.ModeratorID
+		stored.Outcome = report.Outcome
+		stored.ResolutionNote = report.ResolutionNote
+		t.undo = append(t.undo, func() { *stored = saved })
+		return nil
+	}
+	return fmt.Errorf("error updating report %d: not found", report.ID)
+}
+
+func (t *memoryTx) IsUserDeleted(ctx context.Context, userID string) (bool, error) {
+	return t.store.findUserDeletion(userID) != nil, nil
+}
+
+func (t *memoryTx) CreateUserDeletion(ctx context.Context, userID string) (UserDeletion, error) {
+	if deletion := t.store.findUserDeletion(userID); deletion != nil {
+		return *deletion, nil
+	}
+	if err := t.checkUser(userID); err != nil {
+		return UserDeletion{}, fmt.Errorf("error creating user deletion: %w", err)
+	}
+
+	deletion := &UserDeletion{
+		UserID:                 userID,
+		Step:                   DeletionDecisionsMade,
+		RequestedUnixTimestamp: uint64(t.store.now().Unix()),
+	}
+	t.store.deletions = append(t.store.deletions, deletion)
+	t.undo = append(t.undo, func() { t.store.deletions = t.store.deletions[:len(t.store.deletions)-1] })
+	return *deletion, nil
+}
+
+func (t *memoryTx) LockPendingUserDeletion(ctx context.Context) (UserDeletion, bool, error) {
+	for _, deletion := range t.store.deletions {
+		if !deletion.Completed() {
+			return *deletion, true, nil
+		}
+	}
+	return UserDeletion{}, false, nil
+}
+
+func (t *memoryTx) SaveUserDeletion(ctx context.Context, deletion UserDeletion) error {
+	stored := t.store.findUserDeletion(deletion.UserID)
+	if stored == nil {
+		return fmt.Errorf("error saving deletion of %s: not found", deletion.UserID)
+	}
+
+	saved := *stored
+	deletion.RequestedUnixTimestamp = saved.RequestedUnixTimestamp
+	deletion.CompletedUnixTimestamp = 0
+	if deletion.Completed() {
+		deletion.CompletedUnixTimestamp = uint64(t.store.now().Unix())
+	}
+	*stored = deletion
+	t.undo = append(t.undo, func() { *stored = saved })
+	return nil
+}
+
+func (t *memoryTx) DeleteActorDecisions(ctx context.Context, actorID string, limit int) ([]DecisionInput, error) {
+	var decisions []DecisionInput
+	for key, decision := range t.store.decisions {
+		if key.actorID == actorID {
+			decisions = append(decisions, DecisionInput{RecipientID: key.recipientID, Liked: decision.liked})
+		}
+	}
+	// same as ORDER BY recipient_user_id LIMIT ?
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].RecipientID < decisions[j].RecipientID })
+	if len(decisions) > limit {
+		decisions = decisions[:limit]
+	}
+
+	for _, decision := range decisions {
+		key := decisionKey{actorID: actorID, recipientID: decision.RecipientID}
+		stored := t.store.decisions[key]
+		delete(t.store.decisions, key)
+		t.undo = append(t.undo, func() { t.store.decisions[key] = stored })
+	}
+	return decisions, nil
+}
+
+func (t *memoryTx) DeleteUserMatches(ctx context.Context, userID string, limit int) ([]string, error) {
+	var matched []string
+	for key := range t.store.matches {
+		if key.userID == userID {
+			matched = append(matched, key.matchedUserID)
+		}
+	}
+	sort.Strings(matched)
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	for _, matchedUserID := range matched {
+		if _, err := t.DeleteMatch(ctx, userID, matchedUserID); err != nil {
+			return nil, err
+		}
+	}
+	return matched, nil
+}
+
+func (t *memoryTx) DeleteUserRows(ctx context.Context, userID string, step DeletionStep, limit int) (int, error) {
+	deleted := 0
+	switch step {
+	case DeletionDecisionsReceived:
+		for key, decision := range t.store.decisions {
+			if deleted == limit {
+				break
+			}
+			if key.recipientID == userID {
+				deleted++
+				delete(t.store.decisions, key)
+				t.undo = append(t.undo, func() { t.store.decisions[key] = decision })
+			}
+		}
+	case DeletionDecisionEvents:
+		saved := t.store.events
+		kept := make([]DecisionEvent, 0, len(saved))
+		for _, event := range saved {
+			if deleted < limit && (event.ActorID == userID || event.RecipientID == userID) {
+				deleted++
+				continue
+			}
+			kept = append(kept, event)
+		}
+		t.store.events = kept
+		t.undo = append(t.undo, func() { t.store.events = saved })
+	case DeletionBlocks:
+		for key, block := range t.store.blocks {
+			if deleted == limit {
+				break
+			}
+			if key.blockerID == userID || key.blockedID == userID {
+				deleted++
+				delete(t.store.blocks, key)
+				t.undo = append(t.undo, func() { t.store.blocks[key] = block })
+			}
+		}
+	case DeletionReports:
+		saved := t.store.reports
+		kept := make([]*Report, 0, len(saved))
+		for _, report := range saved {
+			if deleted < limit && (report.ReporterID == userID || report.ReportedID == userID) {
+				deleted++
+				continue
+			}
+			kept = append(kept, report)
+		}
+		t.store.reports = kept
+		t.undo = append(t.undo, func() { t.store.reports = saved })
+	case DeletionOutbox:
+		for id, stored := range t.store.keys {
+			if deleted == limit {
+				break
+			}
+			if stored.record.RecipientID == userID {
+				deleted++
+				delete(t.store.keys, id)
+				t.undo = append(t.undo, func() { t.store.keys[id] = stored })
+			}
+		}
+
+		saved := t.store.outbox
+		kept := make([]memoryOutboxEvent, 0, len(saved))
+		for _, stored := range saved {
+			if deleted < limit && stored.event.mentionsUser(userID) {
+				deleted++
+				continue
+			}
+			kept = append(kept, stored)
+		}
+		t.store.outbox = kept
+		t.undo = append(t.undo, func() { t.store.outbox = saved })
+
+		deleted += t.store.deleteWebhookDeliveries(userID, limit-deleted, &t.undo)
+	default:
+		return 0, fmt.Errorf("error deleting rows of %s: unsupported step %s", userID, step)
+	}
+	return deleted, nil
+}
+
+func (t *memoryTx) DeleteUserAccount(ctx context.Context, userID string) error {
+	name, ok := t.store.users[userID]
+	if !ok {
+		return nil
+	}
+
+	if count, ok := t.store.likeStats[userID]; ok {
+		delete(t.store.likeStats, userID)
+		t.undo = append(t.undo, func() { t.store.likeStats[userID] = count })
+	}
+	if stats, ok := t.store.userStats[userID]; ok {
+		delete(t.store.userStats, userID)
+		t.undo = append(t.undo, func() { t.store.userStats[userID] = stats })
+	}
+	if moderation, ok := t.store.moderated[userID]; ok {
+		delete(t.store.moderated, userID)
+		t.undo = append(t.undo, func() { t.store.moderated[userID] = moderation })
+	}
+	for id, record := range t.store.keys {
+		if id.actorID == userID {
+			delete(t.store.keys, id)
+			t.undo = append(t.undo, func() { t.store.keys[id] = record })
+		}
+	}
+	delete(t.store.users, userID)
+	t.undo = append(t.undo, func() { t.store.users[userID] = name })
+	return nil
+}
+
+func (t *memoryTx) GetIdempotencyRecord(ctx context.Context, actorID, key string) (IdempotencyRecord, bool, error) {
+	stored, ok := t.store.keys[idempotencyKeyID{actorID: actorID, key: key}]
+	if !ok || !stored.expiresAt.After(t.store.now()) {
+		return IdempotencyRecord{}, false, nil
+	}
+	return stored.record, true, nil
+}
+
+func (t *memoryTx) SaveIdempotencyRecord(ctx context.Context, record IdempotencyRecord, ttl time.Duration) error {
+	if err := t.checkUser(record.ActorID); err != nil {
+		return fmt.Errorf("error saving idempotency key: %w", err)
+	}
+
+	id := idempotencyKeyID{actorID: record.ActorID, key: record.Key}
+	previous, existed := t.store.keys[id]
+	t.store.keys[id] = &memoryIdempotencyRecord{record: record, expiresAt: t.store.now().Add(ttl)}
+	t.undo = append(t.undo, func() {
+		if existed {
+			t.store.keys[id] = previous
+		} else {
+			delete(t.store.keys, id)
+		}
+	})
+	return nil
+}
+
+// ClaimOutboxEvents only holds the store lock while the lease is written, the batch is published without it
+func (s *MemoryStore) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var claimed []*memoryOutboxEvent
+	for i := range s.outbox {
+		stored := &s.outbox[i]
+		if len(claimed) == limit {
+			break
+		}
+		if !stored.published.IsZero() {
+			continue
+		}
+		// another relay is publishing the first events, wait for its turn to end
+		if stored.claimedUntil.After(now) {
+			return nil, nil
+		}
+		claimed = append(claimed, stored)
+	}
+
+	events := make([]OutboxEvent, 0, len(claimed))
+	for _, stored := range claimed {
+		stored.claimedUntil = now.Add(lease)
+		events = append(events, stored.event)
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) MarkOutboxEventsPublished(ctx context.Context, ids []uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updateOutboxEvents(ids, func(stored *memoryOutboxEvent) {
+		stored.published = s.now()
+		stored.claimedUntil = time.Time{}
+	})
+	return nil
+}
+
+func (s *MemoryStore) ReleaseOutboxEvents(ctx context.Context, ids []uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updateOutboxEvents(ids, func(stored *memoryOutboxEvent) {
+		if stored.published.IsZero() {
+			stored.claimedUntil = time.Time{}
+		}
+	})
+	return nil
+}
+
+// updateOutboxEvents applies update to the events with the given ids. Must be called with s.mu held
+func (s *MemoryStore) updateOutboxEvents(ids []uint64, update func(stored *memoryOutboxEvent)) {
+	selected := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+	for i := range s.outbox {
+		if selected[s.outbox[i].event.ID] {
+			update(&s.outbox[i])
+		}
+	}
+}
+
+func (s *MemoryStore) DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldest := s.now().Add(-retention)
+	kept := s.outbox[:0]
+	deleted := 0
+	for _, stored := range s.outbox {
+		if deleted < limit && !stored.published.IsZero() && stored.published.Before(oldest) {
+			deleted++
+			continue
+		}
+		kept = append(kept, stored)
+	}
+	s.outbox = kept
+	return deleted, nil
+}
+
+func (s *MemoryStore) DeleteExpiredIdempotencyKeys(ctx context.Context, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for id, stored := range s.keys {
+		if deleted == limit {
+			break
+		}
+		if !stored.expiresAt.After(s.now()) {
+			delete(s.keys, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *MemoryStore) EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	now := s.now()
+	for _, delivery := range deliveries {
+		if s.findWebhookDelivery(func(stored WebhookDelivery) bool {
+			return stored.EventID == delivery.EventID && stored.Endpoint == delivery.Endpoint
+		}) != nil {
+			continue
+		}
+
+		s.lastWebhookDeliveryID++
+		delivery.ID = s.lastWebhookDeliveryID
+		delivery.UnixTimestamp = uint64(now.Unix())
+		s.webhookDeliveries = append(s.webhookDeliveries, &memoryWebhookDelivery{delivery: delivery, dueAt: now})
+	}
+	return nil
+}
+
+func (s *MemoryStore) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	now := s.now()
+	var due []*memoryWebhookDelivery
+	for _, stored := range s.webhookDeliveries {
+		if !stored.dead && !stored.dueAt.After(now) {
+			due = append(due, stored)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].dueAt.Before(due[j].dueAt) })
+
+	deliveries := make([]WebhookDelivery, 0, min(limit, len(due)))
+	for _, stored := range due[:min(limit, len(due))] {
+		stored.dueAt = now.Add(lease)
+		deliveries = append(deliveries, stored.delivery)
+	}
+	return deliveries, nil
+}
+
+func (s *MemoryStore) CompleteWebhookDelivery(ctx context.Context, id uint64) error {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	for i, stored := range s.webhookDeliveries {
+		if stored.delivery.ID == id {
+			s.webhookDeliveries = append(s.webhookDeliveries[:i], s.webhookDeliveries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) FailWebhookDelivery(ctx context.Context, id uint64, lastError string, retryIn time.Duration, dead bool) error {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	stored := s.findWebhookDelivery(func(delivery WebhookDelivery) bool { return delivery.ID == id })
+	if stored == nil {
+		return nil
+	}
+	stored.delivery.Attempts++
+	stored.delivery.LastError = lastError
+	stored.dueAt = s.now().Add(retryIn)
+	stored.dead = dead
+	return nil
+}
+
+func (s *MemoryStore) ListWebhookDeadLetters(ctx context.Context, afterID uint64, limit int) ([]WebhookDelivery, error) {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	var deliveries []WebhookDelivery
+	for _, stored := range s.webhookDeliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if stored.dead && stored.delivery.ID > afterID {
+			deliveries = append(deliveries, stored.delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (s *MemoryStore) RequeueWebhookDeadLetters(ctx context.Context, ids []uint64) (int, error) {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	selected := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	requeued := 0
+	for _, stored := range s.webhookDeliveries {
+		if !stored.dead || (len(ids) > 0 && !selected[stored.delivery.ID]) {
+			continue
+		}
+		stored.dead = false
+		stored.delivery.Attempts = 0
+		stored.dueAt = s.now()
+		requeued++
+	}
+	return requeued, nil
+}
+
+// deleteWebhookDeliveries deletes up to limit deliveries of events mentioning the user, dead letters included.
+// Deliveries change without mu held, so the undo appended puts back the deleted ones rather than the whole slice
+func (s *MemoryStore) deleteWebhookDeliveries(userID string, limit int, undo *[]func()) int {
+	s.webhookMu.Lock()
+	defer s.webhookMu.Unlock()
+
+	var deleted []*memoryWebhookDelivery
+	kept := make([]*memoryWebhookDelivery, 0, len(s.webhookDeliveries))
+	for _, stored := range s.webhookDeliveries {
+		var event OutboxEvent
+		if len(deleted) < limit && json.Unmarshal(stored.delivery.Body, &event) == nil && event.mentionsUser(userID) {
+			deleted = append(deleted, stored)
+			continue
+		}
+		kept = append(kept, stored)
+	}
+	s.webhookDeliveries = kept
+	*undo = append(*undo, func() {
+		s.webhookMu.Lock()
+		defer s.webhookMu.Unlock()
+		s.webhookDeliveries = append(s.webhookDeliveries, deleted...)
+		sort.Slice(s.webhookDeliveries, func(i, j int) bool {
+			return s.webhookDeliveries[i].delivery.ID < s.webhookDeliveries[j].delivery.ID
+		})
+	})
+	return len(deleted)
+}
+
+// findWebhookDelivery returns the first delivery matching, must be called with webhookMu held
+func (s *MemoryStore) findWebhookDelivery(match func(delivery WebhookDelivery) bool) *memoryWebhookDelivery {
+	for _, stored := range s.webhookDeliveries {
+		if match(stored.delivery) {
+			return stored
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"strconv"
+)
+
+// ReportReason is why a user was reported
+type ReportReason string
+
+const (
+	ReportSpam                 ReportReason = "SPAM"
+	ReportHarassment           ReportReason = "HARASSMENT"
+	ReportInappropriateContent ReportReason = "INAPPROPRIATE_CONTENT"
+	ReportFakeProfile          ReportReason = "FAKE_PROFILE"
+	ReportUnderage             ReportReason = "UNDERAGE"
+	ReportOther                ReportReason = "OTHER"
+)
+
+// ReportStatus is the step of a report in the moderation queue: open, claimed by a moderator, then resolved
+type ReportStatus string
+
+const (
+	ReportOpen     ReportStatus = "OPEN"
+	ReportClaimed  ReportStatus = "CLAIMED"
+	ReportResolved ReportStatus = "RESOLVED"
+)
+
+// ReportOutcome is the sanction applied to the reported user when the report is resolved
+type ReportOutcome string
+
+const (
+	OutcomeDismissed   ReportOutcome = "DISMISSED"    // no sanction
+	OutcomeWarning     ReportOutcome = "WARNING"      // counted in the user's warnings
+	OutcomeLikesHidden ReportOutcome = "LIKES_HIDDEN" // the user's likes are hidden from everyone
+	OutcomeBanned      ReportOutcome = "BANNED"       // likes hidden and decisions rejected
+)
+
+// DecisionState is the current decision of a user on another one
+type DecisionState int
+
+const (
+	DecisionNone DecisionState = iota
+	DecisionLiked
+	DecisionPassed
+	DecisionUnmatched // pass recorded by Unmatch or BlockUser
+)
+
+// decisionStateOf returns the state of a decision read from the store
+func decisionStateOf(found, liked, unmatched bool) DecisionState {
+	switch {
+	case !found:
+		return DecisionNone
+	case liked:
+		return DecisionLiked
+	case unmatched:
+		return DecisionUnmatched
+	default:
+		return DecisionPassed
+	}
+}
+
+// Relationship is the social context of a report between the reporter and the reported user
+type Relationship struct {
+	ReporterDecision DecisionState // decision of the reporter on the reported user
+	ReportedDecision DecisionState // decision of the reported user on the reporter
+	Matched          bool
+	Blocked          bool // the reporter blocked the reported user
+}
+
+// Report is a user report in the moderation queue
+type Report struct {
+	ID             uint64
+	ReporterID     string
+	ReportedID     string
+	Reason         ReportReason
+	Details        string
+	Status         ReportStatus
+	ModeratorID    string        // moderator who claimed the report
+	Outcome        ReportOutcome // set once resolved
+	ResolutionNote string
+	UnixTimestamp  uint64       // report time
+	Relationship   Relationship // read along with the report, so it reflects the current decisions
+}
+
+type ListReportsResult struct {
+	Reports             []Report
+	NextPaginationToken string
+}
+
+const listReportsEndpoint = "ListReports"
+
+// UserModeration is the moderation state of a user, the zero value stands for a user never sanctioned
+type UserModeration struct {
+	UserID      string
+	Warnings    uint32
+	LikesHidden bool // likes are left out of everyone's ListLikedYou, ListNewLikedYou, CountLikedYou and WatchLikes
+	Banned      bool // decisions are rejected, a ban also hides the likes
+}
+
+// likeCountChange returns how the like_stats follow the user's likes: not at all once they are hidden
+func (m UserModeration) likeCountChange(change likeCountChange) likeCountChange {
+	if m.LikesHidden {
+		return skipLikeCountChange
+	}
+	return change
+}
+
+// likesCounted reports if the likes of the actor are counted in like_stats and user_stats
+func likesCounted(ctx context.Context, tx DecisionTx, actorID string) (bool, error) {
+	moderation, err := tx.GetUserModeration(ctx, actorID)
+	if err != nil {
+		return false, err
+	}
+	return !moderation.LikesHidden, nil
+}
+
+// newUserBannedError reports a decision of a banned actor
+func newUserBannedError(actorID string) error {
+	return &DomainError{
+		Kind:     ErrPermissionDenied,
+		Reason:   ReasonUserBanned,
+		Message:  "user is banned",
+		Metadata: map[string]string{"actor_user_id": actorID},
+	}
+}
+
+// newReportError reports a report that can't go through the requested step
+func newReportError(kind error, reason, message string, reportID uint64) error {
+	return &DomainError{
+		Kind:     kind,
+		Reason:   reason,
+		Message:  message,
+		Metadata: map[string]string{"report_id": strconv.FormatUint(reportID, 10)},
+	}
+}
+
+// ReportUser queues a report of the reporter on the reported user and returns its id
+func (b *ExploreBusiness) ReportUser(ctx context.Context, reporterID, reportedID string, reason ReportReason, details string) (uint64, error) {
+	var reportID uint64
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		if err := checkNotDeleted(ctx, tx, reporterID, reportedID); err != nil {
+			return err
+		}
+		var err error
+		reportID, err = tx.CreateReport(ctx, Report{
+			ReporterID: reporterID,
+			ReportedID: reportedID,
+			Reason:     reason,
+			Details:    details,
+			Status:     ReportOpen,
+		})
+		return err
+	})
+	return reportID, err
+}
+
+// ListReports returns the reports in the status, ordered by report time
+func (b *ExploreBusiness) ListReports(ctx context.Context, status ReportStatus, pagination PaginationParams) (*ListReportsResult, error) {
+	cursor, err := b.decodeCursor(pagination, listReportsEndpoint, string(status))
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := b.store.ListReports(ctx, status, timeQuery(cursor, pagination))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListReportsResult{Reports: reports}
+	if len(reports) == pagination.PageSize {
+		last := reports[len(reports)-1]
+		result.NextPaginationToken, err = b.tokens.Encode(listReportsEndpoint, string(status), pageCursor{
+			Timestamp:  last.UnixTimestamp,
+			ID:         last.ID,
+			Descending: pagination.Order == SortNewestFirst,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ClaimReport assigns an open report to the moderator, claiming it again is a no-op.
+// Reports claimed by another moderator or already resolved can't be claimed
+func (b *ExploreBusiness) ClaimReport(ctx context.Context, reportID uint64, moderatorID string) (*Report, error) {
+	var report Report
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Lock the report, concurrent claims wait for each other
+		var err error
+		report, err = getReport(ctx, tx, reportID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Only open reports can be claimed
+		switch {
+		case report.Status == ReportResolved:
+			return newReportError(ErrFailedPrecondition, ReasonReportResolved, "report is already resolved", reportID)
+		case report.Status == ReportClaimed && report.ModeratorID != moderatorID:
+			return newReportError(ErrFailedPrecondition, ReasonReportClaimed, "report is claimed by another moderator", reportID)
+		case report.Status == ReportClaimed:
+			return nil
+		}
+
+		// 3. Assign it
+		report.Status = ReportClaimed
+		report.ModeratorID = moderatorID
+		return tx.UpdateReport(ctx, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ResolveReport closes a report claimed by the moderator and applies the outcome to the reported user
+func (b *ExploreBusiness) ResolveReport(ctx context.Context, reportID uint64, moderatorID string, outcome ReportOutcome, note string) (*Report, error) {
+	var report Report
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Lock the report, it must be claimed by the moderator
+		var err error
+		report, err = getReport(ctx, tx, reportID)
+		if err != nil {
+			return err
+		}
+		if report.Status == ReportResolved {
+			return newReportError(ErrFailedPrecondition, ReasonReportResolved, "report is already resolved", reportID)
+		}
+		if report.Status != ReportClaimed || report.ModeratorID != moderatorID {
+			return newReportError(ErrFailedPrecondition, ReasonReportNotClaimed, "report must be claimed by the moderator first", reportID)
+		}
+
+		// 2. Sanction the reported user
+		if err := applyReportOutcome(ctx, tx, report.ReportedID, outcome); err != nil {
+			return err
+		}
+
+		// 3. Close the report
+		report.Status = ReportResolved
+		report.Outcome = outcome
+		report.ResolutionNote = note
+		return tx.UpdateReport(ctx, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// getReport locks the report, a REPORT_NOT_FOUND error is returned if it does not exist
+func getReport(ctx context.Context, tx DecisionTx, reportID uint64) (Report, error) {
+	report, found, err := tx.GetReport(ctx, reportID)
+	if err != nil {
+		return Report{}, err
+	}
+	if !found {
+		return Report{}, newReportError(ErrNotFound, ReasonReportNotFound, "report not found", reportID)
+	}
+	return report, nil
+}
+
+// applyReportOutcome updates the moderation state of the user. When the likes of the user get hidden,
+// they are taken out of the like_stats and user_stats of every liked user at once
+func applyReportOutcome(ctx context.Context, tx DecisionTx, userID string, outcome ReportOutcome) error {
+	if outcome == OutcomeDismissed {
+		return nil
+	}
+
+	moderation, err := tx.GetUserModeration(ctx, userID)
+	if err != nil {
+		return err
+	}
+	wasHidden := moderation.LikesHidden
+
+	switch outcome {
+	case OutcomeWarning:
+		moderation.Warnings++
+	case OutcomeLikesHidden:
+		moderation.LikesHidden = true
+	case OutcomeBanned:
+		moderation.LikesHidden = true
+		moderation.Banned = true
+	}
+	if err := tx.SaveUserModeration(ctx, moderation); err != nil {
+		return err
+	}
+
+	if moderation.LikesHidden && !wasHidden {
+		return tx.UncountLikes(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReports_ClaimAndResolve(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "a", false)
+	require.NoError(t, err)
+
+	reportID, err := business.ReportUser(ctx, "a", "b", ReportHarassment, "rude messages")
+	require.NoError(t, err)
+
+	// the queue shows the relationship of both users
+	open, err := business.ListReports(ctx, ReportOpen, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, open.Reports, 1)
+	assert.Equal(t, reportID, open.Reports[0].ID)
+	assert.Equal(t, Relationship{ReporterDecision: DecisionLiked, ReportedDecision: DecisionPassed}, open.Reports[0].Relationship)
+
+	// a report is handled by a single moderator
+	report, err := business.ClaimReport(ctx, reportID, "mod-1")
+	require.NoError(t, err)
+	assert.Equal(t, ReportClaimed, report.Status)
+	_, err = business.ClaimReport(ctx, reportID, "mod-1")
+	require.NoError(t, err)
+	_, err = business.ClaimReport(ctx, reportID, "mod-2")
+	assert.ErrorIs(t, err, ErrFailedPrecondition)
+	_, err = business.ResolveReport(ctx, reportID, "mod-2", OutcomeWarning, "")
+	assert.ErrorIs(t, err, ErrFailedPrecondition)
+
+	report, err = business.ResolveReport(ctx, reportID, "mod-1", OutcomeWarning, "first warning")
+	require.NoError(t, err)
+	assert.Equal(t, ReportResolved, report.Status)
+	assert.Equal(t, OutcomeWarning, report.Outcome)
+
+	_, err = business.ResolveReport(ctx, reportID, "mod-1", OutcomeBanned, "")
+	assert.ErrorIs(t, err, ErrFailedPrecondition)
+	_, err = business.ClaimReport(ctx, reportID+1, "mod-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	open, err = business.ListReports(ctx, ReportOpen, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, open.Reports)
+	resolved, err := business.ListReports(ctx, ReportResolved, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, resolved.Reports, 1)
+	assert.Equal(t, "first warning", resolved.Reports[0].ResolutionNote)
+}
+
+// resolveReport reports the user and resolves the report with the outcome
+func resolveReport(t *testing.T, business *ExploreBusiness, reporterID, reportedID string, outcome ReportOutcome) {
+	ctx := context.Background()
+	reportID, err := business.ReportUser(ctx, reporterID, reportedID, ReportSpam, "")
+	require.NoError(t, err)
+	_, err = business.ClaimReport(ctx, reportID, "mod-1")
+	require.NoError(t, err)
+	_, err = business.ResolveReport(ctx, reportID, "mod-1", outcome, "")
+	require.NoError(t, err)
+}
+
+func TestResolveReport_HiddenLikesAreNotShownNorCounted(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	for _, actor := range []string{"b", "c"} {
+		_, err := business.RecordDecision(ctx, actor, "a", true)
+		require.NoError(t, err)
+	}
+
+	resolveReport(t, business, "a", "b", OutcomeLikesHidden)
+
+	likers, err := business.ListLikedYouUsers(ctx, "a", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectActorIDs(likers))
+	count, err := business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// later likes are hidden too, and taking them back leaves the counters alone
+	_, err = business.RecordDecision(ctx, "b", "d", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "a", false)
+	require.NoError(t, err)
+	for user, expected := range map[string]uint64{"a": 1, "d": 0} {
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, count, user)
+	}
+}
+
+func TestResolveReport_BanRejectsDecisions(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	_, err := business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+
+	resolveReport(t, business, "a", "b", OutcomeBanned)
+
+	_, err = business.RecordDecision(ctx, "b", "a", true)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	count, err := business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// the banned user can still be decided on
+	_, err = business.RecordDecision(ctx, "a", "b", false)
+	assert.NoError(t, err)
+}
+
+func TestListReports_MySQLQueryWithRelationship(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM user_report r\s+LEFT JOIN decision d1 .+\s+LEFT JOIN decision d2 .+\s+LEFT JOIN user_match m .+\s+LEFT JOIN user_block b .+\s+WHERE r.status = \?\s+ORDER BY r.created_at ASC, r.id ASC`).
+		WithArgs(ReportOpen, 2).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "reporter_user_id", "reported_user_id", "reason", "details", "status", "moderator_id", "outcome",
+			"resolution_note", "unix_timestamp", "d1_liked", "d1_unmatched", "d2_liked", "d2_unmatched", "matched", "blocked",
+		}).AddRow(4, "actor1", "actor2", "SPAM", "", "OPEN", "", "", "", 1700000000, false, true, nil, nil, false, true))
+
+	resp, err := service.ListReports(context.Background(), &pb.ListReportsRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Reports, 1)
+	report := resp.Reports[0]
+	assert.Equal(t, uint64(4), report.ReportId)
+	assert.Equal(t, pb.ReportReason_REPORT_REASON_SPAM, report.Reason)
+	assert.Equal(t, pb.ReportStatus_REPORT_STATUS_OPEN, report.Status)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_UNMATCHED, report.Relationship.ReporterDecision)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_NONE, report.Relationship.ReportedDecision)
+	assert.True(t, report.Relationship.Blocked)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveReport_MySQLHidesLikesOnce(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	reportColumns := []string{
+		"id", "reporter_user_id", "reported_user_id", "reason", "details", "status", "moderator_id", "outcome",
+		"resolution_note", "unix_timestamp", "d1_liked", "d1_unmatched", "d2_liked", "d2_unmatched", "matched", "blocked",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM user_report r\s+.+WHERE r.id = \?\s+FOR UPDATE OF r`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(reportColumns).
+			AddRow(4, "actor1", "actor2", "SPAM", "", "CLAIMED", "mod-1", "", "", 1700000000, nil, nil, true, false, false, false))
+	mock.ExpectQuery(`FROM user_moderation\s+WHERE user_id = \?\s+FOR SHARE`).
+		WithArgs("actor2").
+		WillReturnRows(sqlmock.NewRows([]string{"warnings", "likes_hidden", "banned"}).AddRow(1, false, false))
+	mock.ExpectExec(`INSERT INTO user_moderation`).
+		WithArgs("actor2", uint32(1), true, false).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE like_stats ls\s+JOIN decision d ON d.recipient_user_id = ls.user_id\s+SET ls.like_count = ls.like_count - 1`).
+		WithArgs("actor2").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`UPDATE user_stats us\s+JOIN decision d ON d.recipient_user_id = us.user_id\s+SET us.new_like_count = us.new_like_count - 1`).
+		WithArgs("actor2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE user_report`).
+		WithArgs(ReportResolved, "mod-1", OutcomeLikesHidden, "spam bot", uint64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := service.ResolveReport(context.Background(), &pb.ResolveReportRequest{
+		ReportId:    4,
+		ModeratorId: "mod-1",
+		Outcome:     pb.ReportOutcome_REPORT_OUTCOME_LIKES_HIDDEN,
+		Note:        "spam bot",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, pb.ReportStatus_REPORT_STATUS_RESOLVED, resp.Report.Status)
+	assert.Equal(t, pb.DecisionState_DECISION_STATE_LIKED, resp.Report.Relationship.ReportedDecision)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimReport_ClaimedByAnotherModerator(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+	service := &ExploreService{Business: business, Validator: NewRequestValidator(ValidationConfig{MaxPageSize: 100})}
+
+	reportID, err := business.ReportUser(ctx, "a", "b", ReportSpam, "")
+	require.NoError(t, err)
+	_, err = business.ClaimReport(ctx, reportID, "mod-1")
+	require.NoError(t, err)
+
+	_, err = service.ClaimReport(ctx, &pb.ClaimReportRequest{ReportId: reportID, ModeratorId: "mod-2"})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, ReasonReportClaimed, errorInfoOf(t, err).Reason)
+}
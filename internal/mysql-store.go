@@ -0,0 +1,2159 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL server error numbers handled by classifyMySQLError
+const (
+	mysqlErrNoReferencedRow    = 1452 // foreign key violation on insert/update
+	mysqlErrLockWaitTimeout    = 1205
+	mysqlErrDeadlock           = 1213
+	mysqlErrTooManyConnections = 1040
+	mysqlErrServerShutdown     = 1053
+)
+
+// MySQLStore is the DecisionStore backed by the MySQL schema in db/01-init.sql
+type MySQLStore struct {
+	db *DB
+}
+
+// NewMySQLStore creates a DecisionStore on top of an already connected DB
+func NewMySQLStore(db *DB) *MySQLStore {
+	return &MySQLStore{db: db}
+}
+
+// timeKeyset builds the keyset predicate and sort direction of a TimeQuery for the table aliased as alias.
+// The first page has no predicate, later pages seek past the (created_at, id) cursor in the query direction
+func timeKeyset(alias string, query TimeQuery) (string, []any, string) {
+	operator, direction := ">", "ASC"
+	if query.Descending {
+		operator, direction = "<", "DESC"
+	}
+
+	if query.After == (TimeCursor{}) {
+		return "", nil, direction
+	}
+
+	predicate := fmt.Sprintf(`
+			AND (
+				%[1]s.created_at %[2]s FROM_UNIXTIME(?)
+				OR (%[1]s.created_at = FROM_UNIXTIME(?) AND %[1]s.id %[2]s ?)
+			)`, alias, operator)
+	return predicate, []any{query.After.UnixTimestamp, query.After.UnixTimestamp, query.After.ID}, direction
+}
+
+// timeWindow returns the predicates keeping the rows of alias with a created_at within the window,
+// a range on the created_at column of the like indexes
+func timeWindow(alias string, window TimeWindow) (string, []any) {
+	var predicate string
+	var args []any
+	if window.Since > 0 {
+		predicate += fmt.Sprintf(`
+			AND %s.created_at >= FROM_UNIXTIME(?)`, alias)
+		args = append(args, window.Since)
+	}
+	if window.Until > 0 {
+		predicate += fmt.Sprintf(`
+			AND %s.created_at < FROM_UNIXTIME(?)`, alias)
+		args = append(args, window.Until)
+	}
+	return predicate, args
+}
+
+// visibleLikeActor leaves out the actors of decision d blocked by its recipient, a lookup on unique_blocker_blocked,
+// and the actors whose likes are hidden by moderation, a lookup on the user_moderation primary key
+const visibleLikeActor = `
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_block b
+				WHERE
+					b.blocker_user_id = d.recipient_user_id
+					AND b.blocked_user_id = d.actor_user_id
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_moderation um
+				WHERE
+					um.user_id = d.actor_user_id
+					AND um.likes_hidden = TRUE
+			)`
+
+// ListLikedYou uses the idx_decision_recipient_like_created index to seek directly to the (created_at, id) cursor,
+// in either direction. created_at is reset on every overwrite, so it holds the time of the most recent like,
+// and the window narrows the same index range
+func (s *MySQLStore) ListLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error) {
+	keyset, keysetArgs, direction := timeKeyset("d", query)
+	bounds, boundArgs := timeWindow("d", window)
+	statement := fmt.Sprintf(`
+		SELECT
+			d.id,
+			d.actor_user_id,
+			UNIX_TIMESTAMP(d.created_at)
+		FROM decision d
+		WHERE d.recipient_user_id = ?
+			AND d.liked_recipient = true%[4]s%[1]s%[3]s
+		ORDER BY d.created_at %[2]s, d.id %[2]s
+		LIMIT ?;
+	`, keyset, direction, visibleLikeActor, bounds)
+
+	args := append([]any{recipientID}, boundArgs...)
+	args = append(args, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying liked users: %w", err))
+	}
+	defer result.Close()
+
+	var records []LikeRecord
+	for result.Next() {
+		var record LikeRecord
+		if err := result.Scan(&record.DecisionID, &record.ActorID, &record.UnixTimestamp); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning liked user: %w", err))
+		}
+		records = append(records, record)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating liked users: %w", err))
+	}
+
+	return records, nil
+}
+
+// ListNewLikedYou filters mutual likes and unmatched users with a NOT EXISTS sub-query served by idx_decision_actor_recipient_like
+func (s *MySQLStore) ListNewLikedYou(ctx context.Context, recipientID string, window TimeWindow, query TimeQuery) ([]LikeRecord, error) {
+	keyset, keysetArgs, direction := timeKeyset("d", query)
+	bounds, boundArgs := timeWindow("d", window)
+	statement := fmt.Sprintf(`
+		SELECT
+			d.id,
+			d.actor_user_id,
+			UNIX_TIMESTAMP(d.created_at)
+		FROM decision d
+		WHERE
+			d.recipient_user_id = ?
+			AND d.liked_recipient = TRUE%[4]s%[1]s
+			AND NOT EXISTS (
+				SELECT 1
+				FROM decision d2
+				WHERE
+					d2.actor_user_id = ?
+					AND d2.recipient_user_id = d.actor_user_id
+					AND (d2.liked_recipient = TRUE OR d2.unmatched = TRUE)
+			)%[3]s
+		ORDER BY d.created_at %[2]s, d.id %[2]s
+		LIMIT ?;
+	`, keyset, direction, visibleLikeActor, bounds)
+
+	args := append([]any{recipientID}, boundArgs...)
+	args = append(args, keysetArgs...)
+	args = append(args, recipientID, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying new liked users: %w", err))
+	}
+	defer result.Close()
+
+	var records []LikeRecord
+	for result.Next() {
+		var record LikeRecord
+		if err := result.Scan(&record.DecisionID, &record.ActorID, &record.UnixTimestamp); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning new liked user: %w", err))
+		}
+		records = append(records, record)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating new liked users: %w", err))
+	}
+
+	return records, nil
+}
+
+// ListLikesReceived is ListLikedYou without the visibleLikeActor filter, on the same index
+func (s *MySQLStore) ListLikesReceived(ctx context.Context, recipientID string, query TimeQuery) ([]LikeRecord, error) {
+	keyset, keysetArgs, direction := timeKeyset("d", query)
+	statement := fmt.Sprintf(`
+		SELECT
+			d.id,
+			d.actor_user_id,
+			UNIX_TIMESTAMP(d.created_at)
+		FROM decision d
+		WHERE d.recipient_user_id = ?
+			AND d.liked_recipient = true%[1]s
+		ORDER BY d.created_at %[2]s, d.id %[2]s
+		LIMIT ?;
+	`, keyset, direction)
+
+	args := append([]any{recipientID}, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying likes received: %w", err))
+	}
+	defer result.Close()
+
+	var records []LikeRecord
+	for result.Next() {
+		var record LikeRecord
+		if err := result.Scan(&record.DecisionID, &record.ActorID, &record.UnixTimestamp); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning like received: %w", err))
+		}
+		records = append(records, record)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating likes received: %w", err))
+	}
+
+	return records, nil
+}
+
+// GetDecisions reads the decisions of the actor, and the ones back, on the unique_actor_recipient key
+func (s *MySQLStore) GetDecisions(ctx context.Context, actorID string, recipientIDs []string) ([]DecisionStatus, error) {
+	if len(recipientIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, recipientArgs := idList(recipientIDs)
+	query := fmt.Sprintf(`
+		SELECT
+			d.recipient_user_id,
+			d.liked_recipient,
+			d.unmatched,
+			UNIX_TIMESTAMP(d.created_at),
+			d.liked_recipient AND EXISTS (
+				SELECT 1
+				FROM decision rd
+				WHERE
+					rd.actor_user_id = d.recipient_user_id
+					AND rd.recipient_user_id = d.actor_user_id
+					AND rd.liked_recipient = TRUE
+			)
+		FROM decision d
+		WHERE d.actor_user_id = ?
+			AND d.recipient_user_id IN (%s);
+	`, placeholders)
+
+	rows, err := s.db.QueryContext(ctx, query, append([]any{actorID}, recipientArgs...)...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error getting decisions of %s: %w", actorID, err))
+	}
+	defer rows.Close()
+
+	var statuses []DecisionStatus
+	for rows.Next() {
+		var status DecisionStatus
+		var liked, unmatched bool
+		if err := rows.Scan(&status.RecipientID, &liked, &unmatched, &status.UnixTimestamp, &status.Reciprocated); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning decision of %s: %w", actorID, err))
+		}
+		status.State = decisionStateOf(true, liked, unmatched)
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating decisions of %s: %w", actorID, err))
+	}
+	return statuses, nil
+}
+
+func (s *MySQLStore) GetUser(ctx context.Context, userID string) (User, error) {
+	const query = `
+		SELECT
+			id,
+			name,
+			COALESCE(UNIX_TIMESTAMP(created_at), 0)
+		FROM user
+		WHERE id = ?;
+	`
+
+	var user User
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(&user.ID, &user.Name, &user.CreatedUnixTimestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, err)
+	}
+	if err != nil {
+		return User{}, classifyMySQLError(fmt.Errorf("error getting user %s: %w", userID, err))
+	}
+	return user, nil
+}
+
+// ListMyDecisions is served by idx_decision_actor_like_created, the like back is a lookup on unique_actor_recipient
+func (s *MySQLStore) ListMyDecisions(ctx context.Context, actorID string, liked bool, query TimeQuery) ([]DecisionStatus, error) {
+	keyset, keysetArgs, direction := timeKeyset("d", query)
+	statement := fmt.Sprintf(`
+		SELECT
+			d.id,
+			d.recipient_user_id,
+			d.unmatched,
+			UNIX_TIMESTAMP(d.created_at),
+			d.liked_recipient AND EXISTS (
+				SELECT 1
+				FROM decision rd
+				WHERE
+					rd.actor_user_id = d.recipient_user_id
+					AND rd.recipient_user_id = d.actor_user_id
+					AND rd.liked_recipient = TRUE
+			)
+		FROM decision d
+		WHERE
+			d.actor_user_id = ?
+			AND d.liked_recipient = ?%[1]s
+		ORDER BY d.created_at %[2]s, d.id %[2]s
+		LIMIT ?;
+	`, keyset, direction)
+
+	args := append([]any{actorID, liked}, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying decisions of %s: %w", actorID, err))
+	}
+	defer result.Close()
+
+	var statuses []DecisionStatus
+	for result.Next() {
+		var status DecisionStatus
+		var unmatched bool
+		err := result.Scan(&status.ID, &status.RecipientID, &unmatched, &status.UnixTimestamp, &status.Reciprocated)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning decision of %s: %w", actorID, err))
+		}
+		status.State = decisionStateOf(true, liked, unmatched)
+		statuses = append(statuses, status)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating decisions of %s: %w", actorID, err))
+	}
+
+	return statuses, nil
+}
+
+// ListDecisionsMade seeks past the last recipient on the unique_actor_recipient index
+func (s *MySQLStore) ListDecisionsMade(ctx context.Context, actorID, afterRecipientID string, limit int) ([]Decision, error) {
+	const query = `
+		SELECT
+			id,
+			recipient_user_id,
+			liked_recipient,
+			unmatched,
+			UNIX_TIMESTAMP(created_at)
+		FROM decision
+		WHERE actor_user_id = ?
+			AND recipient_user_id > ?
+		ORDER BY recipient_user_id
+		LIMIT ?;
+	`
+
+	result, err := s.db.QueryContext(ctx, query, actorID, afterRecipientID, limit)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying decisions made: %w", err))
+	}
+	defer result.Close()
+
+	var decisions []Decision
+	for result.Next() {
+		decision := Decision{ActorID: actorID}
+		err := result.Scan(&decision.ID, &decision.RecipientID, &decision.Liked, &decision.Unmatched, &decision.UnixTimestamp)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning decision made: %w", err))
+		}
+		decisions = append(decisions, decision)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating decisions made: %w", err))
+	}
+
+	return decisions, nil
+}
+
+// CountLikedYou reads the like_stats cache instead of running COUNT() over decision.
+// Users never liked have no like_stats row, the LEFT JOIN on the user primary key tells them
+// apart from unknown users in the same round-trip
+func (s *MySQLStore) CountLikedYou(ctx context.Context, recipientID string, window TimeWindow, limit uint64) (uint64, error) {
+	switch {
+	case window.Until != 0:
+		return s.countLikedYouWithin(ctx, recipientID, window, limit)
+	case window.Since != 0:
+		return s.countLikedYouSince(ctx, recipientID, window, limit)
+	}
+
+	const query = `
+		SELECT
+			COALESCE(ls.like_count, 0)
+		FROM user u
+		LEFT JOIN like_stats ls ON ls.user_id = u.id
+		WHERE u.id = ?;
+	`
+
+	var count uint64
+	err := s.db.QueryRowContext(ctx, query, recipientID).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, newUserNotFoundError("user not found", map[string]string{"user_id": recipientID}, err)
+	}
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error getting likes count for id %s: %w", recipientID, err))
+	}
+
+	return count, nil
+}
+
+// countLikedYouSince serves windows with only a since bound, such as the one LIKES_MAX_AGE sets, from the cache
+// when the recipient has no like older than the bound, which a single probe of idx_decision_recipient_like_created
+// tells along with the cached count. Recipients with older likes have them counted by countLikedYouWithin
+func (s *MySQLStore) countLikedYouSince(ctx context.Context, recipientID string, window TimeWindow, limit uint64) (uint64, error) {
+	const query = `
+		SELECT
+			COALESCE(ls.like_count, 0),
+			EXISTS (
+				SELECT 1
+				FROM decision d
+				WHERE d.recipient_user_id = u.id
+					AND d.liked_recipient = TRUE
+					AND d.created_at < FROM_UNIXTIME(?)
+			)
+		FROM user u
+		LEFT JOIN like_stats ls ON ls.user_id = u.id
+		WHERE u.id = ?;
+	`
+
+	var count uint64
+	var older bool
+	err := s.db.QueryRowContext(ctx, query, window.Since, recipientID).Scan(&count, &older)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, newUserNotFoundError("user not found", map[string]string{"user_id": recipientID}, err)
+	}
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error getting likes count for id %s: %w", recipientID, err))
+	}
+	if older {
+		return s.countLikedYouWithin(ctx, recipientID, window, limit)
+	}
+
+	return count, nil
+}
+
+// countLikedYouWithin counts the likes of the window on the idx_decision_recipient_like_created range,
+// the cache can't tell when the likes were recorded. It reads one index entry per like of the window, plus
+// the two primary key probes of visibleLikeActor, so the derived table stops the range at limit likes.
+// The count runs on the user row to tell unknown users apart
+func (s *MySQLStore) countLikedYouWithin(ctx context.Context, recipientID string, window TimeWindow, limit uint64) (uint64, error) {
+	bounds, args := timeWindow("d", window)
+	args = append([]any{recipientID}, args...)
+	var limitClause string
+	if limit > 0 {
+		limitClause = `
+				LIMIT ?`
+		args = append(args, limit)
+	}
+	query := fmt.Sprintf(`
+		SELECT (
+			SELECT COUNT(*)
+			FROM (
+				SELECT 1
+				FROM decision d
+				WHERE d.recipient_user_id = ?
+					AND d.liked_recipient = TRUE%[1]s%[2]s%[3]s
+			) likes
+		)
+		FROM user u
+		WHERE u.id = ?;
+	`, bounds, visibleLikeActor, limitClause)
+
+	var count uint64
+	err := s.db.QueryRowContext(ctx, query, append(args, recipientID)...).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, newUserNotFoundError("user not found", map[string]string{"user_id": recipientID}, err)
+	}
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error counting likes of %s: %w", recipientID, err))
+	}
+
+	return count, nil
+}
+
+// GetUserStats reads both counter tables from the user row, so missing counter rows read as zeros
+func (s *MySQLStore) GetUserStats(ctx context.Context, userID string) (UserStats, error) {
+	const query = `
+		SELECT
+			COALESCE(ls.like_count, 0),
+			COALESCE(us.new_like_count, 0),
+			COALESCE(us.match_count, 0),
+			COALESCE(us.pass_count, 0)
+		FROM user u
+		LEFT JOIN like_stats ls ON ls.user_id = u.id
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE u.id = ?;
+	`
+
+	stats := UserStats{UserID: userID}
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(&stats.LikeCount, &stats.NewLikeCount, &stats.MatchCount, &stats.PassCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserStats{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, err)
+	}
+	if err != nil {
+		return UserStats{}, classifyMySQLError(fmt.Errorf("error getting stats of %s: %w", userID, err))
+	}
+	return stats, nil
+}
+
+// GetUserModeration is a plain read, unlike the locking read of mysqlTx
+func (s *MySQLStore) GetUserModeration(ctx context.Context, userID string) (UserModeration, error) {
+	const query = `
+		SELECT
+			warnings,
+			likes_hidden,
+			banned
+		FROM user_moderation
+		WHERE user_id = ?;
+	`
+
+	moderation := UserModeration{UserID: userID}
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(&moderation.Warnings, &moderation.LikesHidden, &moderation.Banned)
+	if errors.Is(err, sql.ErrNoRows) {
+		return moderation, nil
+	}
+	if err != nil {
+		return UserModeration{}, classifyMySQLError(fmt.Errorf("error getting moderation of %s: %w", userID, err))
+	}
+	return moderation, nil
+}
+
+// ListIdempotencyRecords seeks past the last key on the primary key
+func (s *MySQLStore) ListIdempotencyRecords(ctx context.Context, actorID, afterKey string, limit int) ([]IdempotencyRecord, error) {
+	const query = `
+		SELECT
+			idempotency_key,
+			recipient_user_id,
+			liked_recipient,
+			mutual_likes
+		FROM idempotency_key
+		WHERE actor_user_id = ?
+			AND idempotency_key > ?
+		ORDER BY idempotency_key
+		LIMIT ?;
+	`
+
+	result, err := s.db.QueryContext(ctx, query, actorID, afterKey, limit)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying idempotency keys of %s: %w", actorID, err))
+	}
+	defer result.Close()
+
+	var records []IdempotencyRecord
+	for result.Next() {
+		record := IdempotencyRecord{ActorID: actorID}
+		if err := result.Scan(&record.Key, &record.RecipientID, &record.Liked, &record.MutualLikes); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning idempotency key of %s: %w", actorID, err))
+		}
+		records = append(records, record)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating idempotency keys of %s: %w", actorID, err))
+	}
+
+	return records, nil
+}
+
+// ListMatches is served by idx_user_match_user_created, each match is stored once per side
+func (s *MySQLStore) ListMatches(ctx context.Context, userID string, query TimeQuery) ([]Match, error) {
+	keyset, keysetArgs, direction := timeKeyset("m", query)
+	statement := fmt.Sprintf(`
+		SELECT
+			m.id,
+			m.matched_user_id,
+			UNIX_TIMESTAMP(m.created_at)
+		FROM user_match m
+		WHERE m.user_id = ?%[1]s
+		ORDER BY m.created_at %[2]s, m.id %[2]s
+		LIMIT ?;
+	`, keyset, direction)
+
+	args := append([]any{userID}, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying matches: %w", err))
+	}
+	defer result.Close()
+
+	var matches []Match
+	for result.Next() {
+		var match Match
+		if err := result.Scan(&match.ID, &match.MatchedUserID, &match.UnixTimestamp); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning match: %w", err))
+		}
+		matches = append(matches, match)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating matches: %w", err))
+	}
+
+	return matches, nil
+}
+
+// ListBlocked is served by idx_user_block_blocker_created
+func (s *MySQLStore) ListBlocked(ctx context.Context, blockerID string, query TimeQuery) ([]BlockedUser, error) {
+	keyset, keysetArgs, direction := timeKeyset("b", query)
+	statement := fmt.Sprintf(`
+		SELECT
+			b.id,
+			b.blocked_user_id,
+			UNIX_TIMESTAMP(b.created_at)
+		FROM user_block b
+		WHERE b.blocker_user_id = ?%[1]s
+		ORDER BY b.created_at %[2]s, b.id %[2]s
+		LIMIT ?;
+	`, keyset, direction)
+
+	args := append([]any{blockerID}, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying blocked users: %w", err))
+	}
+	defer result.Close()
+
+	var blocked []BlockedUser
+	for result.Next() {
+		var user BlockedUser
+		if err := result.Scan(&user.ID, &user.BlockedUserID, &user.UnixTimestamp); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning blocked user: %w", err))
+		}
+		blocked = append(blocked, user)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating blocked users: %w", err))
+	}
+
+	return blocked, nil
+}
+
+// reportSelect selects the reports aliased as r along with their relationship, every join is a lookup on a unique key
+const reportSelect = `
+		SELECT
+			r.id,
+			r.reporter_user_id,
+			r.reported_user_id,
+			r.reason,
+			r.details,
+			r.status,
+			r.moderator_id,
+			r.outcome,
+			r.resolution_note,
+			UNIX_TIMESTAMP(r.created_at),
+			d1.liked_recipient,
+			d1.unmatched,
+			d2.liked_recipient,
+			d2.unmatched,
+			m.id IS NOT NULL,
+			b.id IS NOT NULL
+		FROM user_report r
+		LEFT JOIN decision d1 ON d1.actor_user_id = r.reporter_user_id AND d1.recipient_user_id = r.reported_user_id
+		LEFT JOIN decision d2 ON d2.actor_user_id = r.reported_user_id AND d2.recipient_user_id = r.reporter_user_id
+		LEFT JOIN user_match m ON m.user_id = r.reporter_user_id AND m.matched_user_id = r.reported_user_id
+		LEFT JOIN user_block b ON b.blocker_user_id = r.reporter_user_id AND b.blocked_user_id = r.reported_user_id`
+
+// scanReport scans a row selected by reportSelect
+func scanReport(row interface{ Scan(dest ...any) error }) (Report, error) {
+	var report Report
+	var reporterLiked, reporterUnmatched, reportedLiked, reportedUnmatched sql.NullBool
+	err := row.Scan(
+		&report.ID, &report.ReporterID, &report.ReportedID, &report.Reason, &report.Details,
+		&report.Status, &report.ModeratorID, &report.Outcome, &report.ResolutionNote, &report.UnixTimestamp,
+		&reporterLiked, &reporterUnmatched, &reportedLiked, &reportedUnmatched,
+		&report.Relationship.Matched, &report.Relationship.Blocked,
+	)
+	report.Relationship.ReporterDecision = decisionStateOf(reporterLiked.Valid, reporterLiked.Bool, reporterUnmatched.Bool)
+	report.Relationship.ReportedDecision = decisionStateOf(reportedLiked.Valid, reportedLiked.Bool, reportedUnmatched.Bool)
+	return report, err
+}
+
+// ListReports is served by idx_user_report_status_created
+func (s *MySQLStore) ListReports(ctx context.Context, status ReportStatus, query TimeQuery) ([]Report, error) {
+	keyset, keysetArgs, direction := timeKeyset("r", query)
+	statement := reportSelect + fmt.Sprintf(`
+		WHERE r.status = ?%[1]s
+		ORDER BY r.created_at %[2]s, r.id %[2]s
+		LIMIT ?;
+	`, keyset, direction)
+
+	args := append([]any{status}, keysetArgs...)
+	args = append(args, query.Limit)
+
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying reports: %w", err))
+	}
+	defer result.Close()
+
+	var reports []Report
+	for result.Next() {
+		report, err := scanReport(result)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning report: %w", err))
+		}
+		reports = append(reports, report)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating reports: %w", err))
+	}
+
+	return reports, nil
+}
+
+// ListUserReports is served by the foreign key index of the reporter, or of the reported user, which ends with the id
+func (s *MySQLStore) ListUserReports(ctx context.Context, userID string, filed bool, query EventQuery) ([]Report, error) {
+	column := "r.reported_user_id"
+	if filed {
+		column = "r.reporter_user_id"
+	}
+	operator, direction := ">", "ASC"
+	afterID := query.AfterID
+	if query.Descending {
+		operator, direction = "<", "DESC"
+		if afterID == 0 {
+			afterID = math.MaxInt64
+		}
+	}
+
+	statement := reportSelect + fmt.Sprintf(`
+		WHERE %s = ?
+			AND r.id %s ?
+		ORDER BY r.id %s
+		LIMIT ?;
+	`, column, operator, direction)
+
+	result, err := s.db.QueryContext(ctx, statement, userID, afterID, query.Limit)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying reports of %s: %w", userID, err))
+	}
+	defer result.Close()
+
+	var reports []Report
+	for result.Next() {
+		report, err := scanReport(result)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning report of %s: %w", userID, err))
+		}
+		reports = append(reports, report)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating reports of %s: %w", userID, err))
+	}
+
+	return reports, nil
+}
+
+// ListDecisionHistory is served by idx_decision_event_actor_id, or idx_decision_event_actor_recipient_id for a single pair
+func (s *MySQLStore) ListDecisionHistory(ctx context.Context, actorID, recipientID string, query EventQuery) ([]DecisionEvent, error) {
+	operator, direction := ">", "ASC"
+	afterID := query.AfterID
+	if query.Descending {
+		operator, direction = "<", "DESC"
+		if afterID == 0 {
+			afterID = math.MaxInt64
+		}
+	}
+
+	pairFilter := ""
+	args := []any{actorID}
+	if recipientID != "" {
+		pairFilter = `
+			AND recipient_user_id = ?`
+		args = append(args, recipientID)
+	}
+	args = append(args, afterID, query.Limit)
+
+	statement := fmt.Sprintf(`
+		SELECT%s
+		FROM decision_event
+		WHERE actor_user_id = ?%s
+			AND id %s ?
+		ORDER BY id %s
+		LIMIT ?;
+	`, decisionEventColumns, pairFilter, operator, direction)
+
+	return s.queryDecisionEvents(ctx, "decision history", statement, args...)
+}
+
+// ListDecisionEventsReceived is served by idx_decision_event_recipient_id
+func (s *MySQLStore) ListDecisionEventsReceived(ctx context.Context, recipientID string, query EventQuery) ([]DecisionEvent, error) {
+	operator, direction := ">", "ASC"
+	afterID := query.AfterID
+	if query.Descending {
+		operator, direction = "<", "DESC"
+		if afterID == 0 {
+			afterID = math.MaxInt64
+		}
+	}
+
+	statement := fmt.Sprintf(`
+		SELECT%s
+		FROM decision_event
+		WHERE recipient_user_id = ?
+			AND id %s ?
+		ORDER BY id %s
+		LIMIT ?;
+	`, decisionEventColumns, operator, direction)
+
+	return s.queryDecisionEvents(ctx, "decision events received", statement, recipientID, afterID, query.Limit)
+}
+
+// decisionEventColumns are the decision_event columns scanned by queryDecisionEvents
+const decisionEventColumns = `
+			id,
+			actor_user_id,
+			recipient_user_id,
+			liked_recipient,
+			unmatched,
+			matched,
+			UNIX_TIMESTAMP(created_at)`
+
+// queryDecisionEvents runs a query selecting decisionEventColumns, what names the events in error messages
+func (s *MySQLStore) queryDecisionEvents(ctx context.Context, what, statement string, args ...any) ([]DecisionEvent, error) {
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying %s: %w", what, err))
+	}
+	defer result.Close()
+
+	var events []DecisionEvent
+	for result.Next() {
+		var event DecisionEvent
+		err := result.Scan(&event.ID, &event.ActorID, &event.RecipientID, &event.Liked, &event.Unmatched, &event.Matched, &event.UnixTimestamp)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning %s: %w", what, err))
+		}
+		events = append(events, event)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating %s: %w", what, err))
+	}
+
+	return events, nil
+}
+
+// userDeletionColumns are the columns scanned by scanUserDeletion
+const userDeletionColumns = `
+			user_id,
+			step,
+			decisions_deleted,
+			like_counts_repaired,
+			decision_events_deleted,
+			matches_deleted,
+			blocks_deleted,
+			reports_deleted,
+			UNIX_TIMESTAMP(requested_at),
+			COALESCE(UNIX_TIMESTAMP(completed_at), 0)
+`
+
+func scanUserDeletion(row interface{ Scan(dest ...any) error }) (UserDeletion, error) {
+	var deletion UserDeletion
+	err := row.Scan(&deletion.UserID, &deletion.Step, &deletion.DecisionsDeleted, &deletion.LikeCountsRepaired,
+		&deletion.DecisionEventsDeleted, &deletion.MatchesDeleted, &deletion.BlocksDeleted, &deletion.ReportsDeleted,
+		&deletion.RequestedUnixTimestamp, &deletion.CompletedUnixTimestamp)
+	return deletion, err
+}
+
+func (s *MySQLStore) GetUserDeletion(ctx context.Context, userID string) (UserDeletion, bool, error) {
+	query := `
+		SELECT` + userDeletionColumns + `
+		FROM user_deletion
+		WHERE user_id = ?;
+	`
+	deletion, err := scanUserDeletion(s.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return UserDeletion{}, false, nil
+	}
+	if err != nil {
+		return UserDeletion{}, false, classifyMySQLError(fmt.Errorf("error getting deletion of %s: %w", userID, err))
+	}
+	return deletion, true, nil
+}
+
+func (s *MySQLStore) LastDecisionEventID(ctx context.Context) (uint64, error) {
+	const query = `
+		SELECT
+			COALESCE(MAX(id), 0)
+		FROM decision_event;
+	`
+
+	var id uint64
+	if err := s.db.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error getting last decision event id: %w", err))
+	}
+	return id, nil
+}
+
+// ListDecisionEventsAfter is a primary key range scan, the actor's likes_hidden sanction is a lookup on the
+// user_moderation primary key
+func (s *MySQLStore) ListDecisionEventsAfter(ctx context.Context, afterID uint64, limit int) ([]DecisionEvent, error) {
+	statement := `
+		SELECT` + feedEventColumns + `
+		FROM decision_event
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?;
+	`
+	return s.queryFeedEvents(ctx, statement, afterID, limit)
+}
+
+// ListDecisionEventsByID is a primary key lookup per id, as ListDecisionEventsAfter
+func (s *MySQLStore) ListDecisionEventsByID(ctx context.Context, ids []uint64) ([]DecisionEvent, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := idList(ids)
+	statement := `
+		SELECT` + feedEventColumns + `
+		FROM decision_event
+		WHERE id IN (` + placeholders + `)
+		ORDER BY id;
+	`
+	return s.queryFeedEvents(ctx, statement, args...)
+}
+
+// feedEventColumns are decisionEventColumns followed by the likes_hidden sanction of the actor
+const feedEventColumns = decisionEventColumns + `,
+			EXISTS (
+				SELECT 1
+				FROM user_moderation um
+				WHERE
+					um.user_id = decision_event.actor_user_id
+					AND um.likes_hidden = TRUE
+			)`
+
+// queryFeedEvents runs a query selecting feedEventColumns
+func (s *MySQLStore) queryFeedEvents(ctx context.Context, statement string, args ...any) ([]DecisionEvent, error) {
+	result, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying decision events: %w", err))
+	}
+	defer result.Close()
+
+	var events []DecisionEvent
+	for result.Next() {
+		var event DecisionEvent
+		err := result.Scan(&event.ID, &event.ActorID, &event.RecipientID, &event.Liked, &event.Unmatched, &event.Matched,
+			&event.UnixTimestamp, &event.ActorLikesHidden)
+		if err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning decision events: %w", err))
+		}
+		events = append(events, event)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating decision events: %w", err))
+	}
+
+	return events, nil
+}
+
+// ListLikeEvents merges idx_decision_event_recipient_id (likes received)
+// and idx_decision_event_actor_id (matches created by the user)
+func (s *MySQLStore) ListLikeEvents(ctx context.Context, userID string, afterID, untilID uint64, limit int) ([]DecisionEvent, error) {
+	statement := `
+		SELECT` + decisionEventColumns + `
+		FROM decision_event
+		WHERE id > ?
+			AND id <= ?
+			AND (
+				(
+					recipient_user_id = ?
+					AND liked_recipient = TRUE
+					AND NOT EXISTS (
+						SELECT 1
+						FROM user_moderation um
+						WHERE
+							um.user_id = decision_event.actor_user_id
+							AND um.likes_hidden = TRUE
+					)
+				)
+				OR (actor_user_id = ? AND matched = TRUE)
+			)
+		ORDER BY id
+		LIMIT ?;
+	`
+	return s.queryDecisionEvents(ctx, "like events", statement, afterID, untilID, userID, userID, limit)
+}
+
+const webhookDeliveryColumns = `
+			id,
+			endpoint,
+			event_id,
+			body,
+			attempts,
+			last_error,
+			UNIX_TIMESTAMP(created_at)`
+
+// scanWebhookDeliveries reads rows selecting webhookDeliveryColumns
+func scanWebhookDeliveries(result *sql.Rows) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	for result.Next() {
+		var delivery WebhookDelivery
+		var body []byte
+		err := result.Scan(&delivery.ID, &delivery.Endpoint, &delivery.EventID, &body, &delivery.Attempts, &delivery.LastError, &delivery.UnixTimestamp)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+		delivery.Body = body
+		deliveries = append(deliveries, delivery)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// EnqueueWebhookDeliveries relies on the (event_id, endpoint) unique key to skip deliveries already enqueued
+func (s *MySQLStore) EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, 3*len(deliveries))
+	for _, delivery := range deliveries {
+		args = append(args, delivery.Endpoint, delivery.EventID, []byte(delivery.Body))
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO webhook_delivery (endpoint, event_id, body)
+		VALUES %s
+		ON DUPLICATE KEY UPDATE id = id;
+	`, strings.Repeat(", (?, ?, ?)", len(deliveries))[2:])
+
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return classifyMySQLError(fmt.Errorf("error enqueuing webhook deliveries: %w", err))
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries seeks idx_webhook_delivery_due to the due deliveries and pushes their due time past the lease.
+// SKIP LOCKED lets concurrent dispatchers claim different deliveries, unlike the outbox there is no order to keep
+func (s *MySQLStore) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	err := s.inTx(ctx, func(tx *mysqlTx) error {
+		query := `
+			SELECT` + webhookDeliveryColumns + `
+			FROM webhook_delivery
+			WHERE dead_at IS NULL
+				AND next_attempt_at <= CURRENT_TIMESTAMP(3)
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED;
+		`
+		result, err := tx.tx.QueryContext(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("error claiming webhook deliveries: %w", err)
+		}
+		defer result.Close()
+
+		if deliveries, err = scanWebhookDeliveries(result); err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint64, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		placeholders, args := idList(ids)
+		update := fmt.Sprintf(`
+			UPDATE webhook_delivery
+			SET next_attempt_at = CURRENT_TIMESTAMP(3) + INTERVAL ? MICROSECOND
+			WHERE id IN (%s);
+		`, placeholders)
+		if _, err := tx.tx.ExecContext(ctx, update, append([]any{lease.Microseconds()}, args...)...); err != nil {
+			return fmt.Errorf("error leasing webhook deliveries: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (s *MySQLStore) CompleteWebhookDelivery(ctx context.Context, id uint64) error {
+	const query = `
+		DELETE FROM webhook_delivery
+		WHERE id = ?;
+	`
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return classifyMySQLError(fmt.Errorf("error completing webhook delivery %d: %w", id, err))
+	}
+	return nil
+}
+
+func (s *MySQLStore) FailWebhookDelivery(ctx context.Context, id uint64, lastError string, retryIn time.Duration, dead bool) error {
+	const query = `
+		UPDATE webhook_delivery
+		SET attempts = attempts + 1,
+			last_error = ?,
+			next_attempt_at = CURRENT_TIMESTAMP(3) + INTERVAL ? MICROSECOND,
+			dead_at = IF(?, CURRENT_TIMESTAMP, NULL)
+		WHERE id = ?;
+	`
+	if _, err := s.db.ExecContext(ctx, query, lastError, retryIn.Microseconds(), dead, id); err != nil {
+		return classifyMySQLError(fmt.Errorf("error recording failed webhook delivery %d: %w", id, err))
+	}
+	return nil
+}
+
+func (s *MySQLStore) ListWebhookDeadLetters(ctx context.Context, afterID uint64, limit int) ([]WebhookDelivery, error) {
+	query := `
+		SELECT` + webhookDeliveryColumns + `
+		FROM webhook_delivery
+		WHERE dead_at IS NOT NULL
+			AND id > ?
+		ORDER BY id
+		LIMIT ?;
+	`
+	result, err := s.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying webhook dead letters: %w", err))
+	}
+	defer result.Close()
+
+	deliveries, err := scanWebhookDeliveries(result)
+	if err != nil {
+		return nil, classifyMySQLError(err)
+	}
+	return deliveries, nil
+}
+
+func (s *MySQLStore) RequeueWebhookDeadLetters(ctx context.Context, ids []uint64) (int, error) {
+	query := `
+		UPDATE webhook_delivery
+		SET dead_at = NULL,
+			attempts = 0,
+			next_attempt_at = CURRENT_TIMESTAMP(3)
+		WHERE dead_at IS NOT NULL`
+	var args []any
+	if len(ids) > 0 {
+		var placeholders string
+		placeholders, args = idList(ids)
+		query += fmt.Sprintf(`
+			AND id IN (%s)`, placeholders)
+	}
+
+	result, err := s.db.ExecContext(ctx, query+";", args...)
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error requeuing webhook dead letters: %w", err))
+	}
+	requeued, err := result.RowsAffected()
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error requeuing webhook dead letters: %w", err))
+	}
+	return int(requeued), nil
+}
+
+// likeCountSubquery recomputes the like count of user u from the decision table, a range over
+// idx_decision_recipient_like_created with the visibleLikeActor lookups of ListLikedYou
+const likeCountSubquery = `(
+			SELECT COUNT(*)
+			FROM decision d
+			WHERE d.recipient_user_id = u.id
+				AND d.liked_recipient = TRUE` + visibleLikeActor + `
+		)`
+
+// CheckLikeCounts walks the user primary key and recomputes every count with a plain consistent read,
+// so the decision table is never locked
+func (s *MySQLStore) CheckLikeCounts(ctx context.Context, afterUserID string, limit int) ([]LikeCount, error) {
+	query := `
+		SELECT
+			u.id,
+			COALESCE(ls.like_count, 0),
+			` + likeCountSubquery + `
+		FROM user u
+		LEFT JOIN like_stats ls ON ls.user_id = u.id
+		WHERE u.id > ?
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_deletion ud
+				WHERE ud.user_id = u.id
+			)
+		ORDER BY u.id
+		LIMIT ?;
+	`
+
+	result, err := s.db.QueryContext(ctx, query, afterUserID, limit)
+	if err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error querying like counts: %w", err))
+	}
+	defer result.Close()
+
+	var counts []LikeCount
+	for result.Next() {
+		var count LikeCount
+		if err := result.Scan(&count.UserID, &count.Cached, &count.Actual); err != nil {
+			return nil, classifyMySQLError(fmt.Errorf("error scanning like count: %w", err))
+		}
+		counts = append(counts, count)
+	}
+	if err := result.Err(); err != nil {
+		return nil, classifyMySQLError(fmt.Errorf("error iterating like counts: %w", err))
+	}
+
+	return counts, nil
+}
+
+// RepairLikeCount locks the like_stats row first, creating it if needed, so every decision updating it
+// either committed before or waits for the repair. The recount is the first consistent read of the transaction,
+// its snapshot is taken once the lock is held and sees every decision counted in the row
+func (s *MySQLStore) RepairLikeCount(ctx context.Context, userID string) (LikeCount, error) {
+	count := LikeCount{UserID: userID}
+	err := s.inTx(ctx, func(tx *mysqlTx) error {
+		const lock = `
+			INSERT INTO like_stats (user_id, like_count)
+			VALUES (?, 0)
+			ON DUPLICATE KEY UPDATE like_count = like_count;
+		`
+		if _, err := tx.tx.ExecContext(ctx, lock, userID); err != nil {
+			if isMySQLError(err, mysqlErrNoReferencedRow) {
+				return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, err)
+			}
+			return fmt.Errorf("error locking like_stats of %s: %w", userID, err)
+		}
+
+		recount := `
+			SELECT
+				ls.like_count,
+				` + likeCountSubquery + `
+			FROM user u
+			JOIN like_stats ls ON ls.user_id = u.id
+			WHERE u.id = ?;
+		`
+		if err := tx.tx.QueryRowContext(ctx, recount, userID).Scan(&count.Cached, &count.Actual); err != nil {
+			return fmt.Errorf("error recounting likes of %s: %w", userID, err)
+		}
+		if !count.Drifted() {
+			return nil
+		}
+
+		const repair = `
+			UPDATE like_stats
+			SET like_count = ?
+			WHERE user_id = ?;
+		`
+		if _, err := tx.tx.ExecContext(ctx, repair, count.Actual, userID); err != nil {
+			return fmt.Errorf("error repairing like_count of %s: %w", userID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return LikeCount{}, err
+	}
+	return count, nil
+}
+
+// InTx wraps fn in a database transaction, driver errors returned by fn are classified into domain errors
+func (s *MySQLStore) InTx(ctx context.Context, fn func(tx DecisionTx) error) error {
+	return s.inTx(ctx, func(tx *mysqlTx) error { return fn(tx) })
+}
+
+func (s *MySQLStore) inTx(ctx context.Context, fn func(tx *mysqlTx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return classifyMySQLError(fmt.Errorf("error beginning transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := fn(&mysqlTx{tx: tx}); err != nil {
+		return classifyMySQLError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return classifyMySQLError(fmt.Errorf("commit failed: %w", err))
+	}
+	return nil
+}
+
+// mysqlTx implements DecisionTx on top of a *sql.Tx
+type mysqlTx struct {
+	tx *sql.Tx
+}
+
+func (t *mysqlTx) GetDecision(ctx context.Context, actorID, recipientID string) (DecisionState, error) {
+	const query = `
+		SELECT
+			liked_recipient,
+			unmatched
+		FROM decision
+		WHERE actor_user_id = ?
+			AND recipient_user_id = ?
+	`
+
+	var liked, unmatched bool
+	err := t.tx.QueryRowContext(ctx, query, actorID, recipientID).Scan(&liked, &unmatched)
+	if err == sql.ErrNoRows {
+		return DecisionNone, nil
+	}
+	if err != nil {
+		return DecisionNone, fmt.Errorf("error getting decision (%s -> %s): %w", actorID, recipientID, err)
+	}
+	return decisionStateOf(true, liked, unmatched), nil
+}
+
+func (t *mysqlTx) UpsertDecision(ctx context.Context, actorID, recipientID string, liked, unmatched bool) error {
+	const query = `
+		INSERT INTO decision (actor_user_id, recipient_user_id, liked_recipient, unmatched)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			liked_recipient = VALUES(liked_recipient),
+			unmatched = VALUES(unmatched),
+			created_at = CURRENT_TIMESTAMP;
+	`
+	if _, err := t.tx.ExecContext(ctx, query, actorID, recipientID, liked, unmatched); err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return newUserNotFoundError(
+				"actor or recipient user not found",
+				map[string]string{"actor_user_id": actorID, "recipient_user_id": recipientID},
+				err,
+			)
+		}
+		return fmt.Errorf("error inserting decision (%s -> %s): %w", actorID, recipientID, err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) AppendDecisionEvent(ctx context.Context, event DecisionEvent) error {
+	const query = `
+		INSERT INTO decision_event (actor_user_id, recipient_user_id, liked_recipient, unmatched, matched)
+		VALUES (?, ?, ?, ?, ?);
+	`
+	if _, err := t.tx.ExecContext(ctx, query, event.ActorID, event.RecipientID, event.Liked, event.Unmatched, event.Matched); err != nil {
+		return fmt.Errorf("error appending decision event (%s -> %s): %w", event.ActorID, event.RecipientID, err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) AppendOutboxEvent(ctx context.Context, event OutboxEvent) error {
+	const query = `
+		INSERT INTO outbox_event (event_type, event_key, payload)
+		VALUES (?, ?, ?);
+	`
+	if _, err := t.tx.ExecContext(ctx, query, event.Type, event.Key, []byte(event.Payload)); err != nil {
+		return fmt.Errorf("error appending %s outbox event: %w", event.Type, err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) IncrementLikeCount(ctx context.Context, userID string) error {
+	const query = `
+		INSERT INTO like_stats (user_id, like_count)
+		VALUES (?, 1)
+		ON DUPLICATE KEY UPDATE like_count = like_count + 1;
+	`
+	if _, err := t.tx.ExecContext(ctx, query, userID); err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, err)
+		}
+		return fmt.Errorf("error incrementing like_count: %w", err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) DecrementLikeCount(ctx context.Context, userID string) error {
+	const query = `
+		UPDATE like_stats
+		SET like_count = GREATEST(like_count - 1, 0)
+		WHERE user_id = ?;
+	`
+	if _, err := t.tx.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("error decrementing like_count: %w", err)
+	}
+	return nil
+}
+
+// ChangeUserStats clamps every counter at zero, the columns are unsigned
+func (t *mysqlTx) ChangeUserStats(ctx context.Context, userID string, change UserStatsChange) error {
+	const query = `
+		INSERT INTO user_stats (user_id, new_like_count, match_count, pass_count)
+		VALUES (?, GREATEST(?, 0), GREATEST(?, 0), GREATEST(?, 0))
+		ON DUPLICATE KEY UPDATE
+			new_like_count = GREATEST(CAST(new_like_count AS SIGNED) + ?, 0),
+			match_count = GREATEST(CAST(match_count AS SIGNED) + ?, 0),
+			pass_count = GREATEST(CAST(pass_count AS SIGNED) + ?, 0);
+	`
+	_, err := t.tx.ExecContext(ctx, query, userID,
+		change.NewLikes, change.Matches, change.Passes,
+		change.NewLikes, change.Matches, change.Passes)
+	if err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, err)
+		}
+		return fmt.Errorf("error changing user_stats of %s: %w", userID, err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) HasLiked(ctx context.Context, actorID, recipientID string) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM decision
+			WHERE actor_user_id = ?
+				AND recipient_user_id = ?
+				AND liked_recipient = TRUE
+		) AS recipient_liked_actor;
+	`
+
+	var exists bool
+	if err := t.tx.QueryRowContext(ctx, query, actorID, recipientID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error checking if %s likes %s: %w", actorID, recipientID, err)
+	}
+	return exists, nil
+}
+
+// CreateMatch inserts one row per side, a repeated like keeps the original match time.
+// The kept rows are not affected, so the match is created only if a row was inserted
+func (t *mysqlTx) CreateMatch(ctx context.Context, userID, otherUserID string) (bool, error) {
+	const query = `
+		INSERT INTO user_match (user_id, matched_user_id)
+		VALUES (?, ?), (?, ?)
+		ON DUPLICATE KEY UPDATE user_id = user_id;
+	`
+	result, err := t.tx.ExecContext(ctx, query, userID, otherUserID, otherUserID, userID)
+	if err != nil {
+		return false, fmt.Errorf("error creating match (%s <-> %s): %w", userID, otherUserID, err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error creating match (%s <-> %s): %w", userID, otherUserID, err)
+	}
+	return inserted > 0, nil
+}
+
+func (t *mysqlTx) DeleteMatch(ctx context.Context, userID, otherUserID string) (bool, error) {
+	const query = `
+		DELETE FROM user_match
+		WHERE (user_id = ? AND matched_user_id = ?)
+			OR (user_id = ? AND matched_user_id = ?);
+	`
+	result, err := t.tx.ExecContext(ctx, query, userID, otherUserID, otherUserID, userID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting match (%s <-> %s): %w", userID, otherUserID, err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting match (%s <-> %s): %w", userID, otherUserID, err)
+	}
+	return deleted > 0, nil
+}
+
+// IsBlocked takes shared locks on both user_block entries of the pair, or the gaps they would be inserted in.
+// A block created concurrently then waits for this transaction, and this one waits for a block not yet committed
+func (t *mysqlTx) IsBlocked(ctx context.Context, userID, otherUserID string) (bool, error) {
+	const query = `
+		SELECT
+			COUNT(*)
+		FROM user_block
+		WHERE (blocker_user_id = ? AND blocked_user_id = ?)
+			OR (blocker_user_id = ? AND blocked_user_id = ?)
+		FOR SHARE;
+	`
+
+	var count int
+	if err := t.tx.QueryRowContext(ctx, query, userID, otherUserID, otherUserID, userID).Scan(&count); err != nil {
+		return false, fmt.Errorf("error checking block between %s and %s: %w", userID, otherUserID, err)
+	}
+	return count > 0, nil
+}
+
+// CreateBlock reports if the block is new, blocking again keeps the original block time
+func (t *mysqlTx) CreateBlock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	const query = `
+		INSERT INTO user_block (blocker_user_id, blocked_user_id)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE id = id;
+	`
+	result, err := t.tx.ExecContext(ctx, query, blockerID, blockedID)
+	if err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return false, newUserNotFoundError(
+				"blocker or blocked user not found",
+				map[string]string{"blocker_user_id": blockerID, "blocked_user_id": blockedID},
+				err,
+			)
+		}
+		return false, fmt.Errorf("error creating block (%s -> %s): %w", blockerID, blockedID, err)
+	}
+	// 1 for an insert, 0 for a duplicate left unchanged
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error creating block (%s -> %s): %w", blockerID, blockedID, err)
+	}
+	return inserted == 1, nil
+}
+
+func (t *mysqlTx) DeleteBlock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	const query = `
+		DELETE FROM user_block
+		WHERE blocker_user_id = ?
+			AND blocked_user_id = ?;
+	`
+	result, err := t.tx.ExecContext(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting block (%s -> %s): %w", blockerID, blockedID, err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting block (%s -> %s): %w", blockerID, blockedID, err)
+	}
+	return deleted > 0, nil
+}
+
+// GetUserModeration takes a shared lock on the user_moderation row, or the gap it would be inserted in,
+// so a decision and a sanction of the same user don't interleave
+func (t *mysqlTx) GetUserModeration(ctx context.Context, userID string) (UserModeration, error) {
+	const query = `
+		SELECT
+			warnings,
+			likes_hidden,
+			banned
+		FROM user_moderation
+		WHERE user_id = ?
+		FOR SHARE;
+	`
+
+	moderation := UserModeration{UserID: userID}
+	err := t.tx.QueryRowContext(ctx, query, userID).Scan(&moderation.Warnings, &moderation.LikesHidden, &moderation.Banned)
+	if err == sql.ErrNoRows {
+		return moderation, nil
+	}
+	if err != nil {
+		return UserModeration{}, fmt.Errorf("error getting moderation of %s: %w", userID, err)
+	}
+	return moderation, nil
+}
+
+func (t *mysqlTx) SaveUserModeration(ctx context.Context, moderation UserModeration) error {
+	const query = `
+		INSERT INTO user_moderation (user_id, warnings, likes_hidden, banned)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			warnings = VALUES(warnings),
+			likes_hidden = VALUES(likes_hidden),
+			banned = VALUES(banned);
+	`
+	_, err := t.tx.ExecContext(ctx, query, moderation.UserID, moderation.Warnings, moderation.LikesHidden, moderation.Banned)
+	if err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return newUserNotFoundError("user not found", map[string]string{"user_id": moderation.UserID}, err)
+		}
+		return fmt.Errorf("error saving moderation of %s: %w", moderation.UserID, err)
+	}
+	return nil
+}
+
+// UncountLikes updates every like_stats row, then every user_stats row, in a single statement each,
+// driven by the unique (actor, recipient) key of decision
+func (t *mysqlTx) UncountLikes(ctx context.Context, actorID string) error {
+	const newLikesQuery = `
+		UPDATE user_stats us
+		JOIN decision d ON d.recipient_user_id = us.user_id
+		SET us.new_like_count = us.new_like_count - 1
+		WHERE
+			d.actor_user_id = ?
+			AND d.liked_recipient = TRUE
+			AND us.new_like_count > 0
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_block b
+				WHERE
+					b.blocker_user_id = d.recipient_user_id
+					AND b.blocked_user_id = d.actor_user_id
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM decision rd
+				WHERE
+					rd.actor_user_id = d.recipient_user_id
+					AND rd.recipient_user_id = d.actor_user_id
+					AND (rd.liked_recipient = TRUE OR rd.unmatched = TRUE)
+			);
+	`
+	const query = `
+		UPDATE like_stats ls
+		JOIN decision d ON d.recipient_user_id = ls.user_id
+		SET ls.like_count = ls.like_count - 1
+		WHERE
+			d.actor_user_id = ?
+			AND d.liked_recipient = TRUE
+			AND ls.like_count > 0
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_block b
+				WHERE
+					b.blocker_user_id = d.recipient_user_id
+					AND b.blocked_user_id = d.actor_user_id
+			);
+	`
+	if _, err := t.tx.ExecContext(ctx, query, actorID); err != nil {
+		return fmt.Errorf("error uncounting likes of %s: %w", actorID, err)
+	}
+	if _, err := t.tx.ExecContext(ctx, newLikesQuery, actorID); err != nil {
+		return fmt.Errorf("error uncounting new likes of %s: %w", actorID, err)
+	}
+	return nil
+}
+
+func (t *mysqlTx) CreateReport(ctx context.Context, report Report) (uint64, error) {
+	const query = `
+		INSERT INTO user_report (reporter_user_id, reported_user_id, reason, details, status)
+		VALUES (?, ?, ?, ?, ?);
+	`
+	result, err := t.tx.ExecContext(ctx, query, report.ReporterID, report.ReportedID, report.Reason, report.Details, report.Status)
+	if err != nil {
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return 0, newUserNotFoundError(
+				"reporter or reported user not found",
+				map[string]string{"reporter_user_id": report.ReporterID, "reported_user_id": report.ReportedID},
+				err,
+			)
+		}
+		return 0, fmt.Errorf("error creating report (%s -> %s): %w", report.ReporterID, report.ReportedID, err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error creating report (%s -> %s): %w", report.ReporterID, report.ReportedID, err)
+	}
+	return uint64(id), nil
+}
+
+// GetReport only locks the user_report row, the joined rows are read without locks
+func (t *mysqlTx) GetReport(ctx context.Context, reportID uint64) (Report, bool, error) {
+	statement := reportSelect + `
+		WHERE r.id = ?
+		FOR UPDATE OF r;
+	`
+	report, err := scanReport(t.tx.QueryRowContext(ctx, statement, reportID))
+	if err == sql.ErrNoRows {
+		return Report{}, false, nil
+	}
+	if err != nil {
+		return Report{}, false, fmt.Errorf("error getting report %d: %w", reportID, err)
+	}
+	return report, true, nil
+}
+
+func (t *mysqlTx) UpdateReport(ctx context.Context, report Report) error {
+	const query = `
+		UPDATE user_report
+		SET status = ?,
+			moderator_id = ?,
+			outcome = ?,
+			resolution_note = ?
+		WHERE id = ?;
+	`
+	_, err := t.tx.ExecContext(ctx, query, report.Status, report.ModeratorID, report.Outcome, report.ResolutionNote, report.ID)
+	if err != nil {
+		return fmt.Errorf("error updating report %d: %w", report.ID, err)
+	}
+	return nil
+}
+
+// IsUserDeleted takes a shared lock on the user_deletion row, or the gap it would be inserted in,
+// so a deletion requested concurrently waits for this transaction
+func (t *mysqlTx) IsUserDeleted(ctx context.Context, userID string) (bool, error) {
+	const query = `
+		SELECT
+			COUNT(*)
+		FROM user_deletion
+		WHERE user_id = ?
+		FOR SHARE;
+	`
+
+	var count int
+	if err := t.tx.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return false, fmt.Errorf("error checking deletion of %s: %w", userID, err)
+	}
+	return count > 0, nil
+}
+
+// CreateUserDeletion locks the user_deletion row first, two concurrent requests then conflict
+// on insert and the retried one returns the deletion of the other
+func (t *mysqlTx) CreateUserDeletion(ctx context.Context, userID string) (UserDeletion, error) {
+	lock := `
+		SELECT` + userDeletionColumns + `
+		FROM user_deletion
+		WHERE user_id = ?
+		FOR UPDATE;
+	`
+	deletion, err := scanUserDeletion(t.tx.QueryRowContext(ctx, lock, userID))
+	if err == nil {
+		return deletion, nil
+	}
+	if err != sql.ErrNoRows {
+		return UserDeletion{}, fmt.Errorf("error getting deletion of %s: %w", userID, err)
+	}
+
+	// user_deletion has no foreign key since it outlives the user, the user is checked by the insert
+	const insert = `
+		INSERT INTO user_deletion (user_id, step)
+		SELECT id, ?
+		FROM user
+		WHERE id = ?;
+	`
+	result, err := t.tx.ExecContext(ctx, insert, DeletionDecisionsMade, userID)
+	if err != nil {
+		return UserDeletion{}, fmt.Errorf("error creating deletion of %s: %w", userID, err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return UserDeletion{}, fmt.Errorf("error creating deletion of %s: %w", userID, err)
+	}
+	if inserted == 0 {
+		return UserDeletion{}, newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+
+	deletion, err = scanUserDeletion(t.tx.QueryRowContext(ctx, lock, userID))
+	if err != nil {
+		return UserDeletion{}, fmt.Errorf("error getting deletion of %s: %w", userID, err)
+	}
+	return deletion, nil
+}
+
+// LockPendingUserDeletion seeks idx_user_deletion_pending. SKIP LOCKED lets erasers of several instances
+// work on different deletions, the chunks of one deletion still run one at a time
+func (t *mysqlTx) LockPendingUserDeletion(ctx context.Context) (UserDeletion, bool, error) {
+	query := `
+		SELECT` + userDeletionColumns + `
+		FROM user_deletion
+		WHERE completed_at IS NULL
+		ORDER BY requested_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED;
+	`
+	deletion, err := scanUserDeletion(t.tx.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return UserDeletion{}, false, nil
+	}
+	if err != nil {
+		return UserDeletion{}, false, fmt.Errorf("error locking pending user deletion: %w", err)
+	}
+	return deletion, true, nil
+}
+
+func (t *mysqlTx) SaveUserDeletion(ctx context.Context, deletion UserDeletion) error {
+	const query = `
+		UPDATE user_deletion
+		SET step = ?,
+			decisions_deleted = ?,
+			like_counts_repaired = ?,
+			decision_events_deleted = ?,
+			matches_deleted = ?,
+			blocks_deleted = ?,
+			reports_deleted = ?,
+			completed_at = IF(?, CURRENT_TIMESTAMP, NULL)
+		WHERE user_id = ?;
+	`
+	_, err := t.tx.ExecContext(ctx, query, deletion.Step, deletion.DecisionsDeleted, deletion.LikeCountsRepaired,
+		deletion.DecisionEventsDeleted, deletion.MatchesDeleted, deletion.BlocksDeleted, deletion.ReportsDeleted,
+		deletion.Completed(), deletion.UserID)
+	if err != nil {
+		return fmt.Errorf("error saving deletion of %s: %w", deletion.UserID, err)
+	}
+	return nil
+}
+
+// DeleteActorDecisions locks a range of the unique (actor, recipient) key and deletes it
+func (t *mysqlTx) DeleteActorDecisions(ctx context.Context, actorID string, limit int) ([]DecisionInput, error) {
+	const query = `
+		SELECT
+			recipient_user_id,
+			liked_recipient
+		FROM decision
+		WHERE actor_user_id = ?
+		ORDER BY recipient_user_id
+		LIMIT ?
+		FOR UPDATE;
+	`
+
+	result, err := t.tx.QueryContext(ctx, query, actorID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error locking decisions of %s: %w", actorID, err)
+	}
+	defer result.Close()
+
+	var decisions []DecisionInput
+	var recipients []string
+	for result.Next() {
+		var decision DecisionInput
+		if err := result.Scan(&decision.RecipientID, &decision.Liked); err != nil {
+			return nil, fmt.Errorf("error scanning decision of %s: %w", actorID, err)
+		}
+		decisions = append(decisions, decision)
+		recipients = append(recipients, decision.RecipientID)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decisions of %s: %w", actorID, err)
+	}
+	if len(decisions) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := idList(recipients)
+	statement := fmt.Sprintf(`
+		DELETE FROM decision
+		WHERE actor_user_id = ?
+			AND recipient_user_id IN (%s);
+	`, placeholders)
+	if _, err := t.tx.ExecContext(ctx, statement, append([]any{actorID}, args...)...); err != nil {
+		return nil, fmt.Errorf("error deleting decisions of %s: %w", actorID, err)
+	}
+	return decisions, nil
+}
+
+// DeleteUserMatches locks a chunk of the user's side of the matches on the unique (user, matched user) key,
+// then deletes both sides of each
+func (t *mysqlTx) DeleteUserMatches(ctx context.Context, userID string, limit int) ([]string, error) {
+	const query = `
+		SELECT
+			matched_user_id
+		FROM user_match
+		WHERE user_id = ?
+		ORDER BY matched_user_id
+		LIMIT ?
+		FOR UPDATE;
+	`
+
+	rows, err := t.tx.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error locking matches of %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var matched []string
+	for rows.Next() {
+		var matchedUserID string
+		if err := rows.Scan(&matchedUserID); err != nil {
+			return nil, fmt.Errorf("error scanning match of %s: %w", userID, err)
+		}
+		matched = append(matched, matchedUserID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matches of %s: %w", userID, err)
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	placeholders, matchedArgs := idList(matched)
+	statement := fmt.Sprintf(`
+		DELETE FROM user_match
+		WHERE (user_id = ? AND matched_user_id IN (%[1]s))
+			OR (matched_user_id = ? AND user_id IN (%[1]s));
+	`, placeholders)
+	args := append(append([]any{userID}, matchedArgs...), userID)
+	args = append(args, matchedArgs...)
+	if _, err := t.tx.ExecContext(ctx, statement, args...); err != nil {
+		return nil, fmt.Errorf("error deleting matches of %s: %w", userID, err)
+	}
+	return matched, nil
+}
+
+// userRowColumns are the columns referencing the user whose rows each DeleteUserRows step deletes.
+// Every one is the first column of an index, the ones InnoDB creates for foreign keys included, so each chunk is an index range.
+// outbox_event.actor_user_id and the webhook_delivery columns are generated from the JSON of the event
+var userRowColumns = map[DeletionStep][]string{
+	DeletionDecisionsReceived: {"decision.recipient_user_id"},
+	DeletionDecisionEvents:    {"decision_event.actor_user_id", "decision_event.recipient_user_id"},
+	DeletionBlocks:            {"user_block.blocker_user_id", "user_block.blocked_user_id"},
+	DeletionReports:           {"user_report.reporter_user_id", "user_report.reported_user_id"},
+	DeletionOutbox: {
+		"idempotency_key.recipient_user_id",
+		"outbox_event.event_key", "outbox_event.actor_user_id",
+		"webhook_delivery.event_key", "webhook_delivery.actor_user_id",
+	},
+}
+
+func (t *mysqlTx) DeleteUserRows(ctx context.Context, userID string, step DeletionStep, limit int) (int, error) {
+	columns, ok := userRowColumns[step]
+	if !ok {
+		return 0, fmt.Errorf("error deleting rows of %s: unsupported step %s", userID, step)
+	}
+
+	deleted := 0
+	for _, column := range columns {
+		if deleted == limit {
+			break
+		}
+		table, _, _ := strings.Cut(column, ".")
+		statement := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE %s = ?
+		LIMIT ?;
+	`, table, column)
+		result, err := t.tx.ExecContext(ctx, statement, userID, limit-deleted)
+		if err != nil {
+			return 0, fmt.Errorf("error deleting %s rows of %s: %w", table, userID, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("error deleting %s rows of %s: %w", table, userID, err)
+		}
+		deleted += int(affected)
+	}
+	return deleted, nil
+}
+
+// DeleteUserAccount deletes the rows left by the other steps, the user row last since they reference it
+func (t *mysqlTx) DeleteUserAccount(ctx context.Context, userID string) error {
+	statements := []string{
+		`DELETE FROM like_stats WHERE user_id = ?;`,
+		`DELETE FROM user_stats WHERE user_id = ?;`,
+		`DELETE FROM user_moderation WHERE user_id = ?;`,
+		`DELETE FROM idempotency_key WHERE actor_user_id = ?;`,
+		`DELETE FROM user WHERE id = ?;`,
+	}
+	for _, statement := range statements {
+		if _, err := t.tx.ExecContext(ctx, statement, userID); err != nil {
+			return fmt.Errorf("error deleting account of %s: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// idList returns the placeholders and arguments of an IN (...) list of ids, ids must not be empty
+func idList[T uint64 | string](ids []T) (string, []any) {
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return strings.Repeat(", ?", len(ids))[2:], args
+}
+
+// GetIdempotencyRecord locks the idempotency_key row, or the gap it would be inserted in. Two concurrent first calls
+// with the same key then deadlock on insert and one of them is retried, finding the outcome of the other
+func (t *mysqlTx) GetIdempotencyRecord(ctx context.Context, actorID, key string) (IdempotencyRecord, bool, error) {
+	const query = `
+		SELECT
+			recipient_user_id,
+			liked_recipient,
+			mutual_likes
+		FROM idempotency_key
+		WHERE actor_user_id = ?
+			AND idempotency_key = ?
+			AND expires_at > CURRENT_TIMESTAMP
+		FOR UPDATE;
+	`
+
+	record := IdempotencyRecord{ActorID: actorID, Key: key}
+	err := t.tx.QueryRowContext(ctx, query, actorID, key).Scan(&record.RecipientID, &record.Liked, &record.MutualLikes)
+	if err == sql.ErrNoRows {
+		return IdempotencyRecord{}, false, nil
+	}
+	if err != nil {
+		return IdempotencyRecord{}, false, fmt.Errorf("error getting idempotency key of %s: %w", actorID, err)
+	}
+	return record, true, nil
+}
+
+// SaveIdempotencyRecord only hits a duplicate key on an expired key, which is overwritten
+func (t *mysqlTx) SaveIdempotencyRecord(ctx context.Context, record IdempotencyRecord, ttl time.Duration) error {
+	const query = `
+		INSERT INTO idempotency_key (actor_user_id, idempotency_key, recipient_user_id, liked_recipient, mutual_likes, expires_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP + INTERVAL ? SECOND)
+		ON DUPLICATE KEY UPDATE
+			recipient_user_id = VALUES(recipient_user_id),
+			liked_recipient = VALUES(liked_recipient),
+			mutual_likes = VALUES(mutual_likes),
+			created_at = CURRENT_TIMESTAMP,
+			expires_at = VALUES(expires_at);
+	`
+	_, err := t.tx.ExecContext(ctx, query,
+		record.ActorID, record.Key, record.RecipientID, record.Liked, record.MutualLikes, int64(ttl.Seconds()))
+	if err != nil {
+		return fmt.Errorf("error saving idempotency key of %s: %w", record.ActorID, err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvents seeks idx_outbox_event_published_id to the unpublished events. FOR UPDATE without SKIP LOCKED
+// on purpose: skipping would let two relays claim events of a key out of order. The locks are only held
+// until the lease is written, the batch is published after the commit
+func (s *MySQLStore) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	var events []OutboxEvent
+	err := s.inTx(ctx, func(tx *mysqlTx) error {
+		const query = `
+			SELECT
+				id,
+				event_type,
+				event_key,
+				payload,
+				UNIX_TIMESTAMP(created_at),
+				COALESCE(claimed_until > CURRENT_TIMESTAMP(3), FALSE)
+			FROM outbox_event
+			WHERE published_at IS NULL
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE;
+		`
+		result, err := tx.tx.QueryContext(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("error claiming outbox events: %w", err)
+		}
+		defer result.Close()
+
+		leased := false
+		for result.Next() {
+			var event OutboxEvent
+			var payload []byte
+			var claimed bool
+			if err := result.Scan(&event.ID, &event.Type, &event.Key, &payload, &event.UnixTimestamp, &claimed); err != nil {
+				return fmt.Errorf("error scanning outbox event: %w", err)
+			}
+			event.Payload = payload
+			events = append(events, event)
+			leased = leased || claimed
+		}
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("error iterating outbox events: %w", err)
+		}
+
+		// another relay is publishing the first events, wait for its turn to end
+		if leased || len(events) == 0 {
+			events = nil
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		placeholders, args := idList(ids)
+		update := fmt.Sprintf(`
+			UPDATE outbox_event
+			SET claimed_until = CURRENT_TIMESTAMP(3) + INTERVAL ? MICROSECOND
+			WHERE id IN (%s);
+		`, placeholders)
+		if _, err := tx.tx.ExecContext(ctx, update, append([]any{lease.Microseconds()}, args...)...); err != nil {
+			return fmt.Errorf("error leasing outbox events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *MySQLStore) MarkOutboxEventsPublished(ctx context.Context, ids []uint64) error {
+	placeholders, args := idList(ids)
+	query := fmt.Sprintf(`
+		UPDATE outbox_event
+		SET published_at = CURRENT_TIMESTAMP,
+			claimed_until = NULL
+		WHERE id IN (%s);
+	`, placeholders)
+
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return classifyMySQLError(fmt.Errorf("error marking outbox events as published: %w", err))
+	}
+	return nil
+}
+
+func (s *MySQLStore) ReleaseOutboxEvents(ctx context.Context, ids []uint64) error {
+	placeholders, args := idList(ids)
+	query := fmt.Sprintf(`
+		UPDATE outbox_event
+		SET claimed_until = NULL
+		WHERE id IN (%s)
+			AND published_at IS NULL;
+	`, placeholders)
+
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return classifyMySQLError(fmt.Errorf("error releasing outbox events: %w", err))
+	}
+	return nil
+}
+
+// DeletePublishedOutboxEvents is a range over idx_outbox_event_published_id, pending events have a NULL published_at
+func (s *MySQLStore) DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration, limit int) (int, error) {
+	const query = `
+		DELETE FROM outbox_event
+		WHERE published_at IS NOT NULL
+			AND published_at < CURRENT_TIMESTAMP - INTERVAL ? SECOND
+		LIMIT ?;
+	`
+	result, err := s.db.ExecContext(ctx, query, int64(retention.Seconds()), limit)
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error deleting published outbox events: %w", err))
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error deleting published outbox events: %w", err))
+	}
+	return int(deleted), nil
+}
+
+// DeleteExpiredIdempotencyKeys uses idx_idempotency_key_expires
+func (s *MySQLStore) DeleteExpiredIdempotencyKeys(ctx context.Context, limit int) (int, error) {
+	const query = `
+		DELETE FROM idempotency_key
+		WHERE expires_at <= CURRENT_TIMESTAMP
+		LIMIT ?;
+	`
+	result, err := s.db.ExecContext(ctx, query, limit)
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error deleting expired idempotency keys: %w", err))
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, classifyMySQLError(fmt.Errorf("error deleting expired idempotency keys: %w", err))
+	}
+	return int(deleted), nil
+}
+
+// isMySQLError reports if err is a MySQL server error with one of the given numbers
+func isMySQLError(err error, numbers ...uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range numbers {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyMySQLError wraps driver errors into a DomainError of the matching kind.
+// Errors that already are domain errors, or come from the context, are returned unchanged
+func classifyMySQLError(err error) error {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var netErr net.Error
+	switch {
+	case isMySQLError(err, mysqlErrDeadlock, mysqlErrLockWaitTimeout):
+		return &DomainError{Kind: ErrAborted, Reason: ReasonTransactionConflict, Message: "transaction conflict, retry the request", Err: err}
+	case isMySQLError(err, mysqlErrTooManyConnections, mysqlErrServerShutdown),
+		errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, mysql.ErrInvalidConn),
+		errors.Is(err, sql.ErrConnDone),
+		errors.As(err, &netErr):
+		return &DomainError{Kind: ErrUnavailable, Reason: ReasonStorageUnavailable, Message: "storage unavailable", Err: err}
+	default:
+		return &DomainError{Kind: ErrInternal, Reason: ReasonInternal, Message: "internal error", Err: err}
+	}
+}
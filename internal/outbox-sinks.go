@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// WriterSink writes every event as a JSON line, e.g. to stdout or an append-only file
+type WriterSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterSink creates a sink writing newline delimited JSON to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *WriterSink) Publish(ctx context.Context, event OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.encoder.Encode(event); err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+	return nil
+}
+
+// Broker is an in-process EventSink, it keeps every published event and forwards them to its subscribers.
+// It is meant for tests and local development
+type Broker struct {
+	mu          sync.Mutex
+	events      []OutboxEvent
+	subscribers []chan OutboxEvent
+}
+
+// NewBroker creates an empty broker
+func NewBroker() *Broker {
+	return &Broker{}
+}
+
+// Publish blocks until every subscriber took the event, or ctx is done
+func (b *Broker) Publish(ctx context.Context, event OutboxEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	b.events = append(b.events, event)
+	return nil
+}
+
+// Subscribe returns a channel receiving the events published from now on
+func (b *Broker) Subscribe(buffer int) <-chan OutboxEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscriber := make(chan OutboxEvent, buffer)
+	b.subscribers = append(b.subscribers, subscriber)
+	return subscriber
+}
+
+// Events returns a copy of every event published so far, in publishing order
+func (b *Broker) Events() []OutboxEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]OutboxEvent(nil), b.events...)
+}
+
+// MultiSink publishes every event to each of its sinks in order. When one fails the event is retried on all of them,
+// so the sinks before it may get the event twice
+type MultiSink []EventSink
+
+func (m MultiSink) Publish(ctx context.Context, event OutboxEvent) error {
+	for _, sink := range m {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Domain event types written to the outbox
+const (
+	EventTypeLikeReceived = "LikeReceived"
+	EventTypePassRecorded = "PassRecorded"
+	EventTypeMatchCreated = "MatchCreated"
+)
+
+// OutboxEvent is a domain event waiting in the outbox_event table to be relayed to an EventSink
+type OutboxEvent struct {
+	ID            uint64          `json:"id"`   // increasing, consumers can use it to drop duplicates
+	Type          string          `json:"type"` // one of the EventType constants
+	Key           string          `json:"key"`  // recipient user id, events with the same key are relayed in order
+	Payload       json.RawMessage `json:"payload"`
+	UnixTimestamp uint64          `json:"unix_timestamp"`
+}
+
+// DecisionEventPayload is the payload of every decision domain event
+type DecisionEventPayload struct {
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	Unmatched       bool   `json:"unmatched,omitempty"` // PassRecorded by Unmatch
+}
+
+// mentionsUser reports if userID is the recipient or the actor of the event
+func (e OutboxEvent) mentionsUser(userID string) bool {
+	if e.Key == userID {
+		return true
+	}
+	var payload DecisionEventPayload
+	return json.Unmarshal(e.Payload, &payload) == nil && payload.ActorUserID == userID
+}
+
+// newDecisionOutboxEvent builds an event about the decision of actor over recipient,
+// the store assigns its ID and UnixTimestamp
+func newDecisionOutboxEvent(eventType string, payload DecisionEventPayload) (OutboxEvent, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return OutboxEvent{}, fmt.Errorf("error encoding %s event: %w", eventType, err)
+	}
+	return OutboxEvent{Type: eventType, Key: payload.RecipientUserID, Payload: encoded}, nil
+}
+
+// appendDecisionOutboxEvents writes the domain events of a recorded decision: LikeReceived or PassRecorded,
+// followed by MatchCreated when the like created a match
+func appendDecisionOutboxEvents(ctx context.Context, tx DecisionTx, decision DecisionEvent) error {
+	payload := DecisionEventPayload{
+		ActorUserID:     decision.ActorID,
+		RecipientUserID: decision.RecipientID,
+		Unmatched:       decision.Unmatched,
+	}
+
+	eventTypes := []string{EventTypePassRecorded}
+	if decision.Liked {
+		eventTypes = []string{EventTypeLikeReceived}
+	}
+	if decision.Matched {
+		eventTypes = append(eventTypes, EventTypeMatchCreated)
+	}
+
+	for _, eventType := range eventTypes {
+		event, err := newDecisionOutboxEvent(eventType, payload)
+		if err != nil {
+			return err
+		}
+		if err := tx.AppendOutboxEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventSink receives the events relayed from the outbox
+type EventSink interface {
+	// Publish must only return nil once the event is durably handed over,
+	// the event is retried otherwise and may be published more than once
+	Publish(ctx context.Context, event OutboxEvent) error
+}
+
+// SinkFunc adapts a function to the EventSink interface
+type SinkFunc func(ctx context.Context, event OutboxEvent) error
+
+func (f SinkFunc) Publish(ctx context.Context, event OutboxEvent) error {
+	return f(ctx, event)
+}
+
+// OutboxRelayConfig holds the polling settings of OutboxRelay
+type OutboxRelayConfig struct {
+	PollInterval time.Duration // delay between polls when the outbox is drained
+	BatchSize    int           // events claimed and published per batch
+	Lease        time.Duration // time a relay has to publish a claimed batch before another relay can claim it
+}
+
+// DefaultOutboxRelayConfig returns the settings used when nothing is configured
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        time.Minute,
+	}
+}
+
+// OutboxRelay publishes the outbox events to an EventSink with at-least-once delivery.
+// Pending events are claimed in id order, and relays running on several server instances take turns
+// instead of publishing the same events concurrently. A batch stops at the first event the sink
+// fails to publish, later events wait for it, which keeps the events of every key in order
+type OutboxRelay struct {
+	store  OutboxStore
+	sink   EventSink
+	config OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a relay from the store outbox to the sink
+func NewOutboxRelay(store OutboxStore, sink EventSink, config OutboxRelayConfig) *OutboxRelay {
+	if config.Lease <= 0 {
+		config.Lease = DefaultOutboxRelayConfig().Lease
+	}
+	return &OutboxRelay{store: store, sink: sink, config: config}
+}
+
+// Run relays the outbox until ctx is done
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches are published, wait for the next tick otherwise
+		published, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("error relaying outbox events: %v", err)
+		}
+		if err == nil && published == r.config.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes a single batch of pending events and returns how many were published.
+// The batch is claimed first and published without holding any store lock. Events published
+// before a failure are still marked, so they are not published again, the others are released
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	// 1. Claim the batch
+	events, err := r.store.ClaimOutboxEvents(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	// 2. Publish it in order
+	var publishErr error
+	ids := make([]uint64, 0, len(events))
+	for _, event := range events {
+		if err := r.sink.Publish(ctx, event); err != nil {
+			publishErr = fmt.Errorf("error publishing outbox event %d: %w", event.ID, err)
+			break
+		}
+		ids = append(ids, event.ID)
+	}
+
+	// 3. Mark the published events and hand the others back
+	if len(ids) > 0 {
+		if err := r.store.MarkOutboxEventsPublished(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+	if len(ids) < len(events) {
+		var released []uint64
+		for _, event := range events[len(ids):] {
+			released = append(released, event.ID)
+		}
+		if err := r.store.ReleaseOutboxEvents(ctx, released); err != nil {
+			return len(ids), errors.Join(publishErr, err)
+		}
+	}
+
+	return len(ids), publishErr
+}
+
+// DefaultOutboxRetention is how long published events are kept before PurgePublishedOutboxEvents deletes them
+const DefaultOutboxRetention = 7 * 24 * time.Hour
+
+// PurgePublishedOutboxEvents deletes the events published more than retention ago every interval
+// until ctx is done. Published events are never read again, this only keeps the table small
+func PurgePublishedOutboxEvents(ctx context.Context, store OutboxStore, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			deleted, err := store.DeletePublishedOutboxEvents(ctx, retention, purgeBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("error purging published outbox events: %v", err)
+				}
+				break
+			}
+			if deleted < purgeBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypesOf(events []OutboxEvent) []string {
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestOutboxRelay_PublishesDecisionEventsInOrder(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b")
+	broker := NewBroker()
+	relay := NewOutboxRelay(store, broker, DefaultOutboxRelayConfig())
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+	require.NoError(t, business.Unmatch(ctx, "a", "b"))
+
+	published, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, published)
+
+	events := broker.Events()
+	assert.Equal(t, []string{EventTypeLikeReceived, EventTypeLikeReceived, EventTypeMatchCreated, EventTypePassRecorded}, eventTypesOf(events))
+	assert.Equal(t, "a", events[2].Key)
+
+	var payload DecisionEventPayload
+	require.NoError(t, json.Unmarshal(events[3].Payload, &payload))
+	assert.Equal(t, DecisionEventPayload{ActorUserID: "a", RecipientUserID: "b", Unmatched: true}, payload)
+
+	// published events are not relayed twice
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestOutboxRelay_RetriesFromTheFirstFailure(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+	broker := NewBroker()
+
+	failing := true
+	sink := SinkFunc(func(ctx context.Context, event OutboxEvent) error {
+		if failing && event.ID == 2 {
+			return errors.New("sink down")
+		}
+		return broker.Publish(ctx, event)
+	})
+	relay := NewOutboxRelay(store, sink, DefaultOutboxRelayConfig())
+
+	for _, actor := range []string{"a", "b", "c"} {
+		recipient := "b"
+		if actor == "b" {
+			recipient = "c"
+		}
+		_, err := business.RecordDecision(ctx, actor, recipient, false)
+		require.NoError(t, err)
+	}
+
+	// the first event is published, the ones after the failure wait
+	published, err := relay.RelayOnce(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, published)
+
+	failing = false
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	var ids []uint64
+	for _, event := range broker.Events() {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+}
+
+func TestRecordDecision_FailedDecisionLeavesNoOutboxEvent(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a")
+	broker := NewBroker()
+
+	_, err := business.RecordDecision(ctx, "a", "ghost", true)
+	require.Error(t, err)
+
+	published, err := NewOutboxRelay(store, broker, DefaultOutboxRelayConfig()).RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestWriterSink_WritesJSONLines(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := NewWriterSink(&buffer)
+
+	err := sink.Publish(context.Background(), OutboxEvent{
+		ID:            7,
+		Type:          EventTypeLikeReceived,
+		Key:           "b",
+		Payload:       json.RawMessage(`{"actor_user_id":"a","recipient_user_id":"b"}`),
+		UnixTimestamp: 1700000000,
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"id": 7,
+		"type": "LikeReceived",
+		"key": "b",
+		"payload": {"actor_user_id": "a", "recipient_user_id": "b"},
+		"unix_timestamp": 1700000000
+	}`, buffer.String())
+	assert.Equal(t, byte('\n'), buffer.Bytes()[buffer.Len()-1])
+}
+
+func TestOutboxRelay_MySQLClaimsThenMarksEvents(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	broker := NewBroker()
+	relay := NewOutboxRelay(NewMySQLStore(&DB{db}), broker, OutboxRelayConfig{BatchSize: 10, Lease: time.Second})
+
+	// the batch is leased in its own transaction, published after the commit
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM outbox_event\s+WHERE published_at IS NULL\s+ORDER BY id\s+LIMIT \?\s+FOR UPDATE`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "event_key", "payload", "unix_timestamp", "claimed"}).
+			AddRow(4, EventTypeLikeReceived, "actor2", []byte(`{}`), 1700000000, false).
+			AddRow(5, EventTypeMatchCreated, "actor2", []byte(`{}`), 1700000000, false))
+	mock.ExpectExec(`UPDATE outbox_event\s+SET claimed_until = CURRENT_TIMESTAMP\(3\) \+ INTERVAL \? MICROSECOND\s+WHERE id IN \(\?, \?\)`).
+		WithArgs(int64(1000000), 4, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectExec(`UPDATE outbox_event\s+SET published_at = CURRENT_TIMESTAMP,\s+claimed_until = NULL\s+WHERE id IN \(\?, \?\)`).
+		WithArgs(4, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	published, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{EventTypeLikeReceived, EventTypeMatchCreated}, eventTypesOf(broker.Events()))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_MySQLWaitsForAnotherRelayLease(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	broker := NewBroker()
+	relay := NewOutboxRelay(NewMySQLStore(&DB{db}), broker, OutboxRelayConfig{BatchSize: 10})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM outbox_event\s+WHERE published_at IS NULL`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "event_key", "payload", "unix_timestamp", "claimed"}).
+			AddRow(4, EventTypeLikeReceived, "actor2", []byte(`{}`), 1700000000, true).
+			AddRow(5, EventTypeMatchCreated, "actor2", []byte(`{}`), 1700000000, false))
+	mock.ExpectCommit()
+
+	published, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, broker.Events())
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_PublishesWithoutHoldingTheStore(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+
+	// a sink writing decisions would deadlock if the store stayed locked while publishing
+	var events []OutboxEvent
+	sink := SinkFunc(func(ctx context.Context, event OutboxEvent) error {
+		events = append(events, event)
+		_, err := business.RecordDecision(ctx, "c", "b", true)
+		return err
+	})
+	relay := NewOutboxRelay(store, sink, DefaultOutboxRelayConfig())
+
+	published, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	// the events claimed by a relay are left alone until it is done with them
+	claimed, err := store.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	require.NoError(t, store.ReleaseOutboxEvents(ctx, []uint64{claimed[0].ID}))
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []uint64{1, 2}, []uint64{events[0].ID, events[1].ID})
+}
+
+func TestPurgePublishedOutboxEvents_KeepsPendingAndRecentEvents(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b")
+
+	clock := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return clock }
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	published, err := NewOutboxRelay(store, NewBroker(), DefaultOutboxRelayConfig()).RelayOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+
+	clock = clock.Add(48 * time.Hour)
+	_, err = business.RecordDecision(ctx, "b", "a", false)
+	require.NoError(t, err)
+
+	deleted, err := store.DeletePublishedOutboxEvents(ctx, 72*time.Hour, 10)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = store.DeletePublishedOutboxEvents(ctx, 24*time.Hour, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	// the pending event is still relayed
+	claimed, err := store.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{EventTypePassRecorded}, eventTypesOf(claimed))
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const paginationTokenVersion = "v1"
+
+// pageCursor is the position of the last item returned in a page
+type pageCursor struct {
+	Timestamp  uint64   `json:"ts,omitempty"`
+	ID         uint64   `json:"id"`
+	Descending bool     `json:"d,omitempty"` // sort order the page was listed with
+	Skipped    []uint64 `json:"k,omitempty"` // WatchLikes event ids below ID that may still commit
+}
+
+// tokenPayload is the signed content of a pagination token
+type tokenPayload struct {
+	Endpoint  string     `json:"e"`
+	Subject   string     `json:"s"`
+	Cursor    pageCursor `json:"c"`
+	ExpiresAt int64      `json:"x"`
+}
+
+// TokenSigner issues and verifies opaque pagination tokens.
+// A token has the form v1.<base64url payload>.<base64url HMAC-SHA256 of the payload>
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewTokenSigner creates a TokenSigner, tokens are valid for ttl after being issued
+func NewTokenSigner(secret []byte, ttl time.Duration) *TokenSigner {
+	return &TokenSigner{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Encode issues a token for the cursor, bound to the endpoint and subject (usually the recipient id)
+func (s *TokenSigner) Encode(endpoint, subject string, cursor pageCursor) (string, error) {
+	payload, err := json.Marshal(tokenPayload{
+		Endpoint:  endpoint,
+		Subject:   subject,
+		Cursor:    cursor,
+		ExpiresAt: s.now().Add(s.ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding pagination token: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(s.sign(encodedPayload))
+	return paginationTokenVersion + "." + encodedPayload + "." + signature, nil
+}
+
+// Decode verifies the token and returns its cursor. Any failed check is reported as ErrInvalidPaginationToken
+func (s *TokenSigner) Decode(token, endpoint, subject string) (pageCursor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != paginationTokenVersion {
+		return pageCursor{}, fmt.Errorf("%w: malformed token", ErrInvalidPaginationToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, s.sign(parts[1])) {
+		return pageCursor{}, fmt.Errorf("%w: bad signature", ErrInvalidPaginationToken)
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: malformed payload", ErrInvalidPaginationToken)
+	}
+	var payload tokenPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return pageCursor{}, fmt.Errorf("%w: malformed payload", ErrInvalidPaginationToken)
+	}
+
+	if payload.Endpoint != endpoint || payload.Subject != subject {
+		return pageCursor{}, fmt.Errorf("%w: issued for another request", ErrInvalidPaginationToken)
+	}
+	if s.now().Unix() > payload.ExpiresAt {
+		return pageCursor{}, fmt.Errorf("%w: expired", ErrInvalidPaginationToken)
+	}
+
+	return payload.Cursor, nil
+}
+
+func (s *TokenSigner) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(paginationTokenVersion + "." + encodedPayload))
+	return mac.Sum(nil)
+}
+
+// timeCursor converts the token cursor into the keyset position used by DecisionStore
+func (c pageCursor) timeCursor() TimeCursor {
+	return TimeCursor{UnixTimestamp: c.Timestamp, ID: c.ID}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSigner_RoundTrip(t *testing.T) {
+	signer := NewTokenSigner([]byte("secret"), time.Minute)
+
+	token, err := signer.Encode(listLikedYouEndpoint, "recipient", pageCursor{ID: 42})
+	require.NoError(t, err)
+
+	cursor, err := signer.Decode(token, listLikedYouEndpoint, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), cursor.ID)
+}
+
+func TestTokenSigner_Rejections(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := NewTokenSigner([]byte("secret"), time.Minute)
+	signer.now = func() time.Time { return now }
+
+	token, err := signer.Encode(listLikedYouEndpoint, "recipient", pageCursor{ID: 42})
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	otherSigner := NewTokenSigner([]byte("other-secret"), time.Minute)
+	forged, err := otherSigner.Encode(listLikedYouEndpoint, "recipient", pageCursor{ID: 1})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		token    string
+		endpoint string
+		subject  string
+		advance  time.Duration
+	}{
+		{name: "raw decision id", token: "42", endpoint: listLikedYouEndpoint, subject: "recipient"},
+		{name: "tampered payload", token: parts[0] + "." + parts[1] + "x." + parts[2], endpoint: listLikedYouEndpoint, subject: "recipient"},
+		{name: "signed with another secret", token: forged, endpoint: listLikedYouEndpoint, subject: "recipient"},
+		{name: "other endpoint", token: token, endpoint: listNewLikedYouEndpoint, subject: "recipient"},
+		{name: "other recipient", token: token, endpoint: listLikedYouEndpoint, subject: "someone-else"},
+		{name: "expired", token: token, endpoint: listLikedYouEndpoint, subject: "recipient", advance: 2 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer.now = func() time.Time { return now.Add(tc.advance) }
+			_, err := signer.Decode(tc.token, tc.endpoint, tc.subject)
+			assert.ErrorIs(t, err, ErrInvalidPaginationToken)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+)
+
+// User is a row of the user table
+type User struct {
+	ID                   string
+	Name                 string
+	CreatedUnixTimestamp uint64
+}
+
+// Decision is the current decision of an actor on a recipient, as stored in the decision table
+type Decision struct {
+	ID            uint64
+	ActorID       string
+	RecipientID   string
+	Liked         bool
+	Unmatched     bool   // the pass was recorded by Unmatch or BlockUser
+	UnixTimestamp uint64 // time of the latest decision of the pair
+}
+
+// UserDataRecord is one record of a user data export, exactly one field is set
+type UserDataRecord struct {
+	User          *User
+	Stats         *UserStats // like_stats and user_stats of the user
+	Moderation    *UserModeration
+	Decision      *Decision      // decision made by the user
+	LikeReceived  *Liker         // like received, including the ones hidden from ListLikedYou
+	Match         *Match         // match of the user
+	Blocked       *BlockedUser   // user blocked by the user
+	DecisionEvent *DecisionEvent // decision history of the user
+	EventReceived *DecisionEvent // decision history of other users on the user, passes included
+	ReportFiled   *Report        // report filed by the user
+	ReportAbout   *Report        // report about the user, without the reporter
+	Idempotency   *IdempotencyRecord
+}
+
+// exportPageSize is the amount of rows read per query while exporting user data
+const exportPageSize = 500
+
+// ExportUserData passes everything stored about the user to emit, one record at a time in this order:
+// the user row, the counters, the moderation state, the decisions made, the likes received,
+// the matches, the blocked users, the decision history both ways, the reports filed and about the user
+// and the idempotency keys. Each list is read in pages over its index, so the export is not a point-in-time
+// snapshot of the user. Reports are exported as stored, without the relationship read along with them,
+// and the reporter of a report about the user is left out. Users being deleted are not found
+func (b *ExploreBusiness) ExportUserData(ctx context.Context, userID string, emit func(UserDataRecord) error) error {
+	// 1. The user row, counters and moderation state
+	if _, found, err := b.store.GetUserDeletion(ctx, userID); err != nil {
+		return err
+	} else if found {
+		return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+	}
+
+	user, err := b.store.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := emit(UserDataRecord{User: &user}); err != nil {
+		return err
+	}
+
+	stats, err := b.store.GetUserStats(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := emit(UserDataRecord{Stats: &stats}); err != nil {
+		return err
+	}
+
+	moderation, err := b.store.GetUserModeration(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := emit(UserDataRecord{Moderation: &moderation}); err != nil {
+		return err
+	}
+
+	// 2. Every list, page by page
+	err = exportPages(func(last *Decision) ([]Decision, error) {
+		after := ""
+		if last != nil {
+			after = last.RecipientID
+		}
+		return b.store.ListDecisionsMade(ctx, userID, after, exportPageSize)
+	}, func(decision *Decision) error { return emit(UserDataRecord{Decision: decision}) })
+	if err != nil {
+		return err
+	}
+
+	err = exportPages(func(last *LikeRecord) ([]LikeRecord, error) {
+		return b.store.ListLikesReceived(ctx, userID, exportTimeQuery(last, cursorOf))
+	}, func(record *LikeRecord) error { return emit(UserDataRecord{LikeReceived: &record.Liker}) })
+	if err != nil {
+		return err
+	}
+
+	err = exportPages(func(last *Match) ([]Match, error) {
+		return b.store.ListMatches(ctx, userID, exportTimeQuery(last, func(match Match) TimeCursor {
+			return TimeCursor{UnixTimestamp: match.UnixTimestamp, ID: match.ID}
+		}))
+	}, func(match *Match) error { return emit(UserDataRecord{Match: match}) })
+	if err != nil {
+		return err
+	}
+
+	err = exportPages(func(last *BlockedUser) ([]BlockedUser, error) {
+		return b.store.ListBlocked(ctx, userID, exportTimeQuery(last, func(blocked BlockedUser) TimeCursor {
+			return TimeCursor{UnixTimestamp: blocked.UnixTimestamp, ID: blocked.ID}
+		}))
+	}, func(blocked *BlockedUser) error { return emit(UserDataRecord{Blocked: blocked}) })
+	if err != nil {
+		return err
+	}
+
+	err = exportPages(func(last *DecisionEvent) ([]DecisionEvent, error) {
+		return b.store.ListDecisionHistory(ctx, userID, "", exportEventQuery(last, func(event DecisionEvent) uint64 { return event.ID }))
+	}, func(event *DecisionEvent) error { return emit(UserDataRecord{DecisionEvent: event}) })
+	if err != nil {
+		return err
+	}
+
+	err = exportPages(func(last *DecisionEvent) ([]DecisionEvent, error) {
+		return b.store.ListDecisionEventsReceived(ctx, userID, exportEventQuery(last, func(event DecisionEvent) uint64 { return event.ID }))
+	}, func(event *DecisionEvent) error { return emit(UserDataRecord{EventReceived: event}) })
+	if err != nil {
+		return err
+	}
+
+	for _, filed := range []bool{true, false} {
+		err = exportPages(func(last *Report) ([]Report, error) {
+			return b.store.ListUserReports(ctx, userID, filed, exportEventQuery(last, func(report Report) uint64 { return report.ID }))
+		}, func(report *Report) error {
+			report.Relationship = Relationship{}
+			if filed {
+				return emit(UserDataRecord{ReportFiled: report})
+			}
+			report.ReporterID = ""
+			return emit(UserDataRecord{ReportAbout: report})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return exportPages(func(last *IdempotencyRecord) ([]IdempotencyRecord, error) {
+		after := ""
+		if last != nil {
+			after = last.Key
+		}
+		return b.store.ListIdempotencyRecords(ctx, userID, after, exportPageSize)
+	}, func(record *IdempotencyRecord) error { return emit(UserDataRecord{Idempotency: record}) })
+}
+
+// exportPages emits every item of a list read with page, which gets the last item of the previous page
+// or nil for the first one. A page shorter than exportPageSize ends the list
+func exportPages[T any](page func(last *T) ([]T, error), emit func(*T) error) error {
+	var last *T
+	for {
+		items, err := page(last)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			if err := emit(&items[i]); err != nil {
+				return err
+			}
+		}
+		if len(items) < exportPageSize {
+			return nil
+		}
+		last = &items[len(items)-1]
+	}
+}
+
+// exportEventQuery returns the query of the page after last, in id order
+func exportEventQuery[T any](last *T, idOf func(T) uint64) EventQuery {
+	query := EventQuery{Limit: exportPageSize}
+	if last != nil {
+		query.AfterID = idOf(*last)
+	}
+	return query
+}
+
+// exportTimeQuery returns the query of the page after last, oldest first
+func exportTimeQuery[T any](last *T, positionOf func(T) TimeCursor) TimeQuery {
+	query := TimeQuery{Limit: exportPageSize}
+	if last != nil {
+		query.After = positionOf(*last)
+	}
+	return query
+}
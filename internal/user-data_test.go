@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestExportUserData_EveryRecordInOrder(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"a", "b", true}, {"b", "a", true}, // match
+		{"c", "a", true}, // hidden from a once blocked
+		{"d", "a", true}, // hidden from everyone once d's likes are hidden
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+	_, err := business.RecordIdempotentDecision(ctx, "key-1", "a", "c", false)
+	require.NoError(t, err)
+	require.NoError(t, business.BlockUser(ctx, "a", "c"))
+	resolveReport(t, business, "a", "d", OutcomeLikesHidden)
+	_, err = business.ReportUser(ctx, "b", "a", ReportSpam, "fake photos")
+	require.NoError(t, err)
+
+	var records []UserDataRecord
+	err = business.ExportUserData(ctx, "a", func(record UserDataRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, records, 18)
+	assert.Equal(t, "a", records[0].User.ID)
+	assert.Equal(t, UserStats{UserID: "a", LikeCount: 1, MatchCount: 1}, *records[1].Stats)
+	assert.Equal(t, UserModeration{UserID: "a"}, *records[2].Moderation)
+	assert.Equal(t, "b", records[3].Decision.RecipientID)
+	assert.True(t, records[3].Decision.Liked)
+	assert.Equal(t, "c", records[4].Decision.RecipientID)
+	assert.False(t, records[4].Decision.Liked)
+
+	// every like received, including the ones ListLikedYou hides
+	var likers []string
+	for _, record := range records[5:8] {
+		likers = append(likers, record.LikeReceived.ActorID)
+	}
+	assert.ElementsMatch(t, []string{"b", "c", "d"}, likers)
+
+	assert.Equal(t, "b", records[8].Match.MatchedUserID)
+	assert.Equal(t, "c", records[9].Blocked.BlockedUserID)
+	assert.Equal(t, "b", records[10].DecisionEvent.RecipientID)
+	assert.Equal(t, "c", records[11].DecisionEvent.RecipientID)
+
+	// decisions of the others on a, hidden likes included
+	for i, actor := range []string{"b", "c", "d"} {
+		assert.Equal(t, actor, records[12+i].EventReceived.ActorID)
+		assert.Equal(t, "a", records[12+i].EventReceived.RecipientID)
+	}
+
+	// reports both ways, without the reporter of the report about a
+	assert.Equal(t, "d", records[15].ReportFiled.ReportedID)
+	assert.Equal(t, OutcomeLikesHidden, records[15].ReportFiled.Outcome)
+	assert.Empty(t, records[16].ReportAbout.ReporterID)
+	assert.Equal(t, "a", records[16].ReportAbout.ReportedID)
+	assert.Equal(t, "fake photos", records[16].ReportAbout.Details)
+
+	assert.Equal(t, IdempotencyRecord{ActorID: "a", Key: "key-1", RecipientID: "c"}, *records[17].Idempotency)
+}
+
+func TestExportUserData_UnknownOrDeletedUser(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a")
+
+	emit := func(UserDataRecord) error { return nil }
+	assert.ErrorIs(t, business.ExportUserData(ctx, "ghost", emit), ErrNotFound)
+
+	_, err := business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	assert.ErrorIs(t, business.ExportUserData(ctx, "a", emit), ErrNotFound)
+}
+
+func TestWriteUserDataNDJSON_OneMessagePerLine(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b")
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteUserDataNDJSON(ctx, business, "a", &out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+	assert.JSONEq(t, `{"user": {"user_id": "a", "name": "user a"}}`, lines[0])
+	assert.JSONEq(t, `{"like_stats": {}}`, lines[1])
+	assert.JSONEq(t, `{"moderation": {}}`, lines[2])
+
+	// every line is a whole ExportUserDataResponse
+	var decision pb.ExportUserDataResponse
+	require.NoError(t, protojson.Unmarshal([]byte(lines[3]), &decision))
+	assert.Equal(t, "b", decision.GetDecision().RecipientUserId)
+	assert.True(t, decision.GetDecision().LikedRecipient)
+	var event pb.ExportUserDataResponse
+	require.NoError(t, protojson.Unmarshal([]byte(lines[4]), &event))
+	assert.Equal(t, "b", event.GetDecisionEvent().RecipientUserId)
+}
+
+func TestListDecisionsMade_MySQLSeeksPastLastRecipient(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM decision\s+WHERE actor_user_id = \?\s+AND recipient_user_id > \?\s+ORDER BY recipient_user_id\s+LIMIT \?`).
+		WithArgs("actor1", "actor2", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient_user_id", "liked_recipient", "unmatched", "created_at"}).
+			AddRow(7, "actor3", false, true, 1700000000))
+
+	decisions, err := NewMySQLStore(&DB{db}).ListDecisionsMade(context.Background(), "actor1", "actor2", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Decision{{
+		ID:            7,
+		ActorID:       "actor1",
+		RecipientID:   "actor3",
+		Unmatched:     true,
+		UnixTimestamp: 1700000000,
+	}}, decisions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListLikesReceived_MySQLKeepsHiddenLikes(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// nothing but the keyset between the recipient and the ORDER BY, no visibleLikeActor filter
+	mock.ExpectQuery(`FROM decision d\s+WHERE d.recipient_user_id = \?\s+AND d.liked_recipient = true\s+`+
+		`AND \(\s+d.created_at > FROM_UNIXTIME\(\?\)\s+OR \(d.created_at = FROM_UNIXTIME\(\?\) AND d.id > \?\)\s+\)\s+`+
+		`ORDER BY d.created_at ASC, d.id ASC\s+LIMIT \?;`).
+		WithArgs("actor1", uint64(1700000000), uint64(1700000000), uint64(3), 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_user_id", "created_at"}))
+
+	_, err := NewMySQLStore(&DB{db}).ListLikesReceived(context.Background(), "actor1", TimeQuery{
+		After: TimeCursor{UnixTimestamp: 1700000000, ID: 3},
+		Limit: exportPageSize,
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserModeration_MySQLPlainRead(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// no transaction and no FOR SHARE, the export does not hold locks
+	mock.ExpectQuery(`FROM user_moderation\s+WHERE user_id = \?;`).
+		WithArgs("actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"warnings", "likes_hidden", "banned"}).AddRow(2, true, false))
+
+	moderation, err := NewMySQLStore(&DB{db}).GetUserModeration(context.Background(), "actor1")
+
+	require.NoError(t, err)
+	assert.Equal(t, UserModeration{UserID: "actor1", Warnings: 2, LikesHidden: true}, moderation)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUserReports_MySQLSeeksPastLastReportAboutTheUser(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM user_report r\s+.+WHERE r.reported_user_id = \?\s+AND r.id > \?\s+ORDER BY r.id ASC\s+LIMIT \?`).
+		WithArgs("actor1", uint64(4), 500).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "reporter_user_id", "reported_user_id", "reason", "details", "status", "moderator_id", "outcome",
+			"resolution_note", "unix_timestamp", "d1_liked", "d1_unmatched", "d2_liked", "d2_unmatched", "matched", "blocked",
+		}).AddRow(9, "actor2", "actor1", "SPAM", "", "OPEN", "", "", "", 1700000000, nil, nil, nil, nil, false, false))
+
+	reports, err := NewMySQLStore(&DB{db}).ListUserReports(context.Background(), "actor1", false, EventQuery{AfterID: 4, Limit: exportPageSize})
+
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, uint64(9), reports[0].ID)
+	assert.Equal(t, "actor2", reports[0].ReporterID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DeletionStep is the next step of a user deletion. Every step deletes the user's rows of one kind, in chunks
+type DeletionStep string
+
+const (
+	DeletionDecisionsMade     DeletionStep = "DECISIONS_MADE"     // decisions of the user, repairing the like_stats and user_stats of the other users
+	DeletionDecisionsReceived DeletionStep = "DECISIONS_RECEIVED" // decisions of other users on the user
+	DeletionDecisionEvents    DeletionStep = "DECISION_EVENTS"    // decision history, both ways
+	DeletionMatches           DeletionStep = "MATCHES"            // both sides of every match, repairing the match_count of the matched users
+	DeletionBlocks            DeletionStep = "BLOCKS"             // blocks, both ways
+	DeletionReports           DeletionStep = "REPORTS"            // reports, both ways
+	DeletionOutbox            DeletionStep = "OUTBOX"             // outbox events, webhook deliveries and idempotency keys naming the user, dead letters included
+	DeletionAccount           DeletionStep = "ACCOUNT"            // like_stats, user_stats, moderation, own idempotency keys and the user itself
+	DeletionDone              DeletionStep = "DONE"
+)
+
+// deletionSteps lists the steps in the order they run. Decisions go first since their like_stats repair
+// reads the blocks and the moderation of the user, the user row goes last once nothing references it
+var deletionSteps = []DeletionStep{
+	DeletionDecisionsMade,
+	DeletionDecisionsReceived,
+	DeletionDecisionEvents,
+	DeletionMatches,
+	DeletionBlocks,
+	DeletionReports,
+	DeletionOutbox,
+	DeletionAccount,
+	DeletionDone,
+}
+
+// nextDeletionStep returns the step running after step
+func nextDeletionStep(step DeletionStep) DeletionStep {
+	for i, candidate := range deletionSteps[:len(deletionSteps)-1] {
+		if candidate == step {
+			return deletionSteps[i+1]
+		}
+	}
+	return DeletionDone
+}
+
+// UserDeletion is a requested user deletion, its counters make up the receipt once completed
+type UserDeletion struct {
+	UserID                 string
+	Step                   DeletionStep // next step to run, DeletionDone once completed
+	DecisionsDeleted       uint64       // decisions made and received
+	LikeCountsRepaired     uint64       // like_stats of liked users decremented
+	DecisionEventsDeleted  uint64
+	MatchesDeleted         uint64 // user_match rows, two per match
+	BlocksDeleted          uint64
+	ReportsDeleted         uint64
+	RequestedUnixTimestamp uint64
+	CompletedUnixTimestamp uint64 // 0 until completed
+}
+
+// Completed reports if every row of the user is gone
+func (d UserDeletion) Completed() bool {
+	return d.Step == DeletionDone
+}
+
+// count adds the rows deleted by a chunk of step to the receipt
+func (d *UserDeletion) count(step DeletionStep, deleted int) {
+	switch step {
+	case DeletionDecisionsMade, DeletionDecisionsReceived:
+		d.DecisionsDeleted += uint64(deleted)
+	case DeletionDecisionEvents:
+		d.DecisionEventsDeleted += uint64(deleted)
+	case DeletionMatches:
+		d.MatchesDeleted += uint64(deleted)
+	case DeletionBlocks:
+		d.BlocksDeleted += uint64(deleted)
+	case DeletionReports:
+		d.ReportsDeleted += uint64(deleted)
+	}
+}
+
+// checkNotDeleted returns a USER_NOT_FOUND error if one of the users is being deleted, so no new row
+// references them while the deletion runs
+func checkNotDeleted(ctx context.Context, tx DecisionTx, userIDs ...string) error {
+	for _, userID := range userIDs {
+		deleted, err := tx.IsUserDeleted(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			return newUserNotFoundError("user not found", map[string]string{"user_id": userID}, nil)
+		}
+	}
+	return nil
+}
+
+// DeleteUser requests the deletion of every row of the user. New decisions, unmatches, blocks and reports involving
+// the user are refused right away, while reads keep returning their rows until UserEraser deletes them in the
+// background. Requesting it again returns the existing deletion
+func (b *ExploreBusiness) DeleteUser(ctx context.Context, userID string) (*UserDeletion, error) {
+	var deletion UserDeletion
+	err := b.store.InTx(ctx, func(tx DecisionTx) error {
+		var err error
+		deletion, err = tx.CreateUserDeletion(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// GetUserDeletion returns the progress of the user deletion, its receipt once completed
+func (b *ExploreBusiness) GetUserDeletion(ctx context.Context, userID string) (*UserDeletion, error) {
+	deletion, found, err := b.store.GetUserDeletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &DomainError{
+			Kind:     ErrNotFound,
+			Reason:   ReasonDeletionNotFound,
+			Message:  "user deletion not found",
+			Metadata: map[string]string{"user_id": userID},
+		}
+	}
+	return &deletion, nil
+}
+
+// UserEraserConfig holds the chunking and polling settings of UserEraser
+type UserEraserConfig struct {
+	PollInterval time.Duration // delay between polls when no deletion is pending
+	ChunkSize    int           // rows deleted per transaction
+}
+
+// DefaultUserEraserConfig returns the settings used when nothing is configured
+func DefaultUserEraserConfig() UserEraserConfig {
+	return UserEraserConfig{
+		PollInterval: time.Second,
+		ChunkSize:    500,
+	}
+}
+
+// UserEraser runs the pending user deletions. Each transaction deletes one chunk of rows and saves the progress
+// of the deletion along with it, so a huge account never holds a long transaction and an interrupted deletion
+// resumes from its last chunk. Deletions are locked with SKIP LOCKED, erasers of several server instances
+// work on different deletions
+type UserEraser struct {
+	store  DecisionStore
+	config UserEraserConfig
+}
+
+// NewUserEraser creates an eraser for the deletions of the store
+func NewUserEraser(store DecisionStore, config UserEraserConfig) *UserEraser {
+	return &UserEraser{store: store, config: config}
+}
+
+// Run erases users until ctx is done
+func (e *UserEraser) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while deletions are pending, wait for the next tick otherwise
+		pending, err := e.EraseOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("error erasing user: %v", err)
+		}
+		if err == nil && pending {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// EraseOnce runs one chunk of the oldest pending deletion and reports if there was one
+func (e *UserEraser) EraseOnce(ctx context.Context) (bool, error) {
+	found := false
+	var deletion UserDeletion
+	err := e.store.InTx(ctx, func(tx DecisionTx) error {
+		// 1. Lock a pending deletion
+		var err error
+		deletion, found, err = tx.LockPendingUserDeletion(ctx)
+		if err != nil || !found {
+			return err
+		}
+
+		// 2. Delete a chunk, a partial chunk ends the step
+		deleted, err := eraseChunk(ctx, tx, &deletion, e.config.ChunkSize)
+		if err != nil {
+			return err
+		}
+		deletion.count(deletion.Step, deleted)
+		if deleted < e.config.ChunkSize {
+			deletion.Step = nextDeletionStep(deletion.Step)
+		}
+
+		// 3. Save the progress with the chunk
+		return tx.SaveUserDeletion(ctx, deletion)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if found && deletion.Completed() {
+		log.Printf("user %s deleted: %d decisions, %d like counts repaired, %d decision events, %d match rows, %d blocks, %d reports",
+			deletion.UserID, deletion.DecisionsDeleted, deletion.LikeCountsRepaired, deletion.DecisionEventsDeleted,
+			deletion.MatchesDeleted, deletion.BlocksDeleted, deletion.ReportsDeleted)
+	}
+	return found, nil
+}
+
+// eraseChunk deletes up to limit rows of the current step and returns how many were deleted
+func eraseChunk(ctx context.Context, tx DecisionTx, deletion *UserDeletion, limit int) (int, error) {
+	switch deletion.Step {
+	case DeletionDecisionsMade:
+		return eraseDecisionsMade(ctx, tx, deletion, limit)
+	case DeletionMatches:
+		return eraseMatches(ctx, tx, deletion, limit)
+	case DeletionAccount:
+		return 0, tx.DeleteUserAccount(ctx, deletion.UserID)
+	default:
+		return tx.DeleteUserRows(ctx, deletion.UserID, deletion.Step, limit)
+	}
+}
+
+// eraseDecisionsMade deletes a chunk of the user's decisions, takes their counted likes out of like_stats
+// and new_like_count, and their passes out of pass_count. A like is not counted when the user's likes are hidden
+// or when the liked user blocked them, the other way around BlockUser already turned the like into a pass
+func eraseDecisionsMade(ctx context.Context, tx DecisionTx, deletion *UserDeletion, limit int) (int, error) {
+	decisions, err := tx.DeleteActorDecisions(ctx, deletion.UserID, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	counted, err := likesCounted(ctx, tx, deletion.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	// decisions come in recipient order, so concurrent transactions lock like_stats rows in the same order
+	stats := userStatsChanges{}
+	for _, decision := range decisions {
+		if !decision.Liked {
+			stats.add(decision.RecipientID, UserStatsChange{Passes: -1})
+			continue
+		}
+		if !counted {
+			continue
+		}
+		blocked, err := tx.IsBlocked(ctx, deletion.UserID, decision.RecipientID)
+		if err != nil {
+			return 0, err
+		}
+		if blocked {
+			continue
+		}
+		if err := tx.DecrementLikeCount(ctx, decision.RecipientID); err != nil {
+			return 0, err
+		}
+		deletion.LikeCountsRepaired++
+
+		back, err := tx.GetDecision(ctx, decision.RecipientID, deletion.UserID)
+		if err != nil {
+			return 0, err
+		}
+		if !answersLike(back) {
+			stats.add(decision.RecipientID, UserStatsChange{NewLikes: -1})
+		}
+	}
+	return len(decisions), stats.apply(ctx, tx)
+}
+
+// eraseMatches deletes a chunk of the user's matches and takes them out of the match_count of the matched users.
+// It returns the user_match rows deleted, two per match, so a chunk of limit rows is half as many matches,
+// rounded up for a chunk of one to make progress
+func eraseMatches(ctx context.Context, tx DecisionTx, deletion *UserDeletion, limit int) (int, error) {
+	matched, err := tx.DeleteUserMatches(ctx, deletion.UserID, (limit+1)/2)
+	if err != nil {
+		return 0, err
+	}
+
+	stats := userStatsChanges{}
+	for _, userID := range matched {
+		stats.add(userID, UserStatsChange{Matches: -1})
+	}
+	return 2 * len(matched), stats.apply(ctx, tx)
+}
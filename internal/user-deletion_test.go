@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeleteUser_ErasesInChunksAndRepairsLikeCounts(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"a", "b", true}, {"b", "a", true}, // match
+		{"a", "c", true}, // not counted once c blocks a
+		{"a", "d", false},
+		{"d", "a", true},
+		{"d", "b", true},
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+	require.NoError(t, business.BlockUser(ctx, "c", "a"))
+	_, err := business.ReportUser(ctx, "c", "a", ReportSpam, "")
+	require.NoError(t, err)
+
+	deletion, err := business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, deletion.Completed())
+
+	// new rows involving the user are refused right away
+	_, err = business.RecordDecision(ctx, "b", "a", false)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, business.BlockUser(ctx, "b", "a"), ErrNotFound)
+
+	// one row per transaction
+	eraser := NewUserEraser(store, UserEraserConfig{ChunkSize: 1})
+	chunks := 0
+	for {
+		pending, err := eraser.EraseOnce(ctx)
+		require.NoError(t, err)
+		if !pending {
+			break
+		}
+		chunks++
+	}
+	assert.Greater(t, chunks, len(deletionSteps))
+
+	receipt, err := business.GetUserDeletion(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, UserDeletion{
+		UserID:                 "a",
+		Step:                   DeletionDone,
+		DecisionsDeleted:       5,
+		LikeCountsRepaired:     1,
+		DecisionEventsDeleted:  5,
+		MatchesDeleted:         2,
+		BlocksDeleted:          1,
+		ReportsDeleted:         1,
+		RequestedUnixTimestamp: receipt.RequestedUnixTimestamp,
+		CompletedUnixTimestamp: receipt.CompletedUnixTimestamp,
+	}, *receipt)
+	assert.NotZero(t, receipt.CompletedUnixTimestamp)
+
+	// only d's like on b is left
+	for user, expected := range map[string]uint64{"b": 1, "c": 0, "d": 0} {
+		count, err := business.CountLikedYouUsers(ctx, user, TimeWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, count, user)
+	}
+	likers, err := business.ListLikedYouUsers(ctx, "b", TimeWindow{}, PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, collectActorIDs(likers))
+	matches, err := business.ListMatches(ctx, "b", PaginationParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, matches.Matches)
+	_, err = business.CountLikedYouUsers(ctx, "a", TimeWindow{})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// requesting it again returns the receipt
+	again, err := business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, receipt, again)
+}
+
+func TestDeleteUser_HiddenLikesAreNotRepairedTwice(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, actor := range []string{"a", "c"} {
+		_, err := business.RecordDecision(ctx, actor, "b", true)
+		require.NoError(t, err)
+	}
+	resolveReport(t, business, "b", "a", OutcomeLikesHidden)
+
+	_, err := business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	for pending := true; pending; {
+		pending, err = NewUserEraser(store, DefaultUserEraserConfig()).EraseOnce(ctx)
+		require.NoError(t, err)
+	}
+
+	receipt, err := business.GetUserDeletion(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, receipt.Completed())
+	assert.Zero(t, receipt.LikeCountsRepaired)
+
+	count, err := business.CountLikedYouUsers(ctx, "b", TimeWindow{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
+
+var userDeletionTestColumns = []string{
+	"user_id", "step", "decisions_deleted", "like_counts_repaired", "decision_events_deleted",
+	"matches_deleted", "blocks_deleted", "reports_deleted", "requested_at", "completed_at",
+}
+
+func TestDeleteUser_UnknownUser(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM user_deletion\s+WHERE user_id = \?\s+FOR UPDATE`).
+		WithArgs("ghost").
+		WillReturnRows(sqlmock.NewRows(userDeletionTestColumns))
+	mock.ExpectExec(`INSERT INTO user_deletion \(user_id, step\)\s+SELECT id, \?\s+FROM user\s+WHERE id = \?`).
+		WithArgs(DeletionDecisionsMade, "ghost").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := service.DeleteUser(context.Background(), &pb.DeleteUserRequest{UserId: "ghost"})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonUserNotFound, errorInfoOf(t, err).Reason)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserEraser_MySQLChunkOfDecisionsMade(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM user_deletion\s+WHERE completed_at IS NULL\s+ORDER BY requested_at\s+LIMIT 1\s+FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows(userDeletionTestColumns).
+			AddRow("actor1", "DECISIONS_MADE", 2, 1, 0, 0, 0, 0, 1700000000, 0))
+	mock.ExpectQuery(`FROM decision\s+WHERE actor_user_id = \?\s+ORDER BY recipient_user_id\s+LIMIT \?\s+FOR UPDATE`).
+		WithArgs("actor1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"recipient_user_id", "liked_recipient"}).
+			AddRow("actor2", true).
+			AddRow("actor3", true))
+	mock.ExpectExec(`DELETE FROM decision\s+WHERE actor_user_id = \?\s+AND recipient_user_id IN \(\?, \?\)`).
+		WithArgs("actor1", "actor2", "actor3").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`FROM user_moderation`).
+		WithArgs("actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"warnings", "likes_hidden", "banned"}))
+	// actor3 blocked actor1, that like was not counted
+	mock.ExpectQuery(`FROM user_block`).
+		WithArgs("actor1", "actor2", "actor2", "actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE like_stats`).
+		WithArgs("actor2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectDecision(mock, "actor2", "actor1", DecisionNone)
+	mock.ExpectQuery(`FROM user_block`).
+		WithArgs("actor1", "actor3", "actor3", "actor1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	// the like was new for actor2, who did not decide on actor1
+	expectUserStatsChange(mock, "actor2", UserStatsChange{NewLikes: -1})
+	// a full chunk, the step goes on
+	mock.ExpectExec(`UPDATE user_deletion`).
+		WithArgs(DeletionDecisionsMade, uint64(4), uint64(2), uint64(0), uint64(0), uint64(0), uint64(0), false, "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	pending, err := NewUserEraser(NewMySQLStore(&DB{db}), UserEraserConfig{ChunkSize: 2}).EraseOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, pending)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUser_ErasesOutboxEventsWebhooksAndIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	_, err := business.RecordIdempotentDecision(ctx, "key-1", "b", "a", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "a", "b", true) // match, its webhook delivery becomes a dead letter
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "c", "b", true)
+	require.NoError(t, err)
+
+	relay := NewOutboxRelay(store, NewWebhookSink(store, []string{"https://example.com/hook"}), OutboxRelayConfig{BatchSize: 10})
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	require.Len(t, store.webhookDeliveries, 1)
+	require.NoError(t, store.FailWebhookDelivery(ctx, store.webhookDeliveries[0].delivery.ID, "gone", 0, true))
+
+	_, err = business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	eraser := NewUserEraser(store, UserEraserConfig{ChunkSize: 1})
+	for pending := true; pending; {
+		pending, err = eraser.EraseOnce(ctx)
+		require.NoError(t, err)
+	}
+
+	// only c's like on b is left, and b's key naming a is gone
+	require.Len(t, store.outbox, 1)
+	assert.True(t, store.outbox[0].event.mentionsUser("c"))
+	assert.Empty(t, store.webhookDeliveries)
+	assert.Empty(t, store.keys)
+}
+
+func TestUserEraser_MySQLChunkOfOutboxRows(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM user_deletion\s+WHERE completed_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows(userDeletionTestColumns).
+			AddRow("actor1", "OUTBOX", 0, 0, 0, 0, 0, 0, 1700000000, 0))
+	for _, deletion := range []struct {
+		column        string
+		limit, result int64
+	}{
+		{"idempotency_key.recipient_user_id", 10, 2},
+		{"outbox_event.event_key", 8, 3},
+		{"outbox_event.actor_user_id", 5, 0},
+		{"webhook_delivery.event_key", 5, 0},
+		{"webhook_delivery.actor_user_id", 5, 1},
+	} {
+		mock.ExpectExec(`DELETE FROM \w+\s+WHERE `+deletion.column+` = \?\s+LIMIT \?`).
+			WithArgs("actor1", deletion.limit).
+			WillReturnResult(sqlmock.NewResult(0, deletion.result))
+	}
+	// a partial chunk, the account goes next
+	mock.ExpectExec(`UPDATE user_deletion`).
+		WithArgs(DeletionAccount, uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), false, "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	pending, err := NewUserEraser(NewMySQLStore(&DB{db}), UserEraserConfig{ChunkSize: 10}).EraseOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, pending)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
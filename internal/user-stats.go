@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"sort"
+)
+
+// UserStats are the counters of a user, LikeCount comes from like_stats and the others from user_stats.
+// Like CountLikedYou, likes of actors blocked by the user or hidden by moderation are left out
+type UserStats struct {
+	UserID       string
+	LikeCount    uint64 // likes received, as returned by CountLikedYou
+	NewLikeCount uint64 // likes received from users the user did not like back nor unmatch, the ListNewLikedYou set
+	MatchCount   uint64
+	PassCount    uint64 // passes received, including the ones recorded by Unmatch and BlockUser
+}
+
+// UserStatsChange is a change of the user_stats counters of a user
+type UserStatsChange struct {
+	NewLikes int
+	Matches  int
+	Passes   int
+}
+
+// add sums up both changes
+func (c UserStatsChange) add(other UserStatsChange) UserStatsChange {
+	return UserStatsChange{
+		NewLikes: c.NewLikes + other.NewLikes,
+		Matches:  c.Matches + other.Matches,
+		Passes:   c.Passes + other.Passes,
+	}
+}
+
+// userStatsChanges collects the user_stats changes of a transaction, they are written once at the end
+type userStatsChanges map[string]UserStatsChange
+
+// add records a change of the user's counters
+func (c userStatsChanges) add(userID string, change UserStatsChange) {
+	c[userID] = c[userID].add(change)
+}
+
+// addMatch records a match created, delta +1, or dissolved, delta -1, on both sides
+func (c userStatsChanges) addMatch(userID, otherUserID string, delta int) {
+	c.add(userID, UserStatsChange{Matches: delta})
+	c.add(otherUserID, UserStatsChange{Matches: delta})
+}
+
+// apply writes the net changes, sorted by user so concurrent transactions lock user_stats rows in the same order
+func (c userStatsChanges) apply(ctx context.Context, tx DecisionTx) error {
+	userIDs := make([]string, 0, len(c))
+	for userID, change := range c {
+		if change != (UserStatsChange{}) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		if err := tx.ChangeUserStats(ctx, userID, c[userID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// answersLike reports if the decision takes the other user's like out of the decider's ListNewLikedYou
+func answersLike(state DecisionState) bool {
+	return state == DecisionLiked || state == DecisionUnmatched
+}
+
+// countDelta returns how a counter changes when its condition goes from before to after
+func countDelta(before, after bool) int {
+	switch {
+	case after && !before:
+		return 1
+	case before && !after:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// collectDecisionStats adds the user_stats changes of the actor's decision on the recipient going from previous
+// to current. back is the decision of the recipient on the actor and actorCounted tells if the actor's likes
+// are counted. Users who blocked one another can't decide on each other, so blocks play no part here
+func collectDecisionStats(ctx context.Context, tx DecisionTx, stats userStatsChanges, actorID, recipientID string,
+	previous, current, back DecisionState, actorCounted bool) error {
+	// 1. Passes received by the recipient, an unmatched pass is still a pass
+	stats.add(recipientID, UserStatsChange{
+		Passes: countDelta(previous == DecisionPassed || previous == DecisionUnmatched, current == DecisionPassed),
+	})
+
+	// 2. The actor's like among the recipient's new likes, unless the recipient answered it
+	if actorCounted && !answersLike(back) {
+		stats.add(recipientID, UserStatsChange{
+			NewLikes: countDelta(previous == DecisionLiked, current == DecisionLiked),
+		})
+	}
+
+	// 3. The recipient's like among the actor's new likes, which a like answers
+	if back != DecisionLiked || answersLike(previous) == answersLike(current) {
+		return nil
+	}
+	recipientCounted, err := likesCounted(ctx, tx, recipientID)
+	if err != nil || !recipientCounted {
+		return err
+	}
+	stats.add(actorID, UserStatsChange{NewLikes: countDelta(!answersLike(previous), !answersLike(current))})
+	return nil
+}
+
+// GetUserStats returns the counters of the user
+func (b *ExploreBusiness) GetUserStats(ctx context.Context, userID string) (UserStats, error) {
+	return b.store.GetUserStats(ctx, userID)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertUserStats checks the counters of every user of expected, the UserID is filled in
+func assertUserStats(t *testing.T, business *ExploreBusiness, expected map[string]UserStats) {
+	t.Helper()
+	for userID, stats := range expected {
+		stats.UserID = userID
+		actual, err := business.GetUserStats(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, stats, actual, userID)
+	}
+}
+
+func TestUserStats_FollowDecisionsOnBothSides(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	record := func(actor, recipient string, liked bool) {
+		_, err := business.RecordDecision(ctx, actor, recipient, liked)
+		require.NoError(t, err)
+	}
+
+	record("b", "a", true)
+	record("c", "a", false)
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1, NewLikeCount: 1, PassCount: 1},
+		"b": {},
+	})
+
+	// the like becomes mutual, b's like is no longer new for a
+	record("a", "b", true)
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1, MatchCount: 1, PassCount: 1},
+		"b": {LikeCount: 1, MatchCount: 1},
+	})
+
+	// a repeated like changes nothing
+	record("a", "b", true)
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1, MatchCount: 1, PassCount: 1},
+		"b": {LikeCount: 1, MatchCount: 1},
+	})
+
+	// b takes the like back, a's like is new again for b
+	record("b", "a", false)
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {PassCount: 2},
+		"b": {LikeCount: 1, NewLikeCount: 1},
+	})
+
+	// a pass turned into a like is no longer counted as a pass
+	record("c", "a", true)
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1, NewLikeCount: 1, PassCount: 1},
+	})
+}
+
+func TestUserStats_BatchNetsOutPerUser(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	_, err := business.RecordDecision(ctx, "b", "a", true)
+	require.NoError(t, err)
+
+	results, err := business.RecordDecisions(ctx, "a", []DecisionInput{
+		{RecipientID: "b", Liked: true},
+		{RecipientID: "b", Liked: false},
+		{RecipientID: "b", Liked: true},
+		{RecipientID: "c", Liked: false},
+	})
+	require.NoError(t, err)
+	assert.True(t, results[2].Mutual)
+
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1, MatchCount: 1},
+		"b": {LikeCount: 1, MatchCount: 1},
+		"c": {PassCount: 1},
+	})
+}
+
+func TestUserStats_UnmatchAndBlocks(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c")
+
+	for _, decision := range [][2]string{{"a", "b"}, {"b", "a"}, {"c", "a"}} {
+		_, err := business.RecordDecision(ctx, decision[0], decision[1], true)
+		require.NoError(t, err)
+	}
+
+	// b's like stays answered by the unmatch, a's like becomes a pass received by b
+	require.NoError(t, business.Unmatch(ctx, "a", "b"))
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 2, NewLikeCount: 1},
+		"b": {PassCount: 1},
+	})
+
+	require.NoError(t, business.BlockUser(ctx, "a", "c"))
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 1},
+	})
+
+	require.NoError(t, business.UnblockUser(ctx, "a", "c"))
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {LikeCount: 2, NewLikeCount: 1},
+	})
+}
+
+func TestUserStats_HiddenLikesAreUncounted(t *testing.T) {
+	ctx := context.Background()
+	_, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"b", "a", true},
+		{"b", "c", true}, {"c", "b", true}, // match
+		{"b", "d", true}, {"d", "b", false},
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+
+	resolveReport(t, business, "a", "b", OutcomeLikesHidden)
+
+	assertUserStats(t, business, map[string]UserStats{
+		"a": {},
+		"c": {MatchCount: 1},
+		"d": {},
+	})
+
+	// d's answer on a hidden like moves nothing either
+	_, err := business.RecordDecision(ctx, "d", "b", true)
+	require.NoError(t, err)
+	assertUserStats(t, business, map[string]UserStats{
+		"b": {LikeCount: 2, MatchCount: 2},
+		"d": {MatchCount: 1},
+	})
+}
+
+func TestUserStats_DeletedUserIsUncounted(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c", "d")
+
+	for _, decision := range []struct {
+		actor, recipient string
+		liked            bool
+	}{
+		{"a", "b", true}, {"b", "a", true}, // match
+		{"a", "c", true},
+		{"a", "d", false},
+	} {
+		_, err := business.RecordDecision(ctx, decision.actor, decision.recipient, decision.liked)
+		require.NoError(t, err)
+	}
+	assertUserStats(t, business, map[string]UserStats{
+		"b": {LikeCount: 1, MatchCount: 1},
+		"c": {LikeCount: 1, NewLikeCount: 1},
+		"d": {PassCount: 1},
+	})
+
+	_, err := business.DeleteUser(ctx, "a")
+	require.NoError(t, err)
+	eraser := NewUserEraser(store, UserEraserConfig{ChunkSize: 1})
+	for {
+		pending, err := eraser.EraseOnce(ctx)
+		require.NoError(t, err)
+		if !pending {
+			break
+		}
+	}
+
+	assertUserStats(t, business, map[string]UserStats{"b": {}, "c": {}, "d": {}})
+	_, err = business.GetUserStats(ctx, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetUserStats_MySQLReadsBothTables(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM user u\s+LEFT JOIN like_stats ls ON ls.user_id = u.id\s+LEFT JOIN user_stats us ON us.user_id = u.id\s+WHERE u.id = \?`).
+		WithArgs("user123").
+		WillReturnRows(sqlmock.NewRows([]string{"like_count", "new_like_count", "match_count", "pass_count"}).AddRow(3, 2, 1, 4))
+
+	resp, err := service.GetUserStats(context.Background(), &pb.GetUserStatsRequest{UserId: "user123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.LikeCount)
+	assert.Equal(t, uint64(2), resp.NewLikeCount)
+	assert.Equal(t, uint64(1), resp.MatchCount)
+	assert.Equal(t, uint64(4), resp.PassCount)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserEraser_MySQLChunkOfMatches(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM user_deletion\s+WHERE completed_at IS NULL\s+ORDER BY requested_at\s+LIMIT 1\s+FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows(userDeletionTestColumns).
+			AddRow("actor1", "MATCHES", 4, 0, 2, 0, 0, 0, 1700000000, 0))
+	// a chunk of 4 rows is 2 matches
+	mock.ExpectQuery(`SELECT\s+matched_user_id\s+FROM user_match\s+WHERE user_id = \?\s+ORDER BY matched_user_id\s+LIMIT \?\s+FOR UPDATE`).
+		WithArgs("actor1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"matched_user_id"}).AddRow("actor2").AddRow("actor3"))
+	mock.ExpectExec(`DELETE FROM user_match\s+WHERE \(user_id = \? AND matched_user_id IN \(\?, \?\)\)\s+OR \(matched_user_id = \? AND user_id IN \(\?, \?\)\)`).
+		WithArgs("actor1", "actor2", "actor3", "actor1", "actor2", "actor3").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	expectUserStatsChange(mock, "actor2", UserStatsChange{Matches: -1})
+	expectUserStatsChange(mock, "actor3", UserStatsChange{Matches: -1})
+	// a full chunk, the step goes on
+	mock.ExpectExec(`UPDATE user_deletion`).
+		WithArgs(DeletionMatches, uint64(4), uint64(0), uint64(2), uint64(4), uint64(0), uint64(0), false, "actor1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	pending, err := NewUserEraser(NewMySQLStore(&DB{db}), UserEraserConfig{ChunkSize: 4}).EraseOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, pending)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+)
+
+// uuidPattern matches lowercase canonical UUIDs, the format of user.id.
+// Uppercase is rejected because MySQL compares CHAR columns case-insensitively
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+const (
+	maxUserIDLength          = 36 // user.id is CHAR(36)
+	maxPaginationTokenLength = 512
+	maxIdempotencyKeyLength  = 128  // idempotency_key.idempotency_key is VARCHAR(128)
+	maxModeratorIDLength     = 64   // user_report.moderator_id is VARCHAR(64)
+	maxReportTextLength      = 1000 // user_report.details and resolution_note are VARCHAR(1000)
+)
+
+// idempotencyKeyPattern matches printable ASCII without spaces, keys are compared byte for byte.
+// Moderator ids follow the same pattern
+var idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7e]+$`)
+
+// ValidationConfig holds the configurable bounds of RequestValidator
+type ValidationConfig struct {
+	MaxPageSize  uint32 // largest page_size accepted by the list endpoints
+	MaxBatchSize int    // most decisions accepted by BatchPutDecision and BatchGetDecision
+	RequireUUIDs bool   // user ids must be lowercase canonical UUIDs
+}
+
+// DefaultValidationConfig returns the bounds used when nothing is configured
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		MaxPageSize:  100,
+		MaxBatchSize: 100,
+		RequireUUIDs: true,
+	}
+}
+
+// RequestValidator checks every request message before it reaches the business layer.
+// All violations of a request are reported together as an INVALID_REQUEST error
+type RequestValidator struct {
+	config ValidationConfig
+}
+
+// NewRequestValidator creates a validator with the given bounds
+func NewRequestValidator(config ValidationConfig) *RequestValidator {
+	return &RequestValidator{config: config}
+}
+
+// violations collects the field violations of a single request
+type violations []FieldViolation
+
+func (v *violations) add(field, format string, args ...any) {
+	*v = append(*v, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// err returns nil if no violation was collected
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(v))
+	for _, violation := range v {
+		descriptions = append(descriptions, violation.Field+" "+violation.Description)
+	}
+
+	return &DomainError{
+		Kind:       ErrInvalidArgument,
+		Reason:     ReasonInvalidRequest,
+		Message:    "invalid request: " + strings.Join(descriptions, "; "),
+		Violations: v,
+	}
+}
+
+func (r *RequestValidator) checkUserID(v *violations, field, userID string) {
+	switch {
+	case userID == "":
+		v.add(field, "must not be empty")
+	case len(userID) > maxUserIDLength:
+		v.add(field, "must be at most %d characters long", maxUserIDLength)
+	case r.config.RequireUUIDs && !uuidPattern.MatchString(userID):
+		v.add(field, "must be a lowercase canonical UUID")
+	}
+}
+
+func (r *RequestValidator) checkPagination(v *violations, pageSize *uint32, token *string) {
+	if pageSize != nil && *pageSize > r.config.MaxPageSize {
+		v.add("page_size", "must be at most %d", r.config.MaxPageSize)
+	}
+	if token != nil && len(*token) > maxPaginationTokenLength {
+		v.add("pagination_token", "must be at most %d characters long", maxPaginationTokenLength)
+	}
+}
+
+func (r *RequestValidator) checkIdempotencyKey(v *violations, field, key string) {
+	switch {
+	case key == "":
+		v.add(field, "must not be empty")
+	case len(key) > maxIdempotencyKeyLength:
+		v.add(field, "must be at most %d characters long", maxIdempotencyKeyLength)
+	case !idempotencyKeyPattern.MatchString(key):
+		v.add(field, "must only contain printable ASCII characters without spaces")
+	}
+}
+
+func (r *RequestValidator) checkModeratorID(v *violations, moderatorID string) {
+	switch {
+	case moderatorID == "":
+		v.add("moderator_id", "must not be empty")
+	case len(moderatorID) > maxModeratorIDLength:
+		v.add("moderator_id", "must be at most %d characters long", maxModeratorIDLength)
+	case !idempotencyKeyPattern.MatchString(moderatorID):
+		v.add("moderator_id", "must only contain printable ASCII characters without spaces")
+	}
+}
+
+func (r *RequestValidator) checkReportText(v *violations, field, text string) {
+	if utf8.RuneCountInString(text) > maxReportTextLength {
+		v.add(field, "must be at most %d characters long", maxReportTextLength)
+	}
+}
+
+func (r *RequestValidator) checkSortOrder(v *violations, order pb.SortOrder) {
+	if _, ok := pb.SortOrder_name[int32(order)]; !ok {
+		v.add("sort_order", "unknown value %d", order)
+	}
+}
+
+// checkTimeWindow checks the optional like time bounds, the window must not be empty
+func (r *RequestValidator) checkTimeWindow(v *violations, since, until *uint64) {
+	if until == nil {
+		return
+	}
+	var sinceTimestamp uint64
+	if since != nil {
+		sinceTimestamp = *since
+	}
+	if *until <= sinceTimestamp {
+		v.add("until_unix_timestamp", "must be after since_unix_timestamp")
+	}
+}
+
+// ValidateListLikedYouRequest validates requests of ListLikedYou and ListNewLikedYou
+func (r *RequestValidator) ValidateListLikedYouRequest(req *pb.ListLikedYouRequest) error {
+	var v violations
+	r.checkUserID(&v, "recipient_user_id", req.RecipientUserId)
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	r.checkTimeWindow(&v, req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+	return v.err()
+}
+
+// ValidateCountLikedYouRequest validates requests of CountLikedYou
+func (r *RequestValidator) ValidateCountLikedYouRequest(req *pb.CountLikedYouRequest) error {
+	var v violations
+	r.checkUserID(&v, "recipient_user_id", req.RecipientUserId)
+	r.checkTimeWindow(&v, req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+	return v.err()
+}
+
+// ValidateGetUserStatsRequest validates requests of GetUserStats
+func (r *RequestValidator) ValidateGetUserStatsRequest(req *pb.GetUserStatsRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	return v.err()
+}
+
+// ValidatePutDecisionRequest validates requests of PutDecision, users can't decide on themselves
+func (r *RequestValidator) ValidatePutDecisionRequest(req *pb.PutDecisionRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	r.checkUserID(&v, "recipient_user_id", req.RecipientUserId)
+	if req.ActorUserId != "" && req.ActorUserId == req.RecipientUserId {
+		v.add("recipient_user_id", "must be different from actor_user_id")
+	}
+	if req.IdempotencyKey != nil {
+		r.checkIdempotencyKey(&v, "idempotency_key", *req.IdempotencyKey)
+	}
+	return v.err()
+}
+
+// ResolveIdempotencyKey returns the idempotency key of a PutDecision request, sent either in the request
+// or in the idempotency-key metadata. When both are sent they must be the same key
+func (r *RequestValidator) ResolveIdempotencyKey(req *pb.PutDecisionRequest, metadataValues []string) (string, error) {
+	var v violations
+	key := req.GetIdempotencyKey()
+	for _, value := range metadataValues {
+		r.checkIdempotencyKey(&v, idempotencyKeyMetadata, value)
+		if key == "" {
+			key = value
+		} else if value != key {
+			v.add(idempotencyKeyMetadata, "must match idempotency_key and be sent once")
+		}
+	}
+	return key, v.err()
+}
+
+// ValidateBatchPutDecisionRequest validates requests of BatchPutDecision with the rules of PutDecision for every decision
+func (r *RequestValidator) ValidateBatchPutDecisionRequest(req *pb.BatchPutDecisionRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	switch {
+	case len(req.Decisions) == 0:
+		v.add("decisions", "must not be empty")
+	case len(req.Decisions) > r.config.MaxBatchSize:
+		v.add("decisions", "must contain at most %d decisions", r.config.MaxBatchSize)
+	default:
+		for i, decision := range req.Decisions {
+			field := fmt.Sprintf("decisions[%d].recipient_user_id", i)
+			r.checkUserID(&v, field, decision.RecipientUserId)
+			if req.ActorUserId != "" && req.ActorUserId == decision.RecipientUserId {
+				v.add(field, "must be different from actor_user_id")
+			}
+		}
+	}
+	return v.err()
+}
+
+// ValidateGetDecisionRequest validates requests of GetDecision
+func (r *RequestValidator) ValidateGetDecisionRequest(req *pb.GetDecisionRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	r.checkUserID(&v, "recipient_user_id", req.RecipientUserId)
+	return v.err()
+}
+
+// ValidateBatchGetDecisionRequest validates requests of BatchGetDecision with the rules of GetDecision for every recipient
+func (r *RequestValidator) ValidateBatchGetDecisionRequest(req *pb.BatchGetDecisionRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	switch {
+	case len(req.RecipientUserIds) == 0:
+		v.add("recipient_user_ids", "must not be empty")
+	case len(req.RecipientUserIds) > r.config.MaxBatchSize:
+		v.add("recipient_user_ids", "must contain at most %d recipients", r.config.MaxBatchSize)
+	default:
+		for i, recipientID := range req.RecipientUserIds {
+			r.checkUserID(&v, fmt.Sprintf("recipient_user_ids[%d]", i), recipientID)
+		}
+	}
+	return v.err()
+}
+
+// ValidateListMyDecisionsRequest validates requests of ListMyDecisions
+func (r *RequestValidator) ValidateListMyDecisionsRequest(req *pb.ListMyDecisionsRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	if _, ok := pb.DecisionType_name[int32(req.DecisionType)]; !ok || req.DecisionType == pb.DecisionType_DECISION_TYPE_UNSPECIFIED {
+		v.add("decision_type", "must be a known decision type")
+	}
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	return v.err()
+}
+
+// ValidateListDecisionHistoryRequest validates requests of ListDecisionHistory
+func (r *RequestValidator) ValidateListDecisionHistoryRequest(req *pb.ListDecisionHistoryRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	if req.RecipientUserId != nil {
+		r.checkUserID(&v, "recipient_user_id", *req.RecipientUserId)
+	}
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	return v.err()
+}
+
+// ValidateListMatchesRequest validates requests of ListMatches
+func (r *RequestValidator) ValidateListMatchesRequest(req *pb.ListMatchesRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	return v.err()
+}
+
+// ValidateUnmatchRequest validates requests of Unmatch
+func (r *RequestValidator) ValidateUnmatchRequest(req *pb.UnmatchRequest) error {
+	var v violations
+	r.checkUserID(&v, "actor_user_id", req.ActorUserId)
+	r.checkUserID(&v, "other_user_id", req.OtherUserId)
+	if req.ActorUserId != "" && req.ActorUserId == req.OtherUserId {
+		v.add("other_user_id", "must be different from actor_user_id")
+	}
+	return v.err()
+}
+
+// ValidateBlockUserRequest validates requests of BlockUser
+func (r *RequestValidator) ValidateBlockUserRequest(req *pb.BlockUserRequest) error {
+	return r.validateBlockPair(req.BlockerUserId, req.BlockedUserId)
+}
+
+// ValidateUnblockUserRequest validates requests of UnblockUser
+func (r *RequestValidator) ValidateUnblockUserRequest(req *pb.UnblockUserRequest) error {
+	return r.validateBlockPair(req.BlockerUserId, req.BlockedUserId)
+}
+
+func (r *RequestValidator) validateBlockPair(blockerID, blockedID string) error {
+	var v violations
+	r.checkUserID(&v, "blocker_user_id", blockerID)
+	r.checkUserID(&v, "blocked_user_id", blockedID)
+	if blockerID != "" && blockerID == blockedID {
+		v.add("blocked_user_id", "must be different from blocker_user_id")
+	}
+	return v.err()
+}
+
+// ValidateListBlockedRequest validates requests of ListBlocked
+func (r *RequestValidator) ValidateListBlockedRequest(req *pb.ListBlockedRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	return v.err()
+}
+
+// ValidateReportUserRequest validates requests of ReportUser
+func (r *RequestValidator) ValidateReportUserRequest(req *pb.ReportUserRequest) error {
+	var v violations
+	r.checkUserID(&v, "reporter_user_id", req.ReporterUserId)
+	r.checkUserID(&v, "reported_user_id", req.ReportedUserId)
+	if req.ReporterUserId != "" && req.ReporterUserId == req.ReportedUserId {
+		v.add("reported_user_id", "must be different from reporter_user_id")
+	}
+	if _, ok := pb.ReportReason_name[int32(req.Reason)]; !ok || req.Reason == pb.ReportReason_REPORT_REASON_UNSPECIFIED {
+		v.add("reason", "must be a known reason")
+	}
+	r.checkReportText(&v, "details", req.Details)
+	return v.err()
+}
+
+// ValidateListReportsRequest validates requests of ListReports
+func (r *RequestValidator) ValidateListReportsRequest(req *pb.ListReportsRequest) error {
+	var v violations
+	if _, ok := pb.ReportStatus_name[int32(req.Status)]; !ok {
+		v.add("status", "unknown value %d", req.Status)
+	}
+	r.checkPagination(&v, req.PageSize, req.PaginationToken)
+	r.checkSortOrder(&v, req.SortOrder)
+	return v.err()
+}
+
+// ValidateClaimReportRequest validates requests of ClaimReport
+func (r *RequestValidator) ValidateClaimReportRequest(req *pb.ClaimReportRequest) error {
+	var v violations
+	if req.ReportId == 0 {
+		v.add("report_id", "must not be empty")
+	}
+	r.checkModeratorID(&v, req.ModeratorId)
+	return v.err()
+}
+
+// ValidateResolveReportRequest validates requests of ResolveReport
+func (r *RequestValidator) ValidateResolveReportRequest(req *pb.ResolveReportRequest) error {
+	var v violations
+	if req.ReportId == 0 {
+		v.add("report_id", "must not be empty")
+	}
+	r.checkModeratorID(&v, req.ModeratorId)
+	if _, ok := pb.ReportOutcome_name[int32(req.Outcome)]; !ok || req.Outcome == pb.ReportOutcome_REPORT_OUTCOME_UNSPECIFIED {
+		v.add("outcome", "must be a known outcome")
+	}
+	r.checkReportText(&v, "note", req.Note)
+	return v.err()
+}
+
+// ValidateDeleteUserRequest validates requests of DeleteUser
+func (r *RequestValidator) ValidateDeleteUserRequest(req *pb.DeleteUserRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	return v.err()
+}
+
+// ValidateGetUserDeletionRequest validates requests of GetUserDeletion
+func (r *RequestValidator) ValidateGetUserDeletionRequest(req *pb.GetUserDeletionRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	return v.err()
+}
+
+// ValidateExportUserDataRequest validates requests of ExportUserData
+func (r *RequestValidator) ValidateExportUserDataRequest(req *pb.ExportUserDataRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	return v.err()
+}
+
+// ValidateWatchLikesRequest validates requests of WatchLikes
+func (r *RequestValidator) ValidateWatchLikesRequest(req *pb.WatchLikesRequest) error {
+	var v violations
+	r.checkUserID(&v, "user_id", req.UserId)
+	if req.ResumeToken != nil && len(*req.ResumeToken) > maxPaginationTokenLength {
+		v.add("resume_token", "must be at most %d characters long", maxPaginationTokenLength)
+	}
+	return v.err()
+}
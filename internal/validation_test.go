@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/benrod407/explore-service/explore_service_proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	validActor     = "0b9e5c1e-6f4a-4c1e-9a57-2f1d3b6c7a01"
+	validRecipient = "5d2f8a90-1c3b-4e7d-8f6a-9b0c1d2e3f40"
+)
+
+// fieldViolationsOf returns the BadRequest field violations of a gRPC status error, keyed by field
+func fieldViolationsOf(t *testing.T, err error) map[string]string {
+	violations := make(map[string]string)
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				violations[violation.Field] = violation.Description
+			}
+		}
+	}
+	return violations
+}
+
+func TestValidatePutDecisionRequest(t *testing.T) {
+	validator := NewRequestValidator(DefaultValidationConfig())
+
+	testCases := []struct {
+		name            string
+		req             *pb.PutDecisionRequest
+		violatingFields []string
+	}{
+		{
+			name: "valid",
+			req:  &pb.PutDecisionRequest{ActorUserId: validActor, RecipientUserId: validRecipient},
+		},
+		{
+			name:            "empty ids",
+			req:             &pb.PutDecisionRequest{},
+			violatingFields: []string{"actor_user_id", "recipient_user_id"},
+		},
+		{
+			name:            "self like",
+			req:             &pb.PutDecisionRequest{ActorUserId: validActor, RecipientUserId: validActor, LikedRecipient: true},
+			violatingFields: []string{"recipient_user_id"},
+		},
+		{
+			name:            "not a uuid",
+			req:             &pb.PutDecisionRequest{ActorUserId: "1", RecipientUserId: validRecipient},
+			violatingFields: []string{"actor_user_id"},
+		},
+		{
+			name:            "uppercase uuid",
+			req:             &pb.PutDecisionRequest{ActorUserId: validActor, RecipientUserId: "5D2F8A90-1C3B-4E7D-8F6A-9B0C1D2E3F40"},
+			violatingFields: []string{"recipient_user_id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.ValidatePutDecisionRequest(tc.req)
+			if len(tc.violatingFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidArgument)
+			statusErr := toStatusError(err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(statusErr))
+			assert.Equal(t, ReasonInvalidRequest, errorInfoOf(t, statusErr).Reason)
+
+			violations := fieldViolationsOf(t, statusErr)
+			assert.Len(t, violations, len(tc.violatingFields))
+			for _, field := range tc.violatingFields {
+				assert.Contains(t, violations, field)
+			}
+		})
+	}
+}
+
+func TestValidateListLikedYouRequest_PageSizeBound(t *testing.T) {
+	validator := NewRequestValidator(ValidationConfig{MaxPageSize: 50, RequireUUIDs: true})
+
+	pageSize := uint32(50)
+	require.NoError(t, validator.ValidateListLikedYouRequest(&pb.ListLikedYouRequest{
+		RecipientUserId: validRecipient,
+		PageSize:        &pageSize,
+	}))
+
+	pageSize = 51
+	err := validator.ValidateListLikedYouRequest(&pb.ListLikedYouRequest{
+		RecipientUserId: validRecipient,
+		PageSize:        &pageSize,
+		SortOrder:       pb.SortOrder(42),
+	})
+	violations := fieldViolationsOf(t, toStatusError(err))
+	assert.Contains(t, violations, "page_size")
+	assert.Contains(t, violations, "sort_order")
+}
+
+func TestValidateBatchPutDecisionRequest(t *testing.T) {
+	validator := NewRequestValidator(ValidationConfig{MaxBatchSize: 2, RequireUUIDs: true})
+	decision := func(recipientID string) *pb.BatchPutDecisionRequest_Decision {
+		return &pb.BatchPutDecisionRequest_Decision{RecipientUserId: recipientID, LikedRecipient: true}
+	}
+
+	require.NoError(t, validator.ValidateBatchPutDecisionRequest(&pb.BatchPutDecisionRequest{
+		ActorUserId: validActor,
+		Decisions:   []*pb.BatchPutDecisionRequest_Decision{decision(validRecipient), decision(validRecipient)},
+	}))
+
+	err := validator.ValidateBatchPutDecisionRequest(&pb.BatchPutDecisionRequest{
+		ActorUserId: validActor,
+		Decisions:   []*pb.BatchPutDecisionRequest_Decision{decision(validRecipient), decision(validActor)},
+	})
+	assert.Equal(t, map[string]string{
+		"decisions[1].recipient_user_id": "must be different from actor_user_id",
+	}, fieldViolationsOf(t, toStatusError(err)))
+
+	err = validator.ValidateBatchPutDecisionRequest(&pb.BatchPutDecisionRequest{
+		ActorUserId: validActor,
+		Decisions:   []*pb.BatchPutDecisionRequest_Decision{decision(validRecipient), decision(validRecipient), decision(validRecipient)},
+	})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "decisions")
+
+	err = validator.ValidateBatchPutDecisionRequest(&pb.BatchPutDecisionRequest{ActorUserId: validActor})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "decisions")
+}
+
+func TestValidateBatchGetDecisionRequest(t *testing.T) {
+	validator := NewRequestValidator(ValidationConfig{MaxBatchSize: 2, RequireUUIDs: true})
+
+	require.NoError(t, validator.ValidateBatchGetDecisionRequest(&pb.BatchGetDecisionRequest{
+		ActorUserId:      validActor,
+		RecipientUserIds: []string{validRecipient, validRecipient},
+	}))
+
+	err := validator.ValidateBatchGetDecisionRequest(&pb.BatchGetDecisionRequest{
+		ActorUserId:      validActor,
+		RecipientUserIds: []string{validRecipient, "not-a-uuid"},
+	})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "recipient_user_ids[1]")
+
+	err = validator.ValidateBatchGetDecisionRequest(&pb.BatchGetDecisionRequest{
+		ActorUserId:      validActor,
+		RecipientUserIds: []string{validRecipient, validRecipient, validRecipient},
+	})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "recipient_user_ids")
+
+	err = validator.ValidateBatchGetDecisionRequest(&pb.BatchGetDecisionRequest{ActorUserId: validActor})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "recipient_user_ids")
+}
+
+func TestValidateListMyDecisionsRequest(t *testing.T) {
+	validator := NewRequestValidator(ValidationConfig{MaxPageSize: 100, RequireUUIDs: true})
+
+	require.NoError(t, validator.ValidateListMyDecisionsRequest(&pb.ListMyDecisionsRequest{
+		ActorUserId:  validActor,
+		DecisionType: pb.DecisionType_DECISION_TYPE_LIKE,
+	}))
+
+	for _, decisionType := range []pb.DecisionType{pb.DecisionType_DECISION_TYPE_UNSPECIFIED, pb.DecisionType(42)} {
+		err := validator.ValidateListMyDecisionsRequest(&pb.ListMyDecisionsRequest{
+			ActorUserId:  validActor,
+			DecisionType: decisionType,
+		})
+		assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "decision_type")
+	}
+}
+
+func TestValidateLikedYouTimeWindow(t *testing.T) {
+	validator := NewRequestValidator(ValidationConfig{MaxPageSize: 100, RequireUUIDs: true})
+	since, until := uint64(1700086400), uint64(1700000000)
+
+	require.NoError(t, validator.ValidateListLikedYouRequest(&pb.ListLikedYouRequest{
+		RecipientUserId:    validRecipient,
+		SinceUnixTimestamp: &since,
+	}))
+
+	err := validator.ValidateListLikedYouRequest(&pb.ListLikedYouRequest{
+		RecipientUserId:    validRecipient,
+		SinceUnixTimestamp: &since,
+		UntilUnixTimestamp: &until,
+	})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "until_unix_timestamp")
+
+	zero := uint64(0)
+	err = validator.ValidateCountLikedYouRequest(&pb.CountLikedYouRequest{
+		RecipientUserId:    validRecipient,
+		UntilUnixTimestamp: &zero,
+	})
+	assert.Contains(t, fieldViolationsOf(t, toStatusError(err)), "until_unix_timestamp")
+}
+
+func TestValidateReportRequests(t *testing.T) {
+	validator := NewRequestValidator(DefaultValidationConfig())
+
+	err := validator.ValidateReportUserRequest(&pb.ReportUserRequest{
+		ReporterUserId: validActor,
+		ReportedUserId: validActor,
+		Details:        strings.Repeat("é", maxReportTextLength+1),
+	})
+	assert.Equal(t, map[string]string{
+		"reported_user_id": "must be different from reporter_user_id",
+		"reason":           "must be a known reason",
+		"details":          "must be at most 1000 characters long",
+	}, fieldViolationsOf(t, toStatusError(err)))
+
+	// details are counted in characters, like the VARCHAR column
+	require.NoError(t, validator.ValidateReportUserRequest(&pb.ReportUserRequest{
+		ReporterUserId: validActor,
+		ReportedUserId: validRecipient,
+		Reason:         pb.ReportReason_REPORT_REASON_SPAM,
+		Details:        strings.Repeat("é", maxReportTextLength),
+	}))
+
+	err = validator.ValidateResolveReportRequest(&pb.ResolveReportRequest{ModeratorId: "mod 1"})
+	assert.Equal(t, map[string]string{
+		"report_id":    "must not be empty",
+		"moderator_id": "must only contain printable ASCII characters without spaces",
+		"outcome":      "must be a known outcome",
+	}, fieldViolationsOf(t, toStatusError(err)))
+}
+
+func TestPutDecision_InvalidRequestNeverReachesTheStore(t *testing.T) {
+	_, mock, service, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	_, err := service.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     "actor1",
+		RecipientUserId: "actor1",
+		LikedRecipient:  true,
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook call
+const (
+	WebhookSignatureHeader = "X-Explore-Signature" // t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">
+	WebhookEventIDHeader   = "X-Explore-Event-Id"  // outbox event id, receivers can use it to drop duplicates
+)
+
+// maxWebhookErrorLength bounds the last error kept on a delivery
+const maxWebhookErrorLength = 1024
+
+// WebhookDelivery is the call of a MatchCreated event to one webhook endpoint
+type WebhookDelivery struct {
+	ID            uint64
+	Endpoint      string
+	EventID       uint64
+	Body          json.RawMessage // the OutboxEvent as JSON, signed and posted as is
+	Attempts      int             // failed attempts so far
+	LastError     string
+	UnixTimestamp uint64 // enqueue time
+}
+
+// WebhookConfig holds the endpoints and retry settings of the match webhooks
+type WebhookConfig struct {
+	Endpoints      []string
+	Secret         []byte        // HMAC key shared with the receivers
+	Timeout        time.Duration // per call, a delivery is not claimed again by another dispatcher before twice this
+	MaxAttempts    int           // failed attempts before a delivery becomes a dead letter
+	InitialBackoff time.Duration // delay after the first failure, doubled after each one
+	MaxBackoff     time.Duration
+	PollInterval   time.Duration // delay between polls when no delivery is due
+	BatchSize      int           // deliveries claimed and called concurrently per poll
+}
+
+// DefaultWebhookConfig returns the settings used when nothing is configured, without any endpoint
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Timeout:        5 * time.Second,
+		MaxAttempts:    8,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+		PollInterval:   time.Second,
+		BatchSize:      20,
+	}
+}
+
+// backoff returns the delay before the next attempt of a delivery that already failed attempts times
+func (c WebhookConfig) backoff(attempts int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxBackoff)
+}
+
+// SignWebhookPayload returns the signature header value of a webhook body sent at timestamp
+func SignWebhookPayload(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// VerifyWebhookSignature checks a signature header against the received body, signatures older than
+// tolerance are rejected so a captured call can't be replayed later
+func VerifyWebhookSignature(secret []byte, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp int64
+	var signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.New("malformed signature timestamp")
+			}
+			timestamp = parsed
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == 0 || signature == "" {
+		return errors.New("malformed signature header")
+	}
+
+	expected := SignWebhookPayload(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(fmt.Sprintf("t=%d,v1=%s", timestamp, signature))) {
+		return errors.New("signature mismatch")
+	}
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return errors.New("signature timestamp out of tolerance")
+	}
+	return nil
+}
+
+// WebhookSink is the EventSink enqueuing a webhook delivery per endpoint for every MatchCreated event.
+// Calls are made later by WebhookDispatcher, so slow receivers hold neither decisions nor the outbox relay
+type WebhookSink struct {
+	store     WebhookStore
+	endpoints []string
+}
+
+// NewWebhookSink creates a sink enqueuing the deliveries in the store
+func NewWebhookSink(store WebhookStore, endpoints []string) *WebhookSink {
+	return &WebhookSink{store: store, endpoints: endpoints}
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event OutboxEvent) error {
+	if event.Type != EventTypeMatchCreated {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding webhook body: %w", err)
+	}
+
+	deliveries := make([]WebhookDelivery, 0, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		deliveries = append(deliveries, WebhookDelivery{Endpoint: endpoint, EventID: event.ID, Body: body})
+	}
+	return s.store.EnqueueWebhookDeliveries(ctx, deliveries)
+}
+
+// WebhookDispatcher calls the webhook endpoints for the enqueued deliveries. A failed call is retried
+// with exponential backoff, calls rejected with a client error other than 408 and 429 are not retried.
+// Deliveries that run out of attempts stay in the store as dead letters until they are requeued
+type WebhookDispatcher struct {
+	store  WebhookStore
+	config WebhookConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWebhookDispatcher creates a dispatcher for the deliveries of the store
+func NewWebhookDispatcher(store WebhookStore, config WebhookConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		store:  store,
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}
+}
+
+// Run dispatches the deliveries until ctx is done
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches are due, wait for the next tick otherwise
+		claimed, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("error dispatching webhooks: %v", err)
+		}
+		if err == nil && claimed == d.config.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce calls the endpoints of a single batch of due deliveries and returns how many were claimed
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.config.BatchSize, 2*d.config.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make([]error, len(deliveries))
+	var wg sync.WaitGroup
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.dispatch(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// dispatch makes one attempt of the delivery and records its outcome
+func (d *WebhookDispatcher) dispatch(ctx context.Context, delivery WebhookDelivery) error {
+	callErr := d.call(ctx, delivery)
+	if callErr == nil {
+		return d.store.CompleteWebhookDelivery(ctx, delivery.ID)
+	}
+	if ctx.Err() != nil {
+		// shutting down, the lease expires and the attempt is made again
+		return nil
+	}
+
+	attempts := delivery.Attempts + 1
+	dead := attempts >= d.config.MaxAttempts || isPermanentWebhookError(callErr)
+	lastError := callErr.Error()
+	if len(lastError) > maxWebhookErrorLength {
+		lastError = lastError[:maxWebhookErrorLength]
+	}
+	if dead {
+		log.Printf("webhook delivery %d of event %d to %s dead lettered after %d attempts: %v",
+			delivery.ID, delivery.EventID, delivery.Endpoint, attempts, callErr)
+	}
+	return d.store.FailWebhookDelivery(ctx, delivery.ID, lastError, d.config.backoff(attempts), dead)
+}
+
+// webhookStatusError is a call answered with a non 2xx status
+type webhookStatusError struct {
+	status int
+}
+
+func (e *webhookStatusError) Error() string {
+	return fmt.Sprintf("endpoint answered %d %s", e.status, http.StatusText(e.status))
+}
+
+// isPermanentWebhookError reports if retrying the call can't succeed
+func isPermanentWebhookError(err error) bool {
+	var statusErr *webhookStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.status >= 400 && statusErr.status < 500 &&
+		statusErr.status != http.StatusRequestTimeout && statusErr.status != http.StatusTooManyRequests
+}
+
+// call posts the signed body to the endpoint
+func (d *WebhookDispatcher) call(ctx context.Context, delivery WebhookDelivery) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Endpoint, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventIDHeader, strconv.FormatUint(delivery.EventID, 10))
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(d.config.Secret, d.now().Unix(), delivery.Body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &webhookStatusError{status: response.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testWebhookSecret = []byte("webhook-secret")
+
+// webhookReceiver is an endpoint answering status and keeping the bodies with a valid signature
+type webhookReceiver struct {
+	mu     sync.Mutex
+	status int
+	bodies []OutboxEvent
+}
+
+func newWebhookReceiver(t *testing.T) (*webhookReceiver, string) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := VerifyWebhookSignature(testWebhookSecret, r.Header.Get(WebhookSignatureHeader), body, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		if receiver.status == http.StatusOK {
+			var event OutboxEvent
+			_ = json.Unmarshal(body, &event)
+			receiver.bodies = append(receiver.bodies, event)
+		}
+		w.WriteHeader(receiver.status)
+	}))
+	t.Cleanup(server.Close)
+	return receiver, server.URL
+}
+
+func (r *webhookReceiver) answer(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) received() []OutboxEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]OutboxEvent(nil), r.bodies...)
+}
+
+func testWebhookConfig(endpoints ...string) WebhookConfig {
+	config := DefaultWebhookConfig()
+	config.Endpoints = endpoints
+	config.Secret = testWebhookSecret
+	config.MaxAttempts = 3
+	return config
+}
+
+func TestWebhooks_MatchesAreSignedAndDeliveredToEveryEndpoint(t *testing.T) {
+	ctx := context.Background()
+	store, business := setupMemoryBusiness(t, "a", "b", "c")
+	first, firstURL := newWebhookReceiver(t)
+	second, secondURL := newWebhookReceiver(t)
+	config := testWebhookConfig(firstURL, secondURL)
+
+	relay := NewOutboxRelay(store, NewWebhookSink(store, config.Endpoints), DefaultOutboxRelayConfig())
+	dispatcher := NewWebhookDispatcher(store, config)
+
+	_, err := business.RecordDecision(ctx, "a", "b", true)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "c", "b", false)
+	require.NoError(t, err)
+	_, err = business.RecordDecision(ctx, "b", "a", true) // match
+	require.NoError(t, err)
+
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	claimed, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+
+	for _, receiver := range []*webhookReceiver{first, second} {
+		bodies := receiver.received()
+		require.Len(t, bodies, 1)
+		assert.Equal(t, EventTypeMatchCreated, bodies[0].Type)
+		assert.Equal(t, "a", bodies[0].Key)
+	}
+
+	// delivered calls are not made again
+	claimed, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+}
+
+func TestWebhookSink_EnqueuesEachEventOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	sink := NewWebhookSink(store, []string{"http://receiver.test/hook"})
+
+	event := OutboxEvent{ID: 3, Type: EventTypeMatchCreated, Key: "a", Payload: json.RawMessage(`{}`)}
+	require.NoError(t, sink.Publish(ctx, event))
+	require.NoError(t, sink.Publish(ctx, event)) // relayed again after a crash
+
+	deliveries, err := store.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, uint64(3), deliveries[0].EventID)
+}
+
+func TestWebhookDispatcher_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+
+	receiver, url := newWebhookReceiver(t)
+	receiver.answer(http.StatusServiceUnavailable)
+	config := testWebhookConfig(url)
+	dispatcher := NewWebhookDispatcher(store, config)
+	require.NoError(t, NewWebhookSink(store, config.Endpoints).Publish(ctx, OutboxEvent{ID: 1, Type: EventTypeMatchCreated}))
+
+	// attempts 1 and 2 fail and are retried after 1s and then 2s
+	for _, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+		claimed, err := dispatcher.DispatchOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+
+		now = now.Add(backoff - time.Millisecond)
+		claimed, err = dispatcher.DispatchOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, claimed, "retried before the backoff elapsed")
+		now = now.Add(time.Millisecond)
+	}
+
+	// the last attempt fails, the delivery becomes a dead letter
+	_, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	claimed, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+
+	deadLetters, err := store.ListWebhookDeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Contains(t, deadLetters[0].LastError, "503")
+
+	// replayed once the receiver is back
+	receiver.answer(http.StatusOK)
+	requeued, err := store.RequeueWebhookDeadLetters(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+
+	claimed, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Len(t, receiver.received(), 1)
+
+	deadLetters, err = store.ListWebhookDeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestWebhookDispatcher_ClientErrorsAreNotRetried(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	receiver, url := newWebhookReceiver(t)
+	receiver.answer(http.StatusGone)
+	config := testWebhookConfig(url)
+	require.NoError(t, NewWebhookSink(store, config.Endpoints).Publish(ctx, OutboxEvent{ID: 1, Type: EventTypeMatchCreated}))
+
+	_, err := NewWebhookDispatcher(store, config).DispatchOnce(ctx)
+	require.NoError(t, err)
+
+	deadLetters, err := store.ListWebhookDeadLetters(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 1, deadLetters[0].Attempts)
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	sentAt := time.Unix(1700000000, 0)
+	header := SignWebhookPayload(testWebhookSecret, sentAt.Unix(), body)
+
+	assert.NoError(t, VerifyWebhookSignature(testWebhookSecret, header, body, sentAt.Add(time.Minute), 5*time.Minute))
+	assert.Error(t, VerifyWebhookSignature(testWebhookSecret, header, []byte(`{"id":2}`), sentAt, 5*time.Minute), "tampered body")
+	assert.Error(t, VerifyWebhookSignature([]byte("other-secret"), header, body, sentAt, 5*time.Minute), "wrong secret")
+	assert.Error(t, VerifyWebhookSignature(testWebhookSecret, header, body, sentAt.Add(time.Hour), 5*time.Minute), "replayed")
+	assert.Error(t, VerifyWebhookSignature(testWebhookSecret, "v1=deadbeef", body, sentAt, 5*time.Minute), "no timestamp")
+}
+
+func TestClaimWebhookDeliveries_MySQLSkipsLockedAndLeases(t *testing.T) {
+	db, mock, _, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM webhook_delivery\s+WHERE dead_at IS NULL\s+AND next_attempt_at <= CURRENT_TIMESTAMP\(3\)\s+ORDER BY next_attempt_at\s+LIMIT \?\s+FOR UPDATE SKIP LOCKED`).
+		WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "endpoint", "event_id", "body", "attempts", "last_error", "unix_timestamp"}).
+			AddRow(7, "https://receiver.test/hook", 42, []byte(`{}`), 1, "endpoint answered 500", 1700000000))
+	mock.ExpectExec(`UPDATE webhook_delivery\s+SET next_attempt_at = CURRENT_TIMESTAMP\(3\) \+ INTERVAL \? MICROSECOND\s+WHERE id IN \(\?\)`).
+		WithArgs(int64(10_000_000), 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	deliveries, err := NewMySQLStore(&DB{db}).ClaimWebhookDeliveries(context.Background(), 20, 10*time.Second)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, WebhookDelivery{
+		ID:            7,
+		Endpoint:      "https://receiver.test/hook",
+		EventID:       42,
+		Body:          json.RawMessage(`{}`),
+		Attempts:      1,
+		LastError:     "endpoint answered 500",
+		UnixTimestamp: 1700000000,
+	}, deliveries[0])
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
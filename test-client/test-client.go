@@ -23,8 +23,18 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// users from db/02-data.sql
+	const (
+		lily      = "11111111-1111-4111-8111-111111111111"
+		matt      = "22222222-2222-4222-8222-222222222222"
+		kevin     = "33333333-3333-4333-8333-333333333333"
+		alice     = "44444444-4444-4444-8444-444444444444"
+		anna      = "55555555-5555-4555-8555-555555555555"
+		sebastian = "66666666-6666-4666-8666-666666666666"
+	)
+
 	allTestUsers := []string{
-		"1", "2", "3", "4", "5", "6",
+		lily, matt, kevin, alice, anna, sebastian,
 	}
 
 	usedPageSize := uint32(2)
@@ -37,28 +47,28 @@ func main() {
 	log.Println("=======================================================================================")
 	log.Println("==================== Test ListLikeYou and ListNewLikeYou endpoints ====================")
 
-	listLikeYouCall(ctx, "3", usedPageSize, c)
+	listLikeYouCall(ctx, kevin, usedPageSize, c)
 
-	listLikeYouCall(ctx, "5", usedPageSize, c)
+	listLikeYouCall(ctx, anna, usedPageSize, c)
 
-	listLikeYouCall(ctx, "5", 1, c)
+	listLikeYouCall(ctx, anna, 1, c)
 
-	listNewLikeYouCall(ctx, "1", usedPageSize, c)
+	listNewLikeYouCall(ctx, lily, usedPageSize, c)
 
 	log.Println("==================================================================")
 	log.Println("==================== Test CountLiked endpoint ====================")
 
-	putDecisionCall(ctx, "5", "1", false, c)
-	putDecisionCall(ctx, "6", "1", false, c)
-	checkCurrentLikeCount(ctx, []string{"1"}, c)
-	listLikeYouCall(ctx, "1", usedPageSize, c)
-	putDecisionCall(ctx, "5", "1", true, c)
-	checkCurrentLikeCount(ctx, []string{"1"}, c)
-	putDecisionCall(ctx, "5", "1", true, c) // adding same like twice
-	checkCurrentLikeCount(ctx, []string{"1"}, c)
-	putDecisionCall(ctx, "6", "1", true, c)
-	checkCurrentLikeCount(ctx, []string{"1"}, c)
-	listLikeYouCall(ctx, "1", usedPageSize, c)
+	putDecisionCall(ctx, anna, lily, false, c)
+	putDecisionCall(ctx, sebastian, lily, false, c)
+	checkCurrentLikeCount(ctx, []string{lily}, c)
+	listLikeYouCall(ctx, lily, usedPageSize, c)
+	putDecisionCall(ctx, anna, lily, true, c)
+	checkCurrentLikeCount(ctx, []string{lily}, c)
+	putDecisionCall(ctx, anna, lily, true, c) // adding same like twice
+	checkCurrentLikeCount(ctx, []string{lily}, c)
+	putDecisionCall(ctx, sebastian, lily, true, c)
+	checkCurrentLikeCount(ctx, []string{lily}, c)
+	listLikeYouCall(ctx, lily, usedPageSize, c)
 
 	log.Println("================================================================================")
 	log.Println("==================== Check current total likes of all users ====================")